@@ -1,7 +1,9 @@
 package tfe
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
@@ -21,6 +23,9 @@ type Applies interface {
 
 	// Logs retrieves the logs of an apply.
 	Logs(ctx context.Context, applyID string) (io.Reader, error)
+
+	// ResourceChanges retrieves the outcome of the apply for each resource.
+	ResourceChanges(ctx context.Context, applyID string) ([]*AppliedResourceChange, error)
 }
 
 // applies implements Applys.
@@ -44,6 +49,17 @@ const (
 	ApplyUnreachable ApplyStatus = "unreachable"
 )
 
+// IsTerminal returns true if the apply status is a final state, after which
+// the apply will not change status anymore.
+func (s ApplyStatus) IsTerminal() bool {
+	switch s {
+	case ApplyCanceled, ApplyErrored, ApplyFinished, ApplyUnreachable:
+		return true
+	default:
+		return false
+	}
+}
+
 // Apply represents a Terraform Enterprise apply.
 type Apply struct {
 	ID                   string                 `jsonapi:"primary,applies"`
@@ -51,10 +67,37 @@ type Apply struct {
 	ResourceAdditions    int                    `jsonapi:"attr,resource-additions"`
 	ResourceChanges      int                    `jsonapi:"attr,resource-changes"`
 	ResourceDestructions int                    `jsonapi:"attr,resource-destructions"`
+	ResourceImports      int                    `jsonapi:"attr,resource-imports"`
 	Status               ApplyStatus            `jsonapi:"attr,status"`
 	StatusTimestamps     *ApplyStatusTimestamps `jsonapi:"attr,status-timestamps"`
 }
 
+// AppliedResourceChangeAction represents the action taken on a resource.
+type AppliedResourceChangeAction string
+
+// List all available applied resource change actions.
+const (
+	AppliedResourceCreate AppliedResourceChangeAction = "create"
+	AppliedResourceDelete AppliedResourceChangeAction = "delete"
+	AppliedResourceRead   AppliedResourceChangeAction = "read"
+	AppliedResourceUpdate AppliedResourceChangeAction = "update"
+)
+
+// AppliedResourceChange represents the outcome of an apply for a single
+// resource.
+type AppliedResourceChange struct {
+	Address      string
+	Module       string
+	ResourceType string
+	ResourceName string
+	Action       AppliedResourceChangeAction
+	IDKey        string
+	IDValue      string
+
+	// Errored is true when the change failed to apply.
+	Errored bool
+}
+
 // ApplyStatusTimestamps holds the timestamps for individual apply statuses.
 type ApplyStatusTimestamps struct {
 	CanceledAt      *time.Time `json:"canceled-at,rfc3339,omitempty"`
@@ -114,12 +157,7 @@ func (s *applies) Logs(ctx context.Context, applyID string) (io.Reader, error) {
 			return false, err
 		}
 
-		switch a.Status {
-		case ApplyCanceled, ApplyErrored, ApplyFinished, ApplyUnreachable:
-			return true, nil
-		default:
-			return false, nil
-		}
+		return a.Status.IsTerminal(), nil
 	}
 
 	return &LogReader{
@@ -129,3 +167,74 @@ func (s *applies) Logs(ctx context.Context, applyID string) (io.Reader, error) {
 		logURL: u,
 	}, nil
 }
+
+// ResourceChanges retrieves the outcome of the apply for each resource, in the
+// order they completed. It reads the structured logs of the apply and so waits
+// for the apply to finish. ErrUnstructuredLogs is returned when the workspace
+// doesn't have structured run output enabled.
+func (s *applies) ResourceChanges(ctx context.Context, applyID string) ([]*AppliedResourceChange, error) {
+	logs, err := s.Logs(ctx, applyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAppliedResourceChanges(logs)
+}
+
+// parseAppliedResourceChanges parses the resource changes out of the
+// structured logs of an apply.
+func parseAppliedResourceChanges(logs io.Reader) ([]*AppliedResourceChange, error) {
+	type message struct {
+		Type string `json:"type"`
+		Hook struct {
+			Resource struct {
+				Addr         string `json:"addr"`
+				Module       string `json:"module"`
+				ResourceType string `json:"resource_type"`
+				ResourceName string `json:"resource_name"`
+			} `json:"resource"`
+			Action  AppliedResourceChangeAction `json:"action"`
+			IDKey   string                      `json:"id_key"`
+			IDValue string                      `json:"id_value"`
+		} `json:"hook"`
+	}
+
+	var changes []*AppliedResourceChange
+	var structured bool
+
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			// Skip any plain text lines, such as those logged before
+			// Terraform is started.
+			continue
+		}
+		structured = true
+
+		if msg.Type != "apply_complete" && msg.Type != "apply_errored" {
+			continue
+		}
+
+		changes = append(changes, &AppliedResourceChange{
+			Address:      msg.Hook.Resource.Addr,
+			Module:       msg.Hook.Resource.Module,
+			ResourceType: msg.Hook.Resource.ResourceType,
+			ResourceName: msg.Hook.Resource.ResourceName,
+			Action:       msg.Hook.Action,
+			IDKey:        msg.Hook.IDKey,
+			IDValue:      msg.Hook.IDValue,
+			Errored:      msg.Type == "apply_errored",
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !structured {
+		return nil, ErrUnstructuredLogs
+	}
+
+	return changes, nil
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"strconv"
 	"testing"
 	"time"
 
@@ -70,6 +72,76 @@ func TestAppliesLogs(t *testing.T) {
 	})
 }
 
+func TestAppliesResourceChanges(t *testing.T) {
+	var logs string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/applies/apply-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"apply-123","type":"applies","attributes":{"status":"finished","log-read-url":"http://`+r.Host+`/logs"}}}`))
+		case "/logs":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			if offset < len(logs) {
+				checkedWrite(t, w, []byte(logs[offset:]))
+			}
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with structured logs", func(t *testing.T) {
+		logs = "\x02Terraform v1.1.0\n" +
+			`{"@level":"info","@message":"Terraform 1.1.0","type":"version","terraform":"1.1.0","ui":"1.0"}` + "\n" +
+			`{"@level":"info","@message":"null_resource.foo: Creation complete after 0s [id=123]","type":"apply_complete","hook":{"resource":{"addr":"null_resource.foo","module":"","resource_type":"null_resource","resource_name":"foo"},"action":"create","id_key":"id","id_value":"123","elapsed_seconds":0}}` + "\n" +
+			`{"@level":"info","@message":"module.bar.null_resource.baz: Destruction complete after 0s","type":"apply_complete","hook":{"resource":{"addr":"module.bar.null_resource.baz","module":"module.bar","resource_type":"null_resource","resource_name":"baz"},"action":"delete","elapsed_seconds":0}}` + "\n" +
+			`{"@level":"error","@message":"null_resource.qux: Modifications errored after 0s","type":"apply_errored","hook":{"resource":{"addr":"null_resource.qux","module":"","resource_type":"null_resource","resource_name":"qux"},"action":"update","elapsed_seconds":0}}` + "\n" +
+			`{"@level":"info","@message":"Apply complete! Resources: 1 added, 0 changed, 1 destroyed.","type":"change_summary","changes":{"add":1,"change":0,"remove":1,"operation":"apply"}}` + "\n\x03"
+
+		changes, err := client.Applies.ResourceChanges(ctx, "apply-123")
+		require.NoError(t, err)
+		assert.Equal(t, []*AppliedResourceChange{
+			{
+				Address:      "null_resource.foo",
+				ResourceType: "null_resource",
+				ResourceName: "foo",
+				Action:       AppliedResourceCreate,
+				IDKey:        "id",
+				IDValue:      "123",
+			},
+			{
+				Address:      "module.bar.null_resource.baz",
+				Module:       "module.bar",
+				ResourceType: "null_resource",
+				ResourceName: "baz",
+				Action:       AppliedResourceDelete,
+			},
+			{
+				Address:      "null_resource.qux",
+				ResourceType: "null_resource",
+				ResourceName: "qux",
+				Action:       AppliedResourceUpdate,
+				Errored:      true,
+			},
+		}, changes)
+	})
+
+	t.Run("with unstructured logs", func(t *testing.T) {
+		logs = "\x02Terraform v1.1.0\nApply complete! Resources: 1 added, 0 changed, 0 destroyed.\n\x03"
+
+		changes, err := client.Applies.ResourceChanges(ctx, "apply-123")
+		assert.Nil(t, changes)
+		assert.Equal(t, ErrUnstructuredLogs, err)
+	})
+
+	t.Run("with invalid apply ID", func(t *testing.T) {
+		changes, err := client.Applies.ResourceChanges(ctx, badIdentifier)
+		assert.Nil(t, changes)
+		assert.EqualError(t, err, ErrInvalidApplyID.Error())
+	})
+}
+
 func TestApplies_Unmarshal(t *testing.T) {
 	data := map[string]interface{}{
 		"data": map[string]interface{}{
@@ -80,6 +152,7 @@ func TestApplies_Unmarshal(t *testing.T) {
 				"resource-additions":    1,
 				"resource-changes":      1,
 				"resource-destructions": 1,
+				"resource-imports":      2,
 				"status":                ApplyCanceled,
 				"status-timestamps": map[string]string{
 					"queued-at":  "2020-03-16T23:15:59+00:00",
@@ -106,7 +179,20 @@ func TestApplies_Unmarshal(t *testing.T) {
 	assert.Equal(t, apply.ResourceAdditions, 1)
 	assert.Equal(t, apply.ResourceChanges, 1)
 	assert.Equal(t, apply.ResourceDestructions, 1)
+	assert.Equal(t, apply.ResourceImports, 2)
 	assert.Equal(t, apply.Status, ApplyCanceled)
 	assert.Equal(t, apply.StatusTimestamps.QueuedAt, queuedParsedTime)
 	assert.Equal(t, apply.StatusTimestamps.ErroredAt, erroredParsedTime)
 }
+
+func TestApplyStatus_IsTerminal(t *testing.T) {
+	terminal := []ApplyStatus{ApplyCanceled, ApplyErrored, ApplyFinished, ApplyUnreachable}
+	nonTerminal := []ApplyStatus{ApplyCreated, ApplyMFAWaiting, ApplyPending, ApplyQueued, ApplyRunning}
+
+	for _, s := range terminal {
+		assert.True(t, s.IsTerminal(), "expected %s to be terminal", s)
+	}
+	for _, s := range nonTerminal {
+		assert.False(t, s.IsTerminal(), "expected %s not to be terminal", s)
+	}
+}
@@ -0,0 +1,221 @@
+package tfe
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ClientOption configures a client created with NewClientWithOptions.
+type ClientOption func(*Config) error
+
+// Logger is implemented by loggers the client can report to, such as the
+// *log.Logger of the standard library.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// NewClientWithOptions creates a new Terraform Enterprise API client
+// configured by the given options. Anything left unset takes the same
+// defaults as with NewClient, including the TFE_ADDRESS and TFE_TOKEN
+// environment variables.
+func NewClientWithOptions(options ...ClientOption) (*Client, error) {
+	config := &Config{}
+	for _, option := range options {
+		if err := option(config); err != nil {
+			return nil, err
+		}
+	}
+	return NewClient(config)
+}
+
+// WithAddress sets the address of the Terraform Enterprise API, which must be
+// an absolute HTTP or HTTPS URL.
+func WithAddress(address string) ClientOption {
+	return func(c *Config) error {
+		if _, err := parseAddress(address); err != nil {
+			return err
+		}
+		c.Address = address
+		return nil
+	}
+}
+
+// WithToken sets the API token used to access the Terraform Enterprise API.
+func WithToken(token string) ClientOption {
+	return func(c *Config) error {
+		if token == "" {
+			return ErrMissingToken
+		}
+		c.Token = token
+		return nil
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client to send the requests with.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Config) error {
+		if client == nil {
+			return fmt.Errorf("HTTP client is required")
+		}
+		c.HTTPClient = client
+		return nil
+	}
+}
+
+// WithRetry sets the maximum number of times a request is retried, and
+// whether unexpected errors and server errors are retried on top of rate
+// limited requests.
+func WithRetry(retryMax int, retryServerErrors bool) ClientOption {
+	return func(c *Config) error {
+		if retryMax < 1 {
+			return fmt.Errorf("invalid value for retry max: must be at least 1")
+		}
+		c.RetryMax = retryMax
+		c.RetryServerErrors = retryServerErrors
+		return nil
+	}
+}
+
+// WithRateLimit caps the number of requests the client sends per second.
+// The rate limit reported by the server is still applied when lower.
+func WithRateLimit(requestsPerSecond float64) ClientOption {
+	return func(c *Config) error {
+		if requestsPerSecond <= 0 {
+			return fmt.Errorf("invalid value for rate limit: must be greater than 0")
+		}
+		c.RateLimit = requestsPerSecond
+		return nil
+	}
+}
+
+// WithLogger reports each retried request to the given logger, along with
+// each request skipped in dry-run mode. It replaces any RetryLogHook.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Config) error {
+		if logger == nil {
+			return fmt.Errorf("logger is required")
+		}
+		c.Logger = logger
+		c.RetryLogHook = func(attemptNum int, resp *http.Response) {
+			if resp == nil || resp.Request == nil {
+				logger.Printf("[DEBUG] retrying request (attempt %d)", attemptNum)
+				return
+			}
+			logger.Printf("[DEBUG] retrying %s %s after status %d (attempt %d)",
+				resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, attemptNum)
+		}
+		return nil
+	}
+}
+
+// WithRetryPolicy replaces the default retry behavior with the given policy,
+// which decides whether and when each request is retried.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Config) error {
+		if policy == nil {
+			return fmt.Errorf("retry policy is required")
+		}
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the server's TLS
+// certificate chain and host name by the default HTTP client. It is ignored
+// when combined with WithHTTPClient.
+//
+// WARNING: this makes the client vulnerable to man-in-the-middle attacks and
+// must never be used against a production instance. It is only intended for
+// development instances using a self-signed certificate.
+func WithInsecureSkipVerify(skip bool) ClientOption {
+	return func(c *Config) error {
+		c.InsecureSkipVerify = skip
+		return nil
+	}
+}
+
+// WithHeader adds a header sent with every request, such as the key of an API
+// gateway fronting Terraform Enterprise. The Authorization, Accept and
+// Content-Type headers are set by the client and can't be overridden.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Config) error {
+		if key == "" {
+			return fmt.Errorf("header key is required")
+		}
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithDryRun makes the client only send GET requests. Any other request is
+// reported to the logger set with WithLogger and answered with a successful
+// response, or with the response returned by the DryRunFunc set with
+// WithDryRunFunc.
+func WithDryRun(dryRun bool) ClientOption {
+	return func(c *Config) error {
+		c.DryRun = dryRun
+		return nil
+	}
+}
+
+// WithDryRunFunc sets the function invoked with each request that is not sent
+// in dry-run mode, to log it or to provide its response.
+func WithDryRunFunc(fn DryRunFunc) ClientOption {
+	return func(c *Config) error {
+		if fn == nil {
+			return fmt.Errorf("dry-run func is required")
+		}
+		c.DryRunFunc = fn
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Config) error {
+		if userAgent == "" {
+			return fmt.Errorf("user agent is required")
+		}
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set("User-Agent", userAgent)
+		return nil
+	}
+}
+
+// WithSource sets the name and URL recorded as the source of the workspaces
+// created by the client, shown in the UI as "Created via <name>". The URL is
+// optional.
+func WithSource(name, sourceURL string) ClientOption {
+	return func(c *Config) error {
+		if name == "" {
+			return ErrRequiredName
+		}
+		if sourceURL != "" {
+			if _, err := url.ParseRequestURI(sourceURL); err != nil {
+				return fmt.Errorf("invalid value for source URL: %v", err)
+			}
+		}
+		c.SourceName = name
+		c.SourceURL = sourceURL
+		return nil
+	}
+}
+
+// parseAddress parses the address of the Terraform Enterprise API, making
+// sure it is an absolute HTTP or HTTPS URL.
+func parseAddress(address string) (*url.URL, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q is not an absolute HTTP or HTTPS URL", ErrInvalidAddress, address)
+	}
+	return u, nil
+}
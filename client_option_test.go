@@ -0,0 +1,165 @@
+package tfe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	var userAgent atomic.Value
+	var attempts int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent.Store(r.Header.Get("User-Agent"))
+		switch r.URL.Path {
+		case "/api/v2/ping":
+			w.Header().Set("X-RateLimit-Limit", "30")
+		case "/api/v2/workspaces/ws-123":
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces"}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	}))
+	defer ts.Close()
+
+	t.Run("with valid options", func(t *testing.T) {
+		var logs bytes.Buffer
+
+		client, err := NewClientWithOptions(
+			WithAddress(ts.URL),
+			WithToken("123"),
+			WithHTTPClient(ts.Client()),
+			WithRetry(5, true),
+			WithRateLimit(10),
+			WithLogger(log.New(&logs, "", 0)),
+			WithUserAgent("acme-automation/1.0"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "acme-automation/1.0", userAgent.Load())
+		assert.Equal(t, 5, client.http.RetryMax)
+		assert.Equal(t, rate.Limit(10), client.limiter.Limit())
+
+		w, err := client.Workspaces.ReadByID(context.Background(), "ws-123")
+		require.NoError(t, err)
+		assert.Equal(t, "ws-123", w.ID)
+		assert.Contains(t, logs.String(), "retrying GET /api/v2/workspaces/ws-123 after status 502 (attempt 0)")
+		assert.Contains(t, logs.String(), "(attempt 1)")
+	})
+
+	t.Run("keeps the lower rate limit of the server", func(t *testing.T) {
+		client, err := NewClientWithOptions(
+			WithAddress(ts.URL),
+			WithToken("123"),
+			WithHTTPClient(ts.Client()),
+			WithRateLimit(100),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, rate.Limit(30*0.66), client.limiter.Limit())
+	})
+
+	t.Run("with headers, a retry policy and dry-run mode", func(t *testing.T) {
+		var logs bytes.Buffer
+		var gatewayKey atomic.Value
+		var retries int32
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gatewayKey.Store(r.Header.Get("X-Gateway-Key"))
+			switch {
+			case r.URL.Path == "/api/v2/ping":
+			case r.Method == "GET" && r.URL.Path == "/api/v2/workspaces/ws-123":
+				checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces"}}`))
+			default:
+				assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+			}
+		}))
+		defer ts.Close()
+
+		client, err := NewClientWithOptions(
+			WithAddress(ts.URL),
+			WithToken("123"),
+			WithHTTPClient(ts.Client()),
+			WithHeader("X-Gateway-Key", "secret"),
+			WithRetryPolicy(func(req *http.Request, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+				atomic.AddInt32(&retries, 1)
+				return false, 0
+			}),
+			WithDryRun(true),
+			WithLogger(log.New(&logs, "", 0)),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Workspaces.ReadByID(context.Background(), "ws-123")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", gatewayKey.Load())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&retries))
+
+		err = client.Workspaces.DeleteByID(context.Background(), "ws-123")
+		require.NoError(t, err)
+		assert.Contains(t, logs.String(), "dry run: skipping DELETE /api/v2/workspaces/ws-123")
+	})
+
+	t.Run("with insecure skip verify", func(t *testing.T) {
+		client, err := NewClientWithOptions(
+			WithAddress(ts.URL),
+			WithToken("123"),
+			WithInsecureSkipVerify(true),
+		)
+		require.NoError(t, err)
+		transport, ok := client.http.HTTPClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+	})
+
+	t.Run("with invalid options", func(t *testing.T) {
+		for name, option := range map[string]ClientOption{
+			"address without a scheme":  WithAddress("app.terraform.io"),
+			"address with a bad scheme": WithAddress("ftp://app.terraform.io"),
+			"unparseable address":       WithAddress("https://app.terraform.io:port"),
+		} {
+			_, err := NewClientWithOptions(option)
+			assert.True(t, errors.Is(err, ErrInvalidAddress), name)
+		}
+
+		_, err := NewClientWithOptions(WithToken(""))
+		assert.Equal(t, ErrMissingToken, err)
+
+		_, err = NewClientWithOptions(WithHTTPClient(nil))
+		assert.EqualError(t, err, "HTTP client is required")
+
+		_, err = NewClientWithOptions(WithRetry(0, false))
+		assert.EqualError(t, err, "invalid value for retry max: must be at least 1")
+
+		_, err = NewClientWithOptions(WithRateLimit(0))
+		assert.EqualError(t, err, "invalid value for rate limit: must be greater than 0")
+
+		_, err = NewClientWithOptions(WithLogger(nil))
+		assert.EqualError(t, err, "logger is required")
+
+		_, err = NewClientWithOptions(WithUserAgent(""))
+		assert.EqualError(t, err, "user agent is required")
+
+		_, err = NewClientWithOptions(WithRetryPolicy(nil))
+		assert.EqualError(t, err, "retry policy is required")
+
+		_, err = NewClientWithOptions(WithHeader("", "value"))
+		assert.EqualError(t, err, "header key is required")
+
+		_, err = NewClientWithOptions(WithDryRunFunc(nil))
+		assert.EqualError(t, err, "dry-run func is required")
+	})
+}
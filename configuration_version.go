@@ -23,6 +23,10 @@ type ConfigurationVersions interface {
 	// List returns all configuration versions of a workspace.
 	List(ctx context.Context, workspaceID string, options ConfigurationVersionListOptions) (*ConfigurationVersionList, error)
 
+	// ListBySpeculative returns every configuration version of a workspace
+	// which is speculative, or which isn't, depending on speculative.
+	ListBySpeculative(ctx context.Context, workspaceID string, speculative bool) ([]*ConfigurationVersion, error)
+
 	// Create is used to create a new configuration version. The created
 	// configuration version will be usable once data is uploaded to it.
 	Create(ctx context.Context, workspaceID string, options ConfigurationVersionCreateOptions) (*ConfigurationVersion, error)
@@ -33,10 +37,22 @@ type ConfigurationVersions interface {
 	// ReadWithOptions reads a configuration version by its ID using the options supplied
 	ReadWithOptions(ctx context.Context, cvID string, options ConfigurationVersionReadOptions) (*ConfigurationVersion, error)
 
+	// ReadIngressAttributes reads the VCS commit information of a
+	// configuration version ingressed from VCS.
+	ReadIngressAttributes(ctx context.Context, cvID string) (*IngressAttributes, error)
+
+	// WaitForReady waits until a configuration version is uploaded or
+	// ingressed, and so ready to be used by runs.
+	WaitForReady(ctx context.Context, cvID string, options ConfigurationVersionWaitOptions) (*ConfigurationVersion, error)
+
 	// Upload packages and uploads Terraform configuration files. It requires
 	// the upload URL from a configuration version and the full path to the
 	// configuration files on disk.
 	Upload(ctx context.Context, url string, path string) error
+
+	// Download retrieves the uploaded configuration files of a configuration
+	// version, as a gzipped tarball.
+	Download(ctx context.Context, cvID string) ([]byte, error)
 }
 
 // configurationVersions implements ConfigurationVersions.
@@ -49,7 +65,9 @@ type ConfigurationStatus string
 
 //List all available configuration version statuses.
 const (
+	ConfigurationArchived ConfigurationStatus = "archived"
 	ConfigurationErrored  ConfigurationStatus = "errored"
+	ConfigurationFetching ConfigurationStatus = "fetching"
 	ConfigurationPending  ConfigurationStatus = "pending"
 	ConfigurationUploaded ConfigurationStatus = "uploaded"
 )
@@ -80,8 +98,9 @@ type ConfigurationVersion struct {
 	AutoQueueRuns    bool                `jsonapi:"attr,auto-queue-runs"`
 	Error            string              `jsonapi:"attr,error"`
 	ErrorMessage     string              `jsonapi:"attr,error-message"`
+	Provisional      bool                `jsonapi:"attr,provisional"`
 	Source           ConfigurationSource `jsonapi:"attr,source"`
-	Speculative      bool                `jsonapi:"attr,speculative "`
+	Speculative      bool                `jsonapi:"attr,speculative"`
 	Status           ConfigurationStatus `jsonapi:"attr,status"`
 	StatusTimestamps *CVStatusTimestamps `jsonapi:"attr,status-timestamps"`
 	UploadURL        string              `jsonapi:"attr,upload-url"`
@@ -110,7 +129,7 @@ type ConfigurationVersionListOptions struct {
 
 	// A list of relations to include. See available resources:
 	// https://www.terraform.io/docs/cloud/api/configuration-versions.html#available-related-resources
-	Include *string `schema:"include"`
+	Include *string `schema:"include,omitempty"`
 }
 
 // IngressAttributes include commit information associated with configuration versions sourced from VCS.
@@ -119,6 +138,7 @@ type IngressAttributes struct {
 	Branch            string `jsonapi:"attr,branch"`
 	CloneURL          string `jsonapi:"attr,clone-url"`
 	CommitMessage     string `jsonapi:"attr,commit-message"`
+	CommitSHA         string `jsonapi:"attr,commit-sha"`
 	CommitURL         string `jsonapi:"attr,commit-url"`
 	CompareURL        string `jsonapi:"attr,compare-url"`
 	Identifier        string `jsonapi:"attr,identifier"`
@@ -133,8 +153,16 @@ type IngressAttributes struct {
 	SenderAvatarURL   string `jsonapi:"attr,sender-avatar-url"`
 	SenderHTMLURL     string `jsonapi:"attr,sender-html-url"`
 
-	// Links
-	Links map[string]interface{} `jsonapi:"links,omitempty"`
+	// Links of the resource. As the jsonapi package doesn't support links,
+	// they are decoded separately and only for the primary data of a
+	// response, not for included resources.
+	Links map[string]interface{}
+}
+
+// unmarshalRawResource implements rawResourceUnmarshaler.
+func (ia *IngressAttributes) unmarshalRawResource(r *rawResource) error {
+	ia.Links = r.Links
+	return nil
 }
 
 // List returns all configuration versions of a workspace.
@@ -158,6 +186,33 @@ func (s *configurationVersions) List(ctx context.Context, workspaceID string, op
 	return cvl, nil
 }
 
+// ListBySpeculative returns every configuration version of a workspace which
+// is speculative, or which isn't, depending on speculative, most recent
+// first. The API can't filter configuration versions this way, so all of
+// them are listed, page by page, and filtered by the client.
+func (s *configurationVersions) ListBySpeculative(ctx context.Context, workspaceID string, speculative bool) ([]*ConfigurationVersion, error) {
+	var cvs []*ConfigurationVersion
+	err := forEachPage(ctx, ListOptions{}, func(lo ListOptions) (*Pagination, error) {
+		cvl, err := s.List(ctx, workspaceID, ConfigurationVersionListOptions{ListOptions: lo})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cv := range cvl.Items {
+			if cv.Speculative == speculative {
+				cvs = append(cvs, cv)
+			}
+		}
+
+		return cvl.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cvs, nil
+}
+
 // ConfigurationVersionCreateOptions represents the options for creating a
 // configuration version.
 type ConfigurationVersionCreateOptions struct {
@@ -173,6 +228,11 @@ type ConfigurationVersionCreateOptions struct {
 
 	// When true, this configuration version can only be used for planning.
 	Speculative *bool `jsonapi:"attr,speculative,omitempty"`
+
+	// When true, this configuration version does not immediately become the
+	// workspace's current configuration version until a run using it is
+	// applied.
+	Provisional *bool `jsonapi:"attr,provisional,omitempty"`
 }
 
 // Create is used to create a new configuration version. The created
@@ -202,6 +262,60 @@ func (s *configurationVersions) Read(ctx context.Context, cvID string) (*Configu
 	return s.ReadWithOptions(ctx, cvID, ConfigurationVersionReadOptions{})
 }
 
+// ConfigurationVersionWaitOptions represents the options for waiting for a
+// configuration version.
+type ConfigurationVersionWaitOptions struct {
+	// The maximum time to wait for the configuration version to be ready.
+	// Defaults to waiting until the context is done.
+	Timeout time.Duration
+}
+
+// WaitForReady polls a configuration version until it is uploaded, or
+// errored. When the configuration version is errored or archived, it is
+// returned together with a *ConfigurationVersionError holding the error
+// message of the configuration version.
+func (s *configurationVersions) WaitForReady(ctx context.Context, cvID string, options ConfigurationVersionWaitOptions) (*ConfigurationVersion, error) {
+	if !validStringID(&cvID) {
+		return nil, ErrInvalidConfigVersionID
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	for i := 0; ; i++ {
+		start := time.Now()
+
+		cv, err := s.Read(ctx, cvID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch cv.Status {
+		case ConfigurationUploaded:
+			return cv, nil
+		case ConfigurationErrored:
+			return cv, &ConfigurationVersionError{
+				ID:      cv.ID,
+				Code:    cv.Error,
+				Message: cv.ErrorMessage,
+			}
+		case ConfigurationArchived:
+			return cv, &ConfigurationVersionError{
+				ID:      cv.ID,
+				Code:    string(ConfigurationArchived),
+				Message: "it was archived and can no longer be used",
+			}
+		}
+
+		if err := pollWait(ctx, start, backoff(500, 2000, i)); err != nil {
+			return cv, err
+		}
+	}
+}
+
 // Read a configuration version by its ID with the given options.
 func (s *configurationVersions) ReadWithOptions(ctx context.Context, cvID string, options ConfigurationVersionReadOptions) (*ConfigurationVersion, error) {
 	if !validStringID(&cvID) {
@@ -223,6 +337,30 @@ func (s *configurationVersions) ReadWithOptions(ctx context.Context, cvID string
 	return cv, nil
 }
 
+// ReadIngressAttributes reads the VCS commit information of a configuration
+// version ingressed from VCS, such as the commit SHA and the pull request it
+// was ingressed for. ErrResourceNotFound is returned for a configuration
+// version that was uploaded rather than ingressed.
+func (s *configurationVersions) ReadIngressAttributes(ctx context.Context, cvID string) (*IngressAttributes, error) {
+	if !validStringID(&cvID) {
+		return nil, ErrInvalidConfigVersionID
+	}
+
+	u := fmt.Sprintf("configuration-versions/%s/ingress-attributes", url.QueryEscape(cvID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ia := &IngressAttributes{}
+	err = s.client.do(ctx, req, ia)
+	if err != nil {
+		return nil, err
+	}
+
+	return ia, nil
+}
+
 // Upload packages and uploads Terraform configuration files. It requires the
 // upload URL from a configuration version and the path to the configuration
 // files on disk.
@@ -249,3 +387,43 @@ func (s *configurationVersions) Upload(ctx context.Context, url, path string) er
 
 	return s.client.do(ctx, req, nil)
 }
+
+// Download retrieves the uploaded configuration files of a configuration
+// version, as a gzipped tarball. ErrConfigurationVersionArchived is returned
+// when the configuration version was archived and its files are gone.
+func (s *configurationVersions) Download(ctx context.Context, cvID string) ([]byte, error) {
+	if !validStringID(&cvID) {
+		return nil, ErrInvalidConfigVersionID
+	}
+
+	u := fmt.Sprintf("configuration-versions/%s/download", url.QueryEscape(cvID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = s.client.download(ctx, req, &buf)
+	if err != nil {
+		return nil, s.archivedError(ctx, cvID, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// archivedError translates err, returned when retrieving the content of a
+// configuration version, into ErrConfigurationVersionArchived when the
+// configuration version is archived, as the API then responds with an opaque
+// error. Otherwise err is returned as is.
+func (s *configurationVersions) archivedError(ctx context.Context, cvID string, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+
+	cv, readErr := s.Read(ctx, cvID)
+	if readErr != nil || cv.Status != ConfigurationArchived {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", ErrConfigurationVersionArchived, cvID)
+}
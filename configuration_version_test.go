@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -62,6 +66,27 @@ func TestConfigurationVersionsList(t *testing.T) {
 		assert.Equal(t, 2, cvl.TotalCount)
 	})
 
+	t.Run("by speculative", func(t *testing.T) {
+		cvSpec, err := client.ConfigurationVersions.Create(ctx, wTest.ID, ConfigurationVersionCreateOptions{
+			AutoQueueRuns: Bool(false),
+			Speculative:   Bool(true),
+		})
+		require.NoError(t, err)
+		assert.True(t, cvSpec.Speculative)
+
+		cvs, err := client.ConfigurationVersions.ListBySpeculative(ctx, wTest.ID, true)
+		require.NoError(t, err)
+		require.Len(t, cvs, 1)
+		assert.Equal(t, cvSpec.ID, cvs[0].ID)
+
+		cvs, err = client.ConfigurationVersions.ListBySpeculative(ctx, wTest.ID, false)
+		require.NoError(t, err)
+		for _, cv := range cvs {
+			assert.False(t, cv.Speculative)
+			assert.NotEqual(t, cvSpec.ID, cv.ID)
+		}
+	})
+
 	t.Run("without a valid organization", func(t *testing.T) {
 		options := ConfigurationVersionListOptions{}
 
@@ -71,6 +96,51 @@ func TestConfigurationVersionsList(t *testing.T) {
 	})
 }
 
+func TestConfigurationVersionsListBySpeculative(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"data":[` +
+			`{"id":"cv-4","type":"configuration-versions","attributes":{"speculative":true}},` +
+			`{"id":"cv-3","type":"configuration-versions","attributes":{"speculative":false}}` +
+			`],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2}}}`,
+		"2": `{"data":[` +
+			`{"id":"cv-2","type":"configuration-versions","attributes":{"speculative":true}},` +
+			`{"id":"cv-1","type":"configuration-versions","attributes":{"speculative":false}}` +
+			`],"meta":{"pagination":{"current-page":2,"total-pages":2}}}`,
+	}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123/configuration-versions":
+			checkedWrite(t, w, []byte(pages[r.URL.Query().Get("page[number]")]))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	ids := func(cvs []*ConfigurationVersion) []string {
+		var ids []string
+		for _, cv := range cvs {
+			ids = append(ids, cv.ID)
+		}
+		return ids
+	}
+
+	cvs, err := client.ConfigurationVersions.ListBySpeculative(ctx, "ws-123", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cv-4", "cv-2"}, ids(cvs))
+
+	cvs, err = client.ConfigurationVersions.ListBySpeculative(ctx, "ws-123", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cv-3", "cv-1"}, ids(cvs))
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		_, err := client.ConfigurationVersions.ListBySpeculative(ctx, badIdentifier, true)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
 func TestConfigurationVersionsCreate(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -276,3 +346,183 @@ func TestConfigurationVersions_Unmarshal(t *testing.T) {
 	assert.Equal(t, cv.StatusTimestamps.FinishedAt, &finishedParsedTime)
 	assert.Equal(t, cv.StatusTimestamps.StartedAt, &startedParsedTime)
 }
+
+func TestConfigurationVersionsWaitForReady(t *testing.T) {
+	// Each configuration version goes through the given statuses, one per
+	// read, staying in the last one.
+	statuses := map[string][]string{
+		"cv-uploaded": {"pending", "fetching", "uploaded"},
+		"cv-errored":  {"pending", "errored"},
+		"cv-archived": {"archived"},
+		"cv-pending":  {"pending"},
+	}
+	reads := make(map[string]int)
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/configuration-versions/")
+		status := statuses[id][len(statuses[id])-1]
+		if reads[id] < len(statuses[id]) {
+			status = statuses[id][reads[id]]
+		}
+		reads[id]++
+
+		attributes := fmt.Sprintf(`{"status":%q}`, status)
+		if status == "errored" {
+			attributes = `{"status":"errored","error":"unprocessable_entity","error-message":"no Terraform configuration files found"}`
+		}
+		fmt.Fprintf(w, `{"data":{"id":%q,"type":"configuration-versions","attributes":%s}}`, id, attributes)
+	})
+
+	ctx := context.Background()
+
+	t.Run("when the configuration version is uploaded", func(t *testing.T) {
+		cv, err := client.ConfigurationVersions.WaitForReady(ctx, "cv-uploaded", ConfigurationVersionWaitOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, ConfigurationUploaded, cv.Status)
+		assert.Equal(t, 3, reads["cv-uploaded"])
+	})
+
+	t.Run("when the configuration version is errored", func(t *testing.T) {
+		cv, err := client.ConfigurationVersions.WaitForReady(ctx, "cv-errored", ConfigurationVersionWaitOptions{})
+		require.NotNil(t, cv)
+		assert.Equal(t, ConfigurationErrored, cv.Status)
+
+		var cvErr *ConfigurationVersionError
+		require.True(t, errors.As(err, &cvErr))
+		assert.Equal(t, "unprocessable_entity", cvErr.Code)
+		assert.EqualError(t, err, "configuration version cv-errored errored: no Terraform configuration files found")
+	})
+
+	t.Run("when the configuration version is archived", func(t *testing.T) {
+		cv, err := client.ConfigurationVersions.WaitForReady(ctx, "cv-archived", ConfigurationVersionWaitOptions{})
+		require.NotNil(t, cv)
+
+		var cvErr *ConfigurationVersionError
+		require.True(t, errors.As(err, &cvErr))
+		assert.Equal(t, "archived", cvErr.Code)
+		assert.True(t, errors.Is(err, ErrConfigurationVersionArchived))
+	})
+
+	t.Run("when the timeout expires", func(t *testing.T) {
+		_, err := client.ConfigurationVersions.WaitForReady(ctx, "cv-pending", ConfigurationVersionWaitOptions{
+			Timeout: 100 * time.Millisecond,
+		})
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("without a valid configuration version ID", func(t *testing.T) {
+		cv, err := client.ConfigurationVersions.WaitForReady(ctx, badIdentifier, ConfigurationVersionWaitOptions{})
+		assert.Nil(t, cv)
+		assert.EqualError(t, err, ErrInvalidConfigVersionID.Error())
+	})
+}
+
+func TestConfigurationVersionsDownload(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/configuration-versions/cv-uploaded/download":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			checkedWrite(t, w, []byte("tarball"))
+		case "/api/v2/configuration-versions/cv-archived/download",
+			"/api/v2/configuration-versions/cv-missing/download":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			w.WriteHeader(http.StatusNotFound)
+			checkedWrite(t, w, []byte(`{"errors":[{"status":"404","title":"not found"}]}`))
+		case "/api/v2/configuration-versions/cv-archived":
+			checkedWrite(t, w, []byte(`{"data":{"id":"cv-archived","type":"configuration-versions","attributes":{"status":"archived"}}}`))
+		case "/api/v2/configuration-versions/cv-missing":
+			checkedWrite(t, w, []byte(`{"data":{"id":"cv-missing","type":"configuration-versions","attributes":{"status":"uploaded"}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when the configuration version is uploaded", func(t *testing.T) {
+		content, err := client.ConfigurationVersions.Download(ctx, "cv-uploaded")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("tarball"), content)
+	})
+
+	t.Run("when the configuration version is archived", func(t *testing.T) {
+		content, err := client.ConfigurationVersions.Download(ctx, "cv-archived")
+		assert.Nil(t, content)
+		assert.True(t, errors.Is(err, ErrConfigurationVersionArchived))
+		assert.EqualError(t, err, "configuration version is archived: cv-archived")
+	})
+
+	t.Run("when the download fails otherwise", func(t *testing.T) {
+		content, err := client.ConfigurationVersions.Download(ctx, "cv-missing")
+		assert.Nil(t, content)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrConfigurationVersionArchived))
+	})
+
+	t.Run("without a valid configuration version ID", func(t *testing.T) {
+		content, err := client.ConfigurationVersions.Download(ctx, badIdentifier)
+		assert.Nil(t, content)
+		assert.Equal(t, ErrInvalidConfigVersionID, err)
+	})
+}
+
+func TestConfigurationVersionsIngressAttributes(t *testing.T) {
+	attributes := `{"branch":"feature","clone-url":"https://github.com/acme/app.git","commit-message":"Add the network","commit-sha":"abcd1234","compare-url":"https://github.com/acme/app/pull/42/files","identifier":"acme/app","is-pull-request":true,"pull-request-number":42,"sender-username":"octocat"}`
+
+	var query string
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/configuration-versions/cv-123":
+			query = r.URL.RawQuery
+			checkedWrite(t, w, []byte(`{"data":{"id":"cv-123","type":"configuration-versions","attributes":{"source":"github","speculative":true},`+
+				`"relationships":{"ingress-attributes":{"data":{"id":"ia-123","type":"ingress-attributes"}}}},`+
+				`"included":[{"id":"ia-123","type":"ingress-attributes","attributes":`+attributes+`}]}`))
+		case "/api/v2/configuration-versions/cv-123/ingress-attributes":
+			checkedWrite(t, w, []byte(`{"data":{"id":"ia-123","type":"ingress-attributes","attributes":`+attributes+`}}`))
+		case "/api/v2/configuration-versions/cv-uploaded/ingress-attributes":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	assertIngressAttributes := func(t *testing.T, ia *IngressAttributes) {
+		require.NotNil(t, ia)
+		assert.Equal(t, "abcd1234", ia.CommitSHA)
+		assert.Equal(t, "feature", ia.Branch)
+		assert.Equal(t, "Add the network", ia.CommitMessage)
+		assert.Equal(t, "octocat", ia.SenderUsername)
+		assert.True(t, ia.IsPullRequest)
+		assert.Equal(t, 42, ia.PullRequestNumber)
+		assert.Equal(t, "https://github.com/acme/app/pull/42/files", ia.CompareURL)
+	}
+
+	t.Run("when included with the configuration version", func(t *testing.T) {
+		cv, err := client.ConfigurationVersions.ReadWithOptions(ctx, "cv-123", ConfigurationVersionReadOptions{
+			Include: "ingress_attributes",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "include=ingress_attributes", query)
+		assertIngressAttributes(t, cv.IngressAttributes)
+	})
+
+	t.Run("when read on their own", func(t *testing.T) {
+		ia, err := client.ConfigurationVersions.ReadIngressAttributes(ctx, "cv-123")
+		require.NoError(t, err)
+		assertIngressAttributes(t, ia)
+	})
+
+	t.Run("when the configuration version was uploaded", func(t *testing.T) {
+		ia, err := client.ConfigurationVersions.ReadIngressAttributes(ctx, "cv-uploaded")
+		assert.Nil(t, ia)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid configuration version ID", func(t *testing.T) {
+		ia, err := client.ConfigurationVersions.ReadIngressAttributes(ctx, badIdentifier)
+		assert.Nil(t, ia)
+		assert.Equal(t, ErrInvalidConfigVersionID, err)
+	})
+}
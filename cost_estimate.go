@@ -22,6 +22,10 @@ type CostEstimates interface {
 
 	// Logs retrieves the logs of a costEstimate.
 	Logs(ctx context.Context, costEstimateID string) (io.Reader, error)
+
+	// WaitForFinished waits until a costEstimate is no longer pending or
+	// queued.
+	WaitForFinished(ctx context.Context, costEstimateID string) (*CostEstimate, error)
 }
 
 // costEstimates implements CostEstimates.
@@ -128,3 +132,31 @@ func (s *costEstimates) Logs(ctx context.Context, costEstimateID string) (io.Rea
 		return logs, nil
 	}
 }
+
+// WaitForFinished polls a costEstimate until it is no longer pending or
+// queued, and returns it. The status of the returned costEstimate tells
+// whether it finished, errored, was canceled or skipped.
+func (s *costEstimates) WaitForFinished(ctx context.Context, costEstimateID string) (*CostEstimate, error) {
+	if !validStringID(&costEstimateID) {
+		return nil, ErrInvalidCostEstimateID
+	}
+
+	for i := 0; ; i++ {
+		start := time.Now()
+
+		ce, err := s.Read(ctx, costEstimateID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch ce.Status {
+		case CostEstimatePending, CostEstimateQueued:
+		default:
+			return ce, nil
+		}
+
+		if err := pollWait(ctx, start, backoff(500, 2000, i)); err != nil {
+			return ce, err
+		}
+	}
+}
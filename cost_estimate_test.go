@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
@@ -56,6 +57,62 @@ func TestCostEstimatesRead(t *testing.T) {
 	})
 }
 
+func TestCostEstimatesWaitForFinished(t *testing.T) {
+	var statuses []CostEstimateStatus
+	var reads int
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/cost-estimates/ce-123":
+			status := statuses[len(statuses)-1]
+			if reads < len(statuses) {
+				status = statuses[reads]
+			}
+			reads++
+			checkedWrite(t, w, []byte(`{"data":{"id":"ce-123","type":"cost-estimates","attributes":{"status":"`+string(status)+`"}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when the costEstimate finishes", func(t *testing.T) {
+		statuses, reads = []CostEstimateStatus{CostEstimatePending, CostEstimateQueued, CostEstimateFinished}, 0
+
+		ce, err := client.CostEstimates.WaitForFinished(ctx, "ce-123")
+		require.NoError(t, err)
+		assert.Equal(t, CostEstimateFinished, ce.Status)
+		assert.Equal(t, 3, reads)
+	})
+
+	t.Run("when the costEstimate errors", func(t *testing.T) {
+		statuses, reads = []CostEstimateStatus{CostEstimateErrored}, 0
+
+		ce, err := client.CostEstimates.WaitForFinished(ctx, "ce-123")
+		require.NoError(t, err)
+		assert.Equal(t, CostEstimateErrored, ce.Status)
+		assert.Equal(t, 1, reads)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		statuses, reads = []CostEstimateStatus{CostEstimateQueued}, 0
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		ce, err := client.CostEstimates.WaitForFinished(ctx, "ce-123")
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, CostEstimateQueued, ce.Status)
+	})
+
+	t.Run("with invalid costEstimate ID", func(t *testing.T) {
+		ce, err := client.CostEstimates.WaitForFinished(ctx, badIdentifier)
+		assert.Nil(t, ce)
+		assert.EqualError(t, err, ErrInvalidCostEstimateID.Error())
+	})
+}
+
 func TestCostEsimate_Unmarshal(t *testing.T) {
 	data := map[string]interface{}{
 		"data": map[string]interface{}{
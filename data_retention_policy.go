@@ -0,0 +1,180 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Compile-time proof of interface implementation.
+var _ DataRetentionPolicies = (*dataRetentionPolicies)(nil)
+
+// DataRetentionPolicies describes all the data retention policy related
+// methods that the Terraform Enterprise API supports. Data retention
+// policies are only available in Terraform Enterprise.
+//
+// TFE API docs:
+// https://www.terraform.io/docs/enterprise/api/data-retention-policies.html
+type DataRetentionPolicies interface {
+	// ReadForWorkspace reads the data retention policy of a workspace.
+	ReadForWorkspace(ctx context.Context, workspaceID string) (*DataRetentionPolicy, error)
+
+	// SetForWorkspace creates or replaces the data retention policy of a
+	// workspace.
+	SetForWorkspace(ctx context.Context, workspaceID string, options DataRetentionPolicySetOptions) (*DataRetentionPolicy, error)
+
+	// DeleteForWorkspace deletes the data retention policy of a workspace.
+	DeleteForWorkspace(ctx context.Context, workspaceID string) error
+
+	// ReadForOrganization reads the data retention policy of an
+	// organization.
+	ReadForOrganization(ctx context.Context, organization string) (*DataRetentionPolicy, error)
+
+	// SetForOrganization creates or replaces the data retention policy of an
+	// organization.
+	SetForOrganization(ctx context.Context, organization string, options DataRetentionPolicySetOptions) (*DataRetentionPolicy, error)
+
+	// DeleteForOrganization deletes the data retention policy of an
+	// organization.
+	DeleteForOrganization(ctx context.Context, organization string) error
+}
+
+// dataRetentionPolicies implements DataRetentionPolicies.
+type dataRetentionPolicies struct {
+	client *Client
+}
+
+// DataRetentionPolicy represents a Terraform Enterprise data retention
+// policy, which deletes state versions and configuration versions older
+// than a number of days.
+type DataRetentionPolicy struct {
+	ID                   string `jsonapi:"primary,data-retention-policies"`
+	DeleteOlderThanNDays int    `jsonapi:"attr,delete-older-than-n-days"`
+}
+
+// DataRetentionPolicySetOptions represents the options for setting a data
+// retention policy.
+type DataRetentionPolicySetOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,data-retention-policies"`
+
+	// The number of days after which data is deleted.
+	DeleteOlderThanNDays int `jsonapi:"attr,delete-older-than-n-days"`
+}
+
+func (o DataRetentionPolicySetOptions) valid() error {
+	if o.DeleteOlderThanNDays < 1 {
+		return errors.New("delete older than n days must be at least 1")
+	}
+	return nil
+}
+
+// ReadForWorkspace reads the data retention policy of a workspace.
+func (s *dataRetentionPolicies) ReadForWorkspace(ctx context.Context, workspaceID string) (*DataRetentionPolicy, error) {
+	if !validStringID(&workspaceID) {
+		return nil, ErrInvalidWorkspaceID
+	}
+
+	u := fmt.Sprintf("workspaces/%s/relationships/data-retention-policy", url.QueryEscape(workspaceID))
+	return s.read(ctx, u)
+}
+
+// SetForWorkspace creates or replaces the data retention policy of a
+// workspace.
+func (s *dataRetentionPolicies) SetForWorkspace(ctx context.Context, workspaceID string, options DataRetentionPolicySetOptions) (*DataRetentionPolicy, error) {
+	if !validStringID(&workspaceID) {
+		return nil, ErrInvalidWorkspaceID
+	}
+
+	u := fmt.Sprintf("workspaces/%s/relationships/data-retention-policy", url.QueryEscape(workspaceID))
+	return s.set(ctx, u, options)
+}
+
+// DeleteForWorkspace deletes the data retention policy of a workspace.
+func (s *dataRetentionPolicies) DeleteForWorkspace(ctx context.Context, workspaceID string) error {
+	if !validStringID(&workspaceID) {
+		return ErrInvalidWorkspaceID
+	}
+
+	u := fmt.Sprintf("workspaces/%s/relationships/data-retention-policy", url.QueryEscape(workspaceID))
+	return s.delete(ctx, u)
+}
+
+// ReadForOrganization reads the data retention policy of an organization.
+func (s *dataRetentionPolicies) ReadForOrganization(ctx context.Context, organization string) (*DataRetentionPolicy, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+
+	u := fmt.Sprintf("organizations/%s/relationships/data-retention-policy", url.QueryEscape(organization))
+	return s.read(ctx, u)
+}
+
+// SetForOrganization creates or replaces the data retention policy of an
+// organization.
+func (s *dataRetentionPolicies) SetForOrganization(ctx context.Context, organization string, options DataRetentionPolicySetOptions) (*DataRetentionPolicy, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+
+	u := fmt.Sprintf("organizations/%s/relationships/data-retention-policy", url.QueryEscape(organization))
+	return s.set(ctx, u, options)
+}
+
+// DeleteForOrganization deletes the data retention policy of an
+// organization.
+func (s *dataRetentionPolicies) DeleteForOrganization(ctx context.Context, organization string) error {
+	if !validStringID(&organization) {
+		return ErrInvalidOrg
+	}
+
+	u := fmt.Sprintf("organizations/%s/relationships/data-retention-policy", url.QueryEscape(organization))
+	return s.delete(ctx, u)
+}
+
+func (s *dataRetentionPolicies) read(ctx context.Context, u string) (*DataRetentionPolicy, error) {
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	drp := &DataRetentionPolicy{}
+	err = s.client.do(ctx, req, drp)
+	if err != nil {
+		return nil, err
+	}
+
+	return drp, nil
+}
+
+func (s *dataRetentionPolicies) set(ctx context.Context, u string, options DataRetentionPolicySetOptions) (*DataRetentionPolicy, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("POST", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	drp := &DataRetentionPolicy{}
+	err = s.client.do(ctx, req, drp)
+	if err != nil {
+		return nil, err
+	}
+
+	return drp, nil
+}
+
+func (s *dataRetentionPolicies) delete(ctx context.Context, u string) error {
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
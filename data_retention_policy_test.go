@@ -0,0 +1,100 @@
+package tfe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataRetentionPoliciesWorkspace(t *testing.T) {
+	skipIfCloud(t)
+
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	t.Run("when no policy is set", func(t *testing.T) {
+		drp, err := client.DataRetentionPolicies.ReadForWorkspace(ctx, wTest.ID)
+		assert.Nil(t, drp)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("setting a policy", func(t *testing.T) {
+		drp, err := client.DataRetentionPolicies.SetForWorkspace(ctx, wTest.ID, DataRetentionPolicySetOptions{
+			DeleteOlderThanNDays: 30,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 30, drp.DeleteOlderThanNDays)
+
+		drp, err = client.DataRetentionPolicies.ReadForWorkspace(ctx, wTest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 30, drp.DeleteOlderThanNDays)
+	})
+
+	t.Run("replacing a policy", func(t *testing.T) {
+		drp, err := client.DataRetentionPolicies.SetForWorkspace(ctx, wTest.ID, DataRetentionPolicySetOptions{
+			DeleteOlderThanNDays: 90,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 90, drp.DeleteOlderThanNDays)
+	})
+
+	t.Run("deleting a policy", func(t *testing.T) {
+		err := client.DataRetentionPolicies.DeleteForWorkspace(ctx, wTest.ID)
+		require.NoError(t, err)
+
+		_, err = client.DataRetentionPolicies.ReadForWorkspace(ctx, wTest.ID)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("with an invalid number of days", func(t *testing.T) {
+		drp, err := client.DataRetentionPolicies.SetForWorkspace(ctx, wTest.ID, DataRetentionPolicySetOptions{})
+		assert.Nil(t, drp)
+		assert.EqualError(t, err, "delete older than n days must be at least 1")
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		drp, err := client.DataRetentionPolicies.ReadForWorkspace(ctx, badIdentifier)
+		assert.Nil(t, drp)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestDataRetentionPoliciesOrganization(t *testing.T) {
+	skipIfCloud(t)
+
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	t.Run("setting a policy", func(t *testing.T) {
+		drp, err := client.DataRetentionPolicies.SetForOrganization(ctx, orgTest.Name, DataRetentionPolicySetOptions{
+			DeleteOlderThanNDays: 365,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 365, drp.DeleteOlderThanNDays)
+
+		drp, err = client.DataRetentionPolicies.ReadForOrganization(ctx, orgTest.Name)
+		require.NoError(t, err)
+		assert.Equal(t, 365, drp.DeleteOlderThanNDays)
+	})
+
+	t.Run("deleting a policy", func(t *testing.T) {
+		err := client.DataRetentionPolicies.DeleteForOrganization(ctx, orgTest.Name)
+		require.NoError(t, err)
+
+		_, err = client.DataRetentionPolicies.ReadForOrganization(ctx, orgTest.Name)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		err := client.DataRetentionPolicies.DeleteForOrganization(ctx, badIdentifier)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+}
@@ -2,6 +2,9 @@ package tfe
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 )
 
 // Generic errors applicable to all resources.
@@ -12,6 +15,14 @@ var (
 	// ErrResourceNotFound is returned when a receiving a 404.
 	ErrResourceNotFound = errors.New("resource not found")
 
+	// ErrMissingToken is returned when a client is created without an API
+	// token.
+	ErrMissingToken = errors.New("missing API token")
+
+	// ErrInvalidAddress is returned when a client is created with an address
+	// that is not an absolute HTTP or HTTPS URL.
+	ErrInvalidAddress = errors.New("invalid address")
+
 	// ErrRequiredName is returned when a name option is not present.
 	ErrRequiredName = errors.New("name is required")
 
@@ -20,6 +31,10 @@ var (
 
 	// ErrMissingDirectory is returned when the path does not have an existing directory.
 	ErrMissingDirectory = errors.New("path needs to be an existing directory")
+
+	// ErrUnsupportedRefresh is returned when refreshing a type of resource
+	// which can't be refreshed.
+	ErrUnsupportedRefresh = errors.New("refreshing this type of resource is not supported")
 )
 
 // Resource Errors
@@ -44,6 +59,14 @@ var (
 	// ErrWorkspaceMinLimit is returned when the length of Workspaces is 0.
 	ErrWorkspaceMinLimit = errors.New("must provide at least one workspace")
 
+	// ErrUnsupportedBothTriggerPatternsAndPrefixes is returned when both
+	// trigger patterns and trigger prefixes are provided.
+	ErrUnsupportedBothTriggerPatternsAndPrefixes = errors.New("trigger patterns and trigger prefixes cannot be used together")
+
+	// ErrInvalidAutoDestroyActivityDuration is returned when the auto destroy
+	// activity duration is not a number of days or hours, such as "14d".
+	ErrInvalidAutoDestroyActivityDuration = errors.New(`invalid value for auto destroy activity duration, must be a number of days or hours such as "14d" or "12h"`)
+
 	// Run/Apply errors
 
 	// ErrInvalidRunID is returned when the run ID is invalid.
@@ -52,6 +75,29 @@ var (
 	// ErrInvalidApplyID is returned when the apply ID is invalid.
 	ErrInvalidApplyID = errors.New("invalid value for apply ID")
 
+	// ErrNoRuns is returned when a workspace has never run.
+	ErrNoRuns = errors.New("workspace has no runs")
+
+	// ErrRunTerminated is returned when a run reaches a final state before
+	// the stage that was waited for.
+	ErrRunTerminated = errors.New("run terminated")
+
+	// ErrPlanNotFinished is returned when retrieving the output of a plan
+	// which hasn't finished yet. Retrying once the plan finished may succeed.
+	ErrPlanNotFinished = errors.New("plan has not finished")
+
+	// ErrUnstructuredLogs is returned when logs need to be parsed, but the
+	// workspace doesn't have structured run output enabled.
+	ErrUnstructuredLogs = errors.New("logs are not structured, structured run output must be enabled")
+
+	// ErrNoPlanSummary is returned when plan logs don't contain a summary of
+	// the changes.
+	ErrNoPlanSummary = errors.New("plan logs do not contain a change summary")
+
+	// ErrNoPolicyResult is returned when the result of a policy check is
+	// requested before the policy check has one.
+	ErrNoPolicyResult = errors.New("policy check has no result")
+
 	// Organzation errors
 
 	// ErrInvalidOrg is returned when the organization option has an invalid value.
@@ -75,11 +121,60 @@ var (
 	// ErrInvalidConfigVersionID is returned when the configuration version ID is invalid.
 	ErrInvalidConfigVersionID = errors.New("invalid value for configuration version ID")
 
+	// ErrConfigurationVersionArchived is returned when the content of a
+	// configuration version is requested after it was archived. The content
+	// is gone for good, so retrying is pointless.
+	ErrConfigurationVersionArchived = errors.New("configuration version is archived")
+
+	// ErrNoConfigurationVersion is returned when the latest non-speculative
+	// configuration version of a workspace, which a run is created with by
+	// default, is missing or not uploaded.
+	ErrNoConfigurationVersion = errors.New("workspace does not have an uploaded configuration version")
+
 	// Cost Esimation Errors
 
 	// ErrInvalidCostEstimateID is returned when the cost estimate ID is invalid.
 	ErrInvalidCostEstimateID = errors.New("invalid value for cost estimate ID")
 
+	// GPG key errors
+
+	// ErrInvalidGPGKeyID is returned when the GPG key ID is invalid.
+	ErrInvalidGPGKeyID = errors.New("invalid value for GPG key ID")
+
+	// ErrRequiredASCIIArmor is returned when the ASCII armored GPG key is
+	// not present.
+	ErrRequiredASCIIArmor = errors.New("ASCII armor is required")
+
+	// Registry provider errors
+
+	// ErrRequiredVersion is returned when a version option is not present.
+	ErrRequiredVersion = errors.New("version is required")
+
+	// ErrInvalidVersion is returned when the version option has an invalid
+	// value.
+	ErrInvalidVersion = errors.New("invalid value for version")
+
+	// ErrRequiredKeyID is returned when a GPG key ID option is not present.
+	ErrRequiredKeyID = errors.New("key ID is required")
+
+	// ErrRequiredOS is returned when an OS option is not present.
+	ErrRequiredOS = errors.New("OS is required")
+
+	// ErrRequiredArch is returned when an arch option is not present.
+	ErrRequiredArch = errors.New("arch is required")
+
+	// ErrRequiredShasum is returned when a shasum option is not present.
+	ErrRequiredShasum = errors.New("shasum is required")
+
+	// ErrRequiredFilename is returned when a filename option is not present.
+	ErrRequiredFilename = errors.New("filename is required")
+
+	// Team errors
+
+	// ErrInvalidTeamVisibility is returned when the team visibility is not
+	// one of "secret" or "organization".
+	ErrInvalidTeamVisibility = errors.New(`invalid value for team visibility, must be "secret" or "organization"`)
+
 	// User
 
 	// ErrInvalidUservalue is invalid.
@@ -103,3 +198,198 @@ var (
 	// not a semantic version string (major.minor.patch).
 	ErrInvalidTerraformVersion = errors.New("invalid terraform version")
 )
+
+// APIError is returned when the API responds with an error document, such as
+// an HTML error page or a JSON:API error document, instead of the requested
+// content.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// ContentType is the content type of the response.
+	ContentType string
+
+	// Errors holds the error objects decoded from a JSON:API error
+	// document. It is empty for any other kind of error document.
+	Errors []*ErrorObject
+}
+
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("unexpected %s response with status %d", e.ContentType, e.StatusCode)
+	}
+
+	msgs := make([]string, 0, len(e.Errors))
+	for _, obj := range e.Errors {
+		msgs = append(msgs, obj.message())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// FieldErrors returns the messages of the errors pointing at an attribute or
+// relationship of the request document, keyed by the attribute or
+// relationship name. Messages of multiple errors about the same field are
+// joined together.
+func (e *APIError) FieldErrors() map[string]string {
+	fields := make(map[string]string)
+	for _, obj := range e.Errors {
+		if obj.Source == nil {
+			continue
+		}
+
+		var field string
+		switch {
+		case strings.HasPrefix(obj.Source.Pointer, "/data/attributes/"):
+			field = strings.TrimPrefix(obj.Source.Pointer, "/data/attributes/")
+		case strings.HasPrefix(obj.Source.Pointer, "/data/relationships/"):
+			field = strings.TrimPrefix(obj.Source.Pointer, "/data/relationships/")
+		default:
+			continue
+		}
+
+		msg := obj.Detail
+		if msg == "" {
+			msg = obj.Title
+		}
+
+		if prev, ok := fields[field]; ok {
+			msg = prev + "; " + msg
+		}
+		fields[field] = msg
+	}
+	return fields
+}
+
+// ErrorObject represents a JSON:API error object.
+type ErrorObject struct {
+	// Status is the HTTP status code applicable to the error.
+	Status string `json:"status,omitempty"`
+
+	// Title is a short summary of the error.
+	Title string `json:"title,omitempty"`
+
+	// Detail is an explanation specific to this occurrence of the error.
+	Detail string `json:"detail,omitempty"`
+
+	// Code is an application-specific error code.
+	Code string `json:"code,omitempty"`
+
+	// Source references the part of the request causing the error.
+	Source *ErrorSource `json:"source,omitempty"`
+}
+
+// ErrorSource references the part of a request causing an error.
+type ErrorSource struct {
+	// Pointer is a JSON Pointer to the value in the request document
+	// causing the error, e.g. "/data/attributes/name".
+	Pointer string `json:"pointer,omitempty"`
+
+	// Parameter is the query parameter causing the error.
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// message formats the error object as a message.
+func (e *ErrorObject) message() string {
+	if e.Detail == "" {
+		return e.Title
+	}
+	return fmt.Sprintf("%s\n\n%s", e.Title, e.Detail)
+}
+
+// DecodeError is returned when an API response cannot be decoded.
+type DecodeError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Body holds the first bytes of the response body. It is only set when
+	// the client is configured with IncludeBodyOnDecodeError.
+	Body string
+
+	// Err is the underlying decoding error.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("failed to decode response with status %d: %v", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("failed to decode response with status %d: %v\n\n%s", e.StatusCode, e.Err, e.Body)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// PolicySetVersionError is returned when a Policy Set Version failed to be
+// ingressed.
+type PolicySetVersionError struct {
+	// ID is the ID of the Policy Set Version.
+	ID string
+
+	// Code is the error code reported for the version.
+	Code string
+
+	// Message is the error message reported for the version.
+	Message string
+}
+
+func (e *PolicySetVersionError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("policy set version %s errored", e.ID)
+	}
+	return fmt.Sprintf("policy set version %s errored: %s", e.ID, e.Message)
+}
+
+// ConfigurationVersionError is returned when a configuration version failed
+// to be uploaded or ingressed.
+type ConfigurationVersionError struct {
+	// ID is the ID of the configuration version.
+	ID string
+
+	// Code is the error code reported for the configuration version.
+	Code string
+
+	// Message is the error message reported for the configuration version.
+	Message string
+}
+
+func (e *ConfigurationVersionError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("configuration version %s errored", e.ID)
+	}
+	return fmt.Sprintf("configuration version %s errored: %s", e.ID, e.Message)
+}
+
+// Is makes errors.Is match ErrConfigurationVersionArchived for the errors of
+// archived configuration versions.
+func (e *ConfigurationVersionError) Is(target error) bool {
+	return target == ErrConfigurationVersionArchived && e.Code == string(ConfigurationArchived)
+}
+
+// RunActionError is returned when an action failed for one or more runs
+// while acting on several runs of a workspace at once, such as with CancelAll
+// or DiscardOlderThan.
+type RunActionError struct {
+	// Action is the action that failed, such as "cancel" or "discard".
+	Action string
+
+	// Errors holds the error of every run the action failed for, keyed by
+	// run ID.
+	Errors map[string]error
+}
+
+func (e *RunActionError) Error() string {
+	runIDs := make([]string, 0, len(e.Errors))
+	for runID := range e.Errors {
+		runIDs = append(runIDs, runID)
+	}
+	sort.Strings(runIDs)
+
+	msgs := make([]string, 0, len(runIDs))
+	for _, runID := range runIDs {
+		msgs = append(msgs, fmt.Sprintf("run %s: %v", runID, e.Errors[runID]))
+	}
+
+	return fmt.Sprintf("failed to %s %d run(s):\n%s", e.Action, len(msgs), strings.Join(msgs, "\n"))
+}
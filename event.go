@@ -1,9 +1,15 @@
 package tfe
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -22,11 +28,17 @@ const (
 	EventPlanQueued            EventType = "plan_queued"
 	EventApplyQueued           EventType = "apply_queued"
 	EventError                 EventType = "error"
+
+	// EventResync is sent by a subscription created with SubscribeWithOptions
+	// after reconnecting to a server unable to replay the events missed while
+	// disconnected. Its payload is the ID of the last event received.
+	EventResync EventType = "resync"
 )
 
 type EventType string
 
 type Event struct {
+	ID      string      `json:"id,omitempty"`
 	Type    EventType   `json:"type"`
 	Payload interface{} `json:"payload"`
 }
@@ -34,6 +46,67 @@ type Event struct {
 // Events provides methods for sending and receiving events in real-time.
 type Events interface {
 	Subscribe(id string) (Subscription, error)
+
+	// SubscribeWithOptions subscribes to the event stream until ctx is done
+	// or the subscription is closed, reconnecting whenever the connection
+	// drops.
+	SubscribeWithOptions(ctx context.Context, id string, options EventSubscribeOptions) (Subscription, error)
+
+	// Handle subscribes to the event stream and dispatches the events to
+	// the given handlers until ctx is done or the stream ends.
+	Handle(ctx context.Context, id string, handlers EventHandlers) error
+}
+
+// EventHandlers holds the functions to call for each type of event. Events
+// without a handler are ignored.
+type EventHandlers struct {
+	OnOrganizationCreated   func(Event)
+	OnOrganizationDeleted   func(Event)
+	OnWorkspaceCreated      func(Event)
+	OnWorkspaceDeleted      func(Event)
+	OnRunCreated            func(Event)
+	OnRunCompleted          func(Event)
+	OnRunCanceled           func(Event)
+	OnRunApplied            func(Event)
+	OnRunPlanned            func(Event)
+	OnRunPlannedAndFinished func(Event)
+	OnPlanQueued            func(Event)
+	OnApplyQueued           func(Event)
+
+	// OnError is called with the error ending the event stream.
+	OnError func(error)
+}
+
+// handler returns the handler for the given type of event, if any.
+func (h EventHandlers) handler(t EventType) func(Event) {
+	switch t {
+	case EventOrganizationCreated:
+		return h.OnOrganizationCreated
+	case EventOrganizationDeleted:
+		return h.OnOrganizationDeleted
+	case EventWorkspaceCreated:
+		return h.OnWorkspaceCreated
+	case EventWorkspaceDeleted:
+		return h.OnWorkspaceDeleted
+	case EventRunCreated:
+		return h.OnRunCreated
+	case EventRunCompleted:
+		return h.OnRunCompleted
+	case EventRunCanceled:
+		return h.OnRunCanceled
+	case EventRunApplied:
+		return h.OnRunApplied
+	case EventRunPlanned:
+		return h.OnRunPlanned
+	case EventRunPlannedAndFinished:
+		return h.OnRunPlannedAndFinished
+	case EventPlanQueued:
+		return h.OnPlanQueued
+	case EventApplyQueued:
+		return h.OnApplyQueued
+	default:
+		return nil
+	}
 }
 
 // Subscription represents a stream of events for a subscriber
@@ -55,9 +128,28 @@ type subscription struct {
 	ch   chan Event
 }
 
-func (e *events) Subscribe(id string) (Subscription, error) {
+// eventsURL returns the URL of the event stream.
+func (e *events) eventsURL() string {
 	u := url.URL{Scheme: "wss", Host: e.client.baseURL.Host, Path: "/events"}
-	c, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	return u.String()
+}
+
+// dialer returns a dialer connecting to the event stream through the proxy
+// and with the TLS configuration of the client's transport.
+func (e *events) dialer() *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	if transport, ok := e.client.http.HTTPClient.Transport.(*http.Transport); ok {
+		dialer.Proxy = transport.Proxy
+		dialer.TLSClientConfig = transport.TLSClientConfig
+	}
+	return &dialer
+}
+
+// Subscribe subscribes to the event stream. The channel of the subscription
+// is closed once the server closes the stream normally. When reading the
+// stream fails, an EventError event is sent before closing the channel.
+func (e *events) Subscribe(id string) (Subscription, error) {
+	c, _, err := e.dialer().Dial(e.eventsURL(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -65,10 +157,14 @@ func (e *events) Subscribe(id string) (Subscription, error) {
 	ch := make(chan Event)
 
 	go func() {
+		defer close(ch)
 		defer c.Close()
 
 		for {
 			_, msg, err := c.ReadMessage()
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return
+			}
 			if err != nil {
 				ch <- Event{Type: EventError, Payload: fmt.Sprintf("websocket read error: %s\n", err.Error())}
 				return
@@ -87,16 +183,241 @@ func (e *events) Subscribe(id string) (Subscription, error) {
 	return &subscription{conn: c, ch: ch}, nil
 }
 
+// EventSubscribeOptions represents the options for subscribing to the event
+// stream.
+type EventSubscribeOptions struct {
+	// LastEventID resumes the stream after the event with this ID, such as
+	// the last event handled before a restart.
+	LastEventID string
+}
+
+// resumableSubscription is a subscription which reconnects when the
+// connection drops, and resumes the stream after the last event received.
+type resumableSubscription struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	dialer *websocket.Dialer
+	url    string
+	ch     chan Event
+
+	// lastID is the ID of the last event received. It is only used by the
+	// goroutine reading the stream, once connected.
+	lastID string
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+// SubscribeWithOptions subscribes to the event stream until ctx is done or
+// the subscription is closed. The channel of the subscription is closed
+// then.
+//
+// Whenever the connection drops, the subscription reconnects with a backoff
+// and asks the server to replay the events since the last one received, by
+// sending its ID in the Last-Event-ID header. A server doing so acknowledges
+// it by echoing the header in its handshake response. Otherwise an
+// EventResync event is sent, telling the consumer events may have been
+// missed.
+func (e *events) SubscribeWithOptions(ctx context.Context, id string, options EventSubscribeOptions) (Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	s := &resumableSubscription{
+		ctx:    ctx,
+		cancel: cancel,
+		dialer: e.dialer(),
+		url:    e.eventsURL(),
+		ch:     make(chan Event),
+		lastID: options.LastEventID,
+	}
+
+	// Connect right away, so an unreachable event stream is reported.
+	conn, resumed, err := s.dial()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Unblock reading the stream once ctx is done.
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.conn.Close()
+	}()
+
+	go s.run(conn, resumed)
+
+	return s, nil
+}
+
+// dial connects to the event stream, and reports whether the server resumes
+// the stream after the last event received.
+func (s *resumableSubscription) dial() (*websocket.Conn, bool, error) {
+	header := make(http.Header)
+	if s.lastID != "" {
+		header.Set("Last-Event-ID", s.lastID)
+	}
+
+	conn, resp, err := s.dialer.DialContext(s.ctx, s.url, header)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Don't hold on to a connection made while ctx got done.
+	if err := s.ctx.Err(); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	s.conn = conn
+
+	resumed := s.lastID == "" || resp.Header.Get("Last-Event-ID") == s.lastID
+	return conn, resumed, nil
+}
+
+// run reads the event stream, reconnecting until ctx is done.
+func (s *resumableSubscription) run(conn *websocket.Conn, resumed bool) {
+	defer close(s.ch)
+
+	for {
+		if !resumed && !s.send(Event{Type: EventResync, Payload: s.lastID}) {
+			return
+		}
+
+		s.read(conn)
+		conn.Close()
+
+		for i := 0; ; i++ {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(backoff(500, 30000, i)):
+			}
+
+			var err error
+			if conn, resumed, err = s.dial(); err == nil {
+				break
+			}
+		}
+	}
+}
+
+// read sends the events read from conn until reading fails.
+func (s *resumableSubscription) read(conn *websocket.Conn) {
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		// Reconnect on a malformed message, to resume after the last
+		// event that could be decoded.
+		var ev Event
+		if err := json.Unmarshal(msg, &ev); err != nil {
+			return
+		}
+
+		if !s.send(ev) {
+			return
+		}
+		if ev.ID != "" {
+			s.lastID = ev.ID
+		}
+	}
+}
+
+// send sends ev, unless ctx is done first.
+func (s *resumableSubscription) send(ev Event) bool {
+	select {
+	case s.ch <- ev:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+func (s *resumableSubscription) C() <-chan Event {
+	return s.ch
+}
+
+func (s *resumableSubscription) Close() error {
+	if s.ctx.Err() != nil {
+		return nil
+	}
+	defer s.cancel()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Cleanly close the connection by sending a close message.
+	return s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
+
+// Handle subscribes to the event stream and dispatches the events to the
+// given handlers. It blocks until ctx is done, in which case ctx.Err() is
+// returned, or until the stream ends. A stream closed normally by the server
+// returns nil, while a stream ending with an error passes it to OnError and
+// returns it.
+func (e *events) Handle(ctx context.Context, id string, handlers EventHandlers) error {
+	sub, err := e.Subscribe(id)
+	if err != nil {
+		return err
+	}
+
+	return handleEvents(ctx, sub, handlers)
+}
+
+// handleEvents dispatches the events of the subscription to the handlers.
+func handleEvents(ctx context.Context, sub Subscription, handlers EventHandlers) error {
+	for {
+		select {
+		case <-ctx.Done():
+			// The stream is being abandoned, so errors closing it are of
+			// no interest. Drain it to let the subscription shut down.
+			sub.Close()
+			go func() {
+				for range sub.C() {
+				}
+			}()
+
+			return ctx.Err()
+		case ev, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+
+			if ev.Type == EventError {
+				err := errors.New(strings.TrimSpace(fmt.Sprint(ev.Payload)))
+				if handlers.OnError != nil {
+					handlers.OnError(err)
+				}
+				return err
+			}
+
+			if fn := handlers.handler(ev.Type); fn != nil {
+				fn(ev)
+			}
+		}
+	}
+}
+
 func (s *subscription) C() <-chan Event {
 	return s.ch
 }
 
+// eventCloseTimeout is how long a closed subscription waits for the server
+// to acknowledge the close message before giving up on reading the stream.
+var eventCloseTimeout = 5 * time.Second
+
 func (s *subscription) Close() error {
 	// Cleanly close the connection by sending a close message and then waiting
-	// (with timeout) for the server to close the connection.
+	// (with timeout) for the server to close the connection. The read
+	// deadline ends the stream even if the server never does.
 	err := s.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-	if err != nil {
-		return err
+	if deadlineErr := s.conn.SetReadDeadline(time.Now().Add(eventCloseTimeout)); err == nil {
+		err = deadlineErr
 	}
-	return nil
+	return err
 }
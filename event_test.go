@@ -1,8 +1,14 @@
 package tfe
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,3 +40,251 @@ func TestEvents(t *testing.T) {
 	runPlanned := <-sub.C()
 	assert.Equal(t, EventRunPlanned, runPlanned.Type)
 }
+
+// fakeSubscription is a subscription fed by a test.
+type fakeSubscription struct {
+	ch     chan Event
+	closed bool
+}
+
+func (s *fakeSubscription) C() <-chan Event { return s.ch }
+
+func (s *fakeSubscription) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestEvents_handleEvents(t *testing.T) {
+	t.Run("dispatching events to their handler", func(t *testing.T) {
+		sub := &fakeSubscription{ch: make(chan Event, 4)}
+		sub.ch <- Event{Type: EventRunCreated, Payload: "run-1"}
+		sub.ch <- Event{Type: EventWorkspaceCreated, Payload: "ws-1"}
+		sub.ch <- Event{Type: EventRunCompleted, Payload: "run-1"}
+		close(sub.ch)
+
+		var created, completed []interface{}
+		err := handleEvents(context.Background(), sub, EventHandlers{
+			OnRunCreated:   func(ev Event) { created = append(created, ev.Payload) },
+			OnRunCompleted: func(ev Event) { completed = append(completed, ev.Payload) },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"run-1"}, created)
+		assert.Equal(t, []interface{}{"run-1"}, completed)
+	})
+
+	t.Run("when the stream ends with an error", func(t *testing.T) {
+		sub := &fakeSubscription{ch: make(chan Event, 1)}
+		sub.ch <- Event{Type: EventError, Payload: "websocket read error: EOF\n"}
+
+		var handled error
+		err := handleEvents(context.Background(), sub, EventHandlers{
+			OnError: func(err error) { handled = err },
+		})
+		assert.EqualError(t, err, "websocket read error: EOF")
+		assert.Equal(t, err, handled)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		sub := &fakeSubscription{ch: make(chan Event)}
+		defer close(sub.ch)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := handleEvents(ctx, sub, EventHandlers{})
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.True(t, sub.closed)
+	})
+}
+
+func TestEvents_Handle(t *testing.T) {
+	var upgrader websocket.Upgrader
+	var acknowledge int32 = 1
+	release := make(chan struct{})
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" {
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if atomic.LoadInt32(&acknowledge) == 0 {
+			// Never acknowledge the close message of the client.
+			<-release
+			return
+		}
+
+		conn.WriteJSON(Event{Type: EventRunCreated, Payload: "run-1"})
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "123", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	t.Run("when the server closes the stream", func(t *testing.T) {
+		var created []interface{}
+		var handled error
+		err := client.Events.Handle(context.Background(), "dummy-id", EventHandlers{
+			OnRunCreated: func(ev Event) { created = append(created, ev.Payload) },
+			OnError:      func(err error) { handled = err },
+		})
+		require.NoError(t, err)
+		assert.NoError(t, handled)
+		assert.Equal(t, []interface{}{"run-1"}, created)
+	})
+
+	t.Run("when the server doesn't acknowledge the close", func(t *testing.T) {
+		timeout := eventCloseTimeout
+		eventCloseTimeout = 100 * time.Millisecond
+		defer func() { eventCloseTimeout = timeout }()
+		atomic.StoreInt32(&acknowledge, 0)
+
+		sub, err := client.Events.Subscribe("dummy-id")
+		require.NoError(t, err)
+		require.NoError(t, sub.Close())
+
+		select {
+		case ev := <-sub.C():
+			assert.Equal(t, EventError, ev.Type)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the stream didn't end after closing the subscription")
+		}
+	})
+}
+
+// newEventServer returns a client for a server streaming the given events on
+// each connection, before dropping it. The Last-Event-ID header of each
+// connection is recorded, and echoed back if resume is true.
+func newEventServer(t *testing.T, resume bool, streams ...[]Event) (*Client, *[]string, func()) {
+	var lastIDs []string
+	var upgrader websocket.Upgrader
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" {
+			return
+		}
+		if len(lastIDs) == len(streams) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		lastID := r.Header.Get("Last-Event-ID")
+		header := make(http.Header)
+		if resume && lastID != "" {
+			header.Set("Last-Event-ID", lastID)
+		}
+
+		conn, err := upgrader.Upgrade(w, r, header)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		stream := streams[len(lastIDs)]
+		lastIDs = append(lastIDs, lastID)
+		for _, ev := range stream {
+			if err := conn.WriteJSON(ev); err != nil {
+				return
+			}
+		}
+	}))
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "123", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	return client, &lastIDs, ts.Close
+}
+
+func TestEvents_SubscribeWithOptions(t *testing.T) {
+	streams := [][]Event{
+		{
+			{ID: "1", Type: EventRunCreated, Payload: "run-1"},
+			{ID: "2", Type: EventPlanQueued, Payload: "run-1"},
+		},
+		{
+			{ID: "3", Type: EventRunPlanned, Payload: "run-1"},
+		},
+	}
+
+	t.Run("when the server resumes the stream", func(t *testing.T) {
+		client, lastIDs, tsCleanup := newEventServer(t, true, streams...)
+		defer tsCleanup()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		sub, err := client.Events.SubscribeWithOptions(ctx, "dummy-id", EventSubscribeOptions{})
+		require.NoError(t, err)
+		defer sub.Close()
+
+		var ids []string
+		for ev := range sub.C() {
+			ids = append(ids, ev.ID)
+			if ev.ID == "3" {
+				cancel()
+			}
+		}
+		assert.Equal(t, []string{"1", "2", "3"}, ids)
+		assert.Equal(t, []string{"", "2"}, *lastIDs)
+	})
+
+	t.Run("when the server can't resume the stream", func(t *testing.T) {
+		client, lastIDs, tsCleanup := newEventServer(t, false, streams...)
+		defer tsCleanup()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		sub, err := client.Events.SubscribeWithOptions(ctx, "dummy-id", EventSubscribeOptions{
+			LastEventID: "0",
+		})
+		require.NoError(t, err)
+		defer sub.Close()
+
+		var got []Event
+		for ev := range sub.C() {
+			got = append(got, ev)
+			if ev.ID == "3" {
+				cancel()
+			}
+		}
+		require.Len(t, got, 5)
+		assert.Equal(t, Event{Type: EventResync, Payload: "0"}, got[0])
+		assert.Equal(t, "1", got[1].ID)
+		assert.Equal(t, "2", got[2].ID)
+		assert.Equal(t, Event{Type: EventResync, Payload: "2"}, got[3])
+		assert.Equal(t, "3", got[4].ID)
+		assert.Equal(t, []string{"0", "2"}, *lastIDs)
+	})
+
+	t.Run("when the subscription is closed", func(t *testing.T) {
+		client, _, tsCleanup := newEventServer(t, true, streams...)
+		defer tsCleanup()
+
+		sub, err := client.Events.SubscribeWithOptions(context.Background(), "dummy-id", EventSubscribeOptions{})
+		require.NoError(t, err)
+
+		ev := <-sub.C()
+		assert.Equal(t, "1", ev.ID)
+		require.NoError(t, sub.Close())
+
+		// The channel gets closed without reconnecting.
+		for range sub.C() {
+		}
+	})
+
+	t.Run("when the event stream is unreachable", func(t *testing.T) {
+		client, _, tsCleanup := newEventServer(t, true)
+		defer tsCleanup()
+
+		sub, err := client.Events.SubscribeWithOptions(context.Background(), "dummy-id", EventSubscribeOptions{})
+		assert.Nil(t, sub)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,129 @@
+// Package fake provides in-memory implementations of the services of the
+// Terraform Enterprise API client, to unit test code using the client
+// without a Terraform Enterprise instance.
+//
+// The fakes honor the basic create, read, list, update and delete semantics
+// of the workspaces, runs, plans, teams, variables and policy sets services:
+// resources are kept in memory, identified by generated IDs, and
+// ErrResourceNotFound is returned for unknown resources. Any other method of
+// these services panics with a message naming it. The services which are not
+// faked are left nil.
+//
+// This includes the events service: its subscriptions stream events pushed by
+// the server as runs progress, which an in-memory store of resources has no
+// way to produce, so a fake would only ever deliver events made up by the
+// test itself.
+//
+//	client := fake.NewClient()
+//	w, err := client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{
+//		Name: tfe.String("app"),
+//	})
+package fake
+
+import (
+	"fmt"
+	"sync"
+
+	tfe "github.com/leg100/go-tfe"
+)
+
+// NewClient returns a client whose workspaces, runs, plans, teams, variables
+// and policy sets services are in-memory fakes sharing the same store, so for
+// instance runs can only be created in workspaces created beforehand.
+func NewClient() *tfe.Client {
+	s := &store{
+		workspaces: make(map[string]*tfe.Workspace),
+		runs:       make(map[string]*tfe.Run),
+		plans:      make(map[string]*tfe.Plan),
+		teams:      make(map[string]*tfe.Team),
+		variables:  make(map[string]*tfe.Variable),
+		policySets: make(map[string]*tfe.PolicySet),
+
+		teamOrganizations: make(map[string]string),
+	}
+
+	return &tfe.Client{
+		Workspaces: &Workspaces{store: s},
+		Runs:       &Runs{store: s},
+		Plans:      &Plans{store: s},
+		Teams:      &Teams{store: s},
+		Variables:  &Variables{store: s},
+		PolicySets: &PolicySets{store: s},
+	}
+}
+
+// store holds the resources of the fakes of a client.
+type store struct {
+	mu sync.Mutex
+
+	workspaces map[string]*tfe.Workspace
+	runs       map[string]*tfe.Run
+	plans      map[string]*tfe.Plan
+	teams      map[string]*tfe.Team
+	variables  map[string]*tfe.Variable
+	policySets map[string]*tfe.PolicySet
+
+	// The organization of each team, as teams don't hold it.
+	teamOrganizations map[string]string
+
+	// The resources of each kind in creation order, to list them.
+	workspaceIDs []string
+	runIDs       []string
+	teamIDs      []string
+	variableIDs  []string
+	policySetIDs []string
+
+	lastID int
+}
+
+// newID returns a new unique ID with the given prefix, such as "ws".
+func (s *store) newID(prefix string) string {
+	s.lastID++
+	return fmt.Sprintf("%s-fake%012d", prefix, s.lastID)
+}
+
+// remove removes an ID from a list of IDs.
+func remove(ids []string, id string) []string {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// paginate returns the bounds of the requested page of a list of n items,
+// and its pagination details, using the same defaults as the API.
+func paginate(n int, options tfe.ListOptions) (start, end int, p *tfe.Pagination) {
+	pageNumber := options.PageNumber
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	p = &tfe.Pagination{
+		CurrentPage: pageNumber,
+		TotalCount:  n,
+		TotalPages:  (n + pageSize - 1) / pageSize,
+	}
+	if pageNumber > 1 {
+		p.PreviousPage = pageNumber - 1
+	}
+	if pageNumber < p.TotalPages {
+		p.NextPage = pageNumber + 1
+	}
+
+	start = (pageNumber - 1) * pageSize
+	if start > n {
+		start = n
+	}
+	end = start + pageSize
+	if end > n {
+		end = n
+	}
+
+	return start, end, p
+}
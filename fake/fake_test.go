@@ -0,0 +1,289 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	tfe "github.com/leg100/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaces(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	w, err := client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{
+		Name:      tfe.String("app"),
+		AutoApply: tfe.Bool(true),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, w.ID)
+	assert.Equal(t, "app", w.Name)
+	assert.True(t, w.AutoApply)
+	assert.Equal(t, "acme", w.Organization.Name)
+
+	_, err = client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{Name: tfe.String("app")})
+	assert.Error(t, err)
+
+	_, err = client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{})
+	assert.Equal(t, tfe.ErrRequiredName, err)
+
+	for _, name := range []string{"app-staging", "web"} {
+		_, err := client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{Name: tfe.String(name)})
+		require.NoError(t, err)
+	}
+	_, err = client.Workspaces.Create(ctx, "other", tfe.WorkspaceCreateOptions{Name: tfe.String("app")})
+	require.NoError(t, err)
+
+	t.Run("list", func(t *testing.T) {
+		wl, err := client.Workspaces.List(ctx, "acme", tfe.WorkspaceListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, wl.TotalCount)
+		assert.Len(t, wl.Items, 3)
+
+		wl, err = client.Workspaces.List(ctx, "acme", tfe.WorkspaceListOptions{Search: tfe.String("app")})
+		require.NoError(t, err)
+		assert.Len(t, wl.Items, 2)
+
+		wl, err = client.Workspaces.List(ctx, "acme", tfe.WorkspaceListOptions{
+			ListOptions: tfe.ListOptions{PageNumber: 2, PageSize: 2},
+		})
+		require.NoError(t, err)
+		require.Len(t, wl.Items, 1)
+		assert.Equal(t, "web", wl.Items[0].Name)
+		assert.Equal(t, 2, wl.TotalPages)
+		assert.Equal(t, 0, wl.NextPage)
+	})
+
+	t.Run("read and update", func(t *testing.T) {
+		read, err := client.Workspaces.Read(ctx, "acme", "app")
+		require.NoError(t, err)
+		assert.Equal(t, w.ID, read.ID)
+
+		updated, err := client.Workspaces.UpdateByID(ctx, w.ID, tfe.WorkspaceUpdateOptions{
+			Name:        tfe.String("api"),
+			Description: tfe.String("The API"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "api", updated.Name)
+
+		read, err = client.Workspaces.ReadByID(ctx, w.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "The API", read.Description)
+
+		_, err = client.Workspaces.Read(ctx, "acme", "app")
+		assert.Equal(t, tfe.ErrResourceNotFound, err)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := client.Workspaces.Delete(ctx, "acme", "api")
+		require.NoError(t, err)
+
+		_, err = client.Workspaces.ReadByID(ctx, w.ID)
+		assert.Equal(t, tfe.ErrResourceNotFound, err)
+
+		err = client.Workspaces.DeleteByID(ctx, w.ID)
+		assert.Equal(t, tfe.ErrResourceNotFound, err)
+	})
+}
+
+func TestRuns(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	w, err := client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{Name: tfe.String("app")})
+	require.NoError(t, err)
+
+	_, err = client.Runs.Create(ctx, tfe.RunCreateOptions{Workspace: &tfe.Workspace{ID: "ws-unknown"}})
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+
+	r, err := client.Runs.Create(ctx, tfe.RunCreateOptions{
+		Workspace: w,
+		Message:   tfe.String("Deploy"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tfe.RunPlanned, r.Status)
+	assert.True(t, r.Actions.IsConfirmable)
+
+	po, err := client.Runs.Create(ctx, tfe.RunCreateOptions{Workspace: w, PlanOnly: tfe.Bool(true)})
+	require.NoError(t, err)
+	assert.Equal(t, tfe.RunPlannedAndFinished, po.Status)
+
+	rl, err := client.Runs.List(ctx, w.ID, tfe.RunListOptions{})
+	require.NoError(t, err)
+	require.Len(t, rl.Items, 2)
+	assert.Equal(t, po.ID, rl.Items[0].ID)
+
+	err = client.Runs.Apply(ctx, r.ID, tfe.RunApplyOptions{})
+	require.NoError(t, err)
+
+	r, err = client.Runs.Read(ctx, r.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tfe.RunApplied, r.Status)
+
+	p, err := client.Plans.Read(ctx, r.Plan.ID)
+	require.NoError(t, err)
+	assert.Equal(t, tfe.PlanFinished, p.Status)
+
+	err = client.Runs.Discard(ctx, r.ID, tfe.RunDiscardOptions{})
+	assert.Error(t, err)
+
+	err = client.Workspaces.DeleteByID(ctx, w.ID)
+	require.NoError(t, err)
+
+	_, err = client.Runs.Read(ctx, r.ID)
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+
+	_, err = client.Plans.Read(ctx, r.Plan.ID)
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+
+	assert.PanicsWithValue(t, "fake: Runs.ReadFull not implemented", func() {
+		client.Runs.ReadFull(ctx, r.ID)
+	})
+}
+
+func TestTeams(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	tm, err := client.Teams.Create(ctx, "acme", tfe.TeamCreateOptions{
+		Name: tfe.String("developers"),
+		OrganizationAccess: &tfe.OrganizationAccessOptions{
+			ManageWorkspaces: tfe.Bool(true),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tfe.TeamVisibilitySecret, tm.Visibility)
+	assert.True(t, tm.OrganizationAccess.ManageWorkspaces)
+
+	_, err = client.Teams.Create(ctx, "acme", tfe.TeamCreateOptions{Name: tfe.String("developers")})
+	assert.Error(t, err)
+
+	visibility := tfe.TeamVisibility("public")
+	_, err = client.Teams.Update(ctx, tm.ID, tfe.TeamUpdateOptions{Visibility: &visibility})
+	assert.Equal(t, tfe.ErrInvalidTeamVisibility, err)
+
+	tm, err = client.Teams.Update(ctx, tm.ID, tfe.TeamUpdateOptions{Name: tfe.String("engineers")})
+	require.NoError(t, err)
+	assert.Equal(t, "engineers", tm.Name)
+
+	tl, err := client.Teams.List(ctx, "acme", tfe.TeamListOptions{})
+	require.NoError(t, err)
+	require.Len(t, tl.Items, 1)
+	assert.Equal(t, "engineers", tl.Items[0].Name)
+
+	err = client.Teams.Delete(ctx, tm.ID)
+	require.NoError(t, err)
+
+	_, err = client.Teams.Read(ctx, tm.ID)
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+}
+
+func TestVariables(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	w, err := client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{Name: tfe.String("app")})
+	require.NoError(t, err)
+
+	v, err := client.Variables.Create(ctx, w.ID, tfe.VariableCreateOptions{
+		Key:       tfe.String("password"),
+		Value:     tfe.String("secret"),
+		Category:  tfe.Category(tfe.CategoryEnv),
+		Sensitive: tfe.Bool(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "", v.Value)
+
+	_, err = client.Variables.Create(ctx, w.ID, tfe.VariableCreateOptions{
+		Key:      tfe.String("password"),
+		Category: tfe.Category(tfe.CategoryEnv),
+	})
+	assert.Error(t, err)
+
+	_, err = client.Variables.Create(ctx, w.ID, tfe.VariableCreateOptions{Key: tfe.String("region")})
+	assert.EqualError(t, err, "category is required")
+
+	_, err = client.Variables.Update(ctx, w.ID, v.ID, tfe.VariableUpdateOptions{Sensitive: tfe.Bool(false)})
+	assert.Error(t, err)
+
+	v, err = client.Variables.Update(ctx, w.ID, v.ID, tfe.VariableUpdateOptions{Key: tfe.String("db_password")})
+	require.NoError(t, err)
+	assert.Equal(t, "db_password", v.Key)
+
+	vl, err := client.Variables.List(ctx, w.ID, tfe.VariableListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, vl.Items, 1)
+
+	_, err = client.Variables.Read(ctx, "ws-unknown", v.ID)
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+
+	err = client.Variables.Delete(ctx, w.ID, v.ID)
+	require.NoError(t, err)
+
+	vl, err = client.Variables.List(ctx, w.ID, tfe.VariableListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, vl.Items)
+}
+
+func TestPolicySets(t *testing.T) {
+	client := NewClient()
+	ctx := context.Background()
+
+	w, err := client.Workspaces.Create(ctx, "acme", tfe.WorkspaceCreateOptions{Name: tfe.String("app")})
+	require.NoError(t, err)
+
+	_, err = client.PolicySets.Create(ctx, "acme", tfe.PolicySetCreateOptions{
+		Name:       tfe.String("security"),
+		Workspaces: []*tfe.Workspace{{ID: "ws-unknown"}},
+	})
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+
+	ps, err := client.PolicySets.Create(ctx, "acme", tfe.PolicySetCreateOptions{
+		Name:        tfe.String("security"),
+		Description: tfe.String("Security policies"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", ps.Organization.Name)
+	assert.Equal(t, 0, ps.WorkspaceCount)
+
+	_, err = client.PolicySets.Create(ctx, "acme", tfe.PolicySetCreateOptions{Name: tfe.String("security")})
+	assert.Error(t, err)
+
+	err = client.PolicySets.AddWorkspaces(ctx, ps.ID, tfe.PolicySetAddWorkspacesOptions{
+		Workspaces: []*tfe.Workspace{w, w},
+	})
+	require.NoError(t, err)
+
+	ps, err = client.PolicySets.Read(ctx, ps.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ps.WorkspaceCount)
+	require.Len(t, ps.Workspaces, 1)
+	assert.Equal(t, w.ID, ps.Workspaces[0].ID)
+
+	ps, err = client.PolicySets.Update(ctx, ps.ID, tfe.PolicySetUpdateOptions{Global: tfe.Bool(true)})
+	require.NoError(t, err)
+	assert.True(t, ps.Global)
+	assert.Equal(t, "Security policies", ps.Description)
+
+	psl, err := client.PolicySets.List(ctx, "acme", tfe.PolicySetListOptions{Search: tfe.String("sec")})
+	require.NoError(t, err)
+	require.Len(t, psl.Items, 1)
+	assert.Equal(t, ps.ID, psl.Items[0].ID)
+
+	err = client.PolicySets.RemoveWorkspaces(ctx, ps.ID, tfe.PolicySetRemoveWorkspacesOptions{
+		Workspaces: []*tfe.Workspace{w},
+	})
+	require.NoError(t, err)
+
+	ps, err = client.PolicySets.Read(ctx, ps.ID)
+	require.NoError(t, err)
+	assert.Empty(t, ps.Workspaces)
+
+	err = client.PolicySets.Delete(ctx, ps.ID)
+	require.NoError(t, err)
+
+	_, err = client.PolicySets.Read(ctx, ps.ID)
+	assert.Equal(t, tfe.ErrResourceNotFound, err)
+}
@@ -0,0 +1,59 @@
+package fake
+
+import (
+	"context"
+	"io"
+
+	tfe "github.com/leg100/go-tfe"
+)
+
+// Compile-time proof of interface implementation.
+var _ tfe.Plans = (*Plans)(nil)
+
+// Plans is an in-memory fake of the plans service. It implements Read; any
+// other method panics.
+//
+// A finished plan is created along with every run, as runs are created
+// already planned.
+type Plans struct {
+	store *store
+}
+
+// Read a plan by its ID.
+func (s *Plans) Read(ctx context.Context, planID string) (*tfe.Plan, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	p, ok := s.store.plans[planID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return copyPlan(p), nil
+}
+
+// copyPlan returns a copy of a stored plan, so callers can't change the
+// store.
+func copyPlan(p *tfe.Plan) *tfe.Plan {
+	c := *p
+	timestamps := *p.StatusTimestamps
+	c.StatusTimestamps = &timestamps
+	return &c
+}
+
+// The methods below are not faked.
+
+func (s *Plans) ReadWithResourceDrift(ctx context.Context, planID string) (*tfe.Plan, error) {
+	panic("fake: Plans.ReadWithResourceDrift not implemented")
+}
+
+func (s *Plans) Logs(ctx context.Context, planID string) (io.Reader, error) {
+	panic("fake: Plans.Logs not implemented")
+}
+
+func (s *Plans) JSONOutput(ctx context.Context, planID string) ([]byte, error) {
+	panic("fake: Plans.JSONOutput not implemented")
+}
+
+func (s *Plans) GeneratedConfiguration(ctx context.Context, planID string) ([]byte, error) {
+	panic("fake: Plans.GeneratedConfiguration not implemented")
+}
@@ -0,0 +1,254 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tfe "github.com/leg100/go-tfe"
+)
+
+// Compile-time proof of interface implementation.
+var _ tfe.PolicySets = (*PolicySets)(nil)
+
+// PolicySets is an in-memory fake of the policy sets service. It implements
+// List, Create, Read, ReadWithOptions, Update, AddWorkspaces,
+// RemoveWorkspaces and Delete; any other method panics.
+//
+// Policy sets can only be attached to workspaces created beforehand, and
+// their policies aren't faked.
+type PolicySets struct {
+	store *store
+}
+
+// List the policy sets of an organization, filtered by name when searching.
+func (s *PolicySets) List(ctx context.Context, organization string, options tfe.PolicySetListOptions) (*tfe.PolicySetList, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var items []*tfe.PolicySet
+	for _, id := range s.store.policySetIDs {
+		ps := s.store.policySets[id]
+		if ps.Organization.Name != organization {
+			continue
+		}
+		if options.Search != nil && !strings.Contains(ps.Name, *options.Search) {
+			continue
+		}
+		items = append(items, copyPolicySet(ps))
+	}
+
+	start, end, p := paginate(len(items), options.ListOptions)
+	return &tfe.PolicySetList{Pagination: p, Items: items[start:end]}, nil
+}
+
+// Create a policy set in an organization.
+func (s *PolicySets) Create(ctx context.Context, organization string, options tfe.PolicySetCreateOptions) (*tfe.PolicySet, error) {
+	if options.Name == nil || *options.Name == "" {
+		return nil, tfe.ErrRequiredName
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for _, ps := range s.store.policySets {
+		if ps.Organization.Name == organization && ps.Name == *options.Name {
+			return nil, fmt.Errorf("policy set %s already exists in organization %s", *options.Name, organization)
+		}
+	}
+
+	now := time.Now()
+	ps := &tfe.PolicySet{
+		ID:           s.store.newID("polset"),
+		Name:         *options.Name,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Organization: &tfe.Organization{Name: organization},
+	}
+	if options.Description != nil {
+		ps.Description = *options.Description
+	}
+	if options.Global != nil {
+		ps.Global = *options.Global
+	}
+	if options.PoliciesPath != nil {
+		ps.PoliciesPath = *options.PoliciesPath
+	}
+	if err := s.addWorkspaces(ps, options.Workspaces); err != nil {
+		return nil, err
+	}
+
+	s.store.policySets[ps.ID] = ps
+	s.store.policySetIDs = append(s.store.policySetIDs, ps.ID)
+
+	return copyPolicySet(ps), nil
+}
+
+// Read a policy set by its ID.
+func (s *PolicySets) Read(ctx context.Context, policySetID string) (*tfe.PolicySet, error) {
+	return s.ReadWithOptions(ctx, policySetID, nil)
+}
+
+// ReadWithOptions reads a policy set by its ID. Its workspaces are always
+// populated with their IDs, whatever relations are included.
+func (s *PolicySets) ReadWithOptions(ctx context.Context, policySetID string, options *tfe.PolicySetReadOptions) (*tfe.PolicySet, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	ps, ok := s.store.policySets[policySetID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return copyPolicySet(ps), nil
+}
+
+// Update a policy set by its ID.
+func (s *PolicySets) Update(ctx context.Context, policySetID string, options tfe.PolicySetUpdateOptions) (*tfe.PolicySet, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	ps, ok := s.store.policySets[policySetID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+
+	updated := copyPolicySet(ps)
+	if options.Name != nil {
+		updated.Name = *options.Name
+	}
+	if options.Description != nil {
+		updated.Description = *options.Description
+	}
+	if options.Global != nil {
+		updated.Global = *options.Global
+	}
+	if options.PoliciesPath != nil {
+		updated.PoliciesPath = *options.PoliciesPath
+	}
+	updated.UpdatedAt = time.Now()
+	s.store.policySets[policySetID] = updated
+
+	return copyPolicySet(updated), nil
+}
+
+// AddWorkspaces attaches existing workspaces to a policy set.
+func (s *PolicySets) AddWorkspaces(ctx context.Context, policySetID string, options tfe.PolicySetAddWorkspacesOptions) error {
+	if options.Workspaces == nil {
+		return tfe.ErrWorkspacesRequired
+	}
+	if len(options.Workspaces) == 0 {
+		return tfe.ErrWorkspaceMinLimit
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	ps, ok := s.store.policySets[policySetID]
+	if !ok {
+		return tfe.ErrResourceNotFound
+	}
+	return s.addWorkspaces(ps, options.Workspaces)
+}
+
+// RemoveWorkspaces detaches workspaces from a policy set.
+func (s *PolicySets) RemoveWorkspaces(ctx context.Context, policySetID string, options tfe.PolicySetRemoveWorkspacesOptions) error {
+	if options.Workspaces == nil {
+		return tfe.ErrWorkspacesRequired
+	}
+	if len(options.Workspaces) == 0 {
+		return tfe.ErrWorkspaceMinLimit
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	ps, ok := s.store.policySets[policySetID]
+	if !ok {
+		return tfe.ErrResourceNotFound
+	}
+
+	removed := make(map[string]bool, len(options.Workspaces))
+	for _, w := range options.Workspaces {
+		removed[w.ID] = true
+	}
+
+	var workspaces []*tfe.Workspace
+	for _, w := range ps.Workspaces {
+		if !removed[w.ID] {
+			workspaces = append(workspaces, w)
+		}
+	}
+	ps.Workspaces = workspaces
+	ps.WorkspaceCount = len(workspaces)
+
+	return nil
+}
+
+// Delete a policy set by its ID.
+func (s *PolicySets) Delete(ctx context.Context, policySetID string) error {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.policySets[policySetID]; !ok {
+		return tfe.ErrResourceNotFound
+	}
+
+	delete(s.store.policySets, policySetID)
+	s.store.policySetIDs = remove(s.store.policySetIDs, policySetID)
+
+	return nil
+}
+
+// addWorkspaces attaches workspaces to a policy set, provided they all exist
+// in the organization of the policy set. Workspaces already attached are
+// skipped.
+func (s *PolicySets) addWorkspaces(ps *tfe.PolicySet, workspaces []*tfe.Workspace) error {
+	attached := make(map[string]bool, len(ps.Workspaces))
+	for _, w := range ps.Workspaces {
+		attached[w.ID] = true
+	}
+
+	for _, w := range workspaces {
+		stored, ok := s.store.workspaces[w.ID]
+		if !ok || stored.Organization.Name != ps.Organization.Name {
+			return tfe.ErrResourceNotFound
+		}
+	}
+
+	for _, w := range workspaces {
+		if !attached[w.ID] {
+			ps.Workspaces = append(ps.Workspaces, &tfe.Workspace{ID: w.ID})
+			attached[w.ID] = true
+		}
+	}
+	ps.WorkspaceCount = len(ps.Workspaces)
+
+	return nil
+}
+
+// copyPolicySet returns a copy of a stored policy set, so callers can't
+// change the store.
+func copyPolicySet(ps *tfe.PolicySet) *tfe.PolicySet {
+	c := *ps
+	c.Organization = &tfe.Organization{Name: ps.Organization.Name}
+	c.Workspaces = nil
+	for _, w := range ps.Workspaces {
+		c.Workspaces = append(c.Workspaces, &tfe.Workspace{ID: w.ID})
+	}
+	return &c
+}
+
+// The methods below are not faked.
+
+func (s *PolicySets) AddPolicies(ctx context.Context, policySetID string, options tfe.PolicySetAddPoliciesOptions) error {
+	panic("fake: PolicySets.AddPolicies not implemented")
+}
+
+func (s *PolicySets) RemovePolicies(ctx context.Context, policySetID string, options tfe.PolicySetRemovePoliciesOptions) error {
+	panic("fake: PolicySets.RemovePolicies not implemented")
+}
+
+func (s *PolicySets) ReconcileWorkspaces(ctx context.Context, policySetID string, desired []string) (added, removed []string, err error) {
+	panic("fake: PolicySets.ReconcileWorkspaces not implemented")
+}
@@ -0,0 +1,238 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tfe "github.com/leg100/go-tfe"
+)
+
+// Compile-time proof of interface implementation.
+var _ tfe.Runs = (*Runs)(nil)
+
+// Runs is an in-memory fake of the runs service. It implements List, Create,
+// Read, Apply, Cancel and Discard; any other method panics.
+//
+// Runs are created already planned: a plan-only run is finished, and any
+// other run waits for confirmation until it is applied, canceled or
+// discarded.
+type Runs struct {
+	store *store
+}
+
+// List the runs of a workspace, the most recent first.
+func (s *Runs) List(ctx context.Context, workspaceID string, options tfe.RunListOptions) (*tfe.RunList, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.workspaces[workspaceID]; !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+
+	var items []*tfe.Run
+	for i := len(s.store.runIDs) - 1; i >= 0; i-- {
+		r := s.store.runs[s.store.runIDs[i]]
+		if r.Workspace.ID == workspaceID {
+			items = append(items, copyRun(r))
+		}
+	}
+
+	start, end, p := paginate(len(items), options.ListOptions)
+	return &tfe.RunList{Pagination: p, Items: items[start:end]}, nil
+}
+
+// Create a run in an existing workspace.
+func (s *Runs) Create(ctx context.Context, options tfe.RunCreateOptions) (*tfe.Run, error) {
+	if options.Workspace == nil {
+		return nil, errors.New("workspace is required")
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	w, ok := s.store.workspaces[options.Workspace.ID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+
+	r := &tfe.Run{
+		ID:               s.store.newID("run"),
+		AutoApply:        w.AutoApply,
+		CreatedAt:        time.Now(),
+		Permissions:      &tfe.RunPermissions{},
+		Source:           tfe.RunSourceAPI,
+		StatusTimestamps: &tfe.RunStatusTimestamps{},
+		Workspace:        &tfe.Workspace{ID: w.ID},
+	}
+	if options.IsDestroy != nil {
+		r.IsDestroy = *options.IsDestroy
+	}
+	if options.Message != nil {
+		r.Message = *options.Message
+	}
+	if options.PlanOnly != nil {
+		r.PlanOnly = *options.PlanOnly
+	}
+
+	if r.PlanOnly {
+		setRunStatus(r, tfe.RunPlannedAndFinished)
+	} else {
+		setRunStatus(r, tfe.RunPlanned)
+	}
+
+	finishedAt := r.CreatedAt
+	p := &tfe.Plan{
+		ID:               s.store.newID("plan"),
+		Status:           tfe.PlanFinished,
+		StatusTimestamps: &tfe.PlanStatusTimestamps{FinishedAt: &finishedAt},
+	}
+	s.store.plans[p.ID] = p
+	r.Plan = &tfe.Plan{ID: p.ID}
+
+	s.store.runs[r.ID] = r
+	s.store.runIDs = append(s.store.runIDs, r.ID)
+
+	return copyRun(r), nil
+}
+
+// Read a run by its ID.
+func (s *Runs) Read(ctx context.Context, runID string) (*tfe.Run, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	r, ok := s.store.runs[runID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return copyRun(r), nil
+}
+
+// Apply a run waiting for confirmation.
+func (s *Runs) Apply(ctx context.Context, runID string, options tfe.RunApplyOptions) error {
+	return s.transition(runID, tfe.RunApplied, func(a *tfe.RunActions) bool {
+		return a.IsConfirmable
+	})
+}
+
+// Cancel a run which has not finished yet.
+func (s *Runs) Cancel(ctx context.Context, runID string, options tfe.RunCancelOptions) error {
+	return s.transition(runID, tfe.RunCanceled, func(a *tfe.RunActions) bool {
+		return a.IsCancelable
+	})
+}
+
+// Discard a run waiting for confirmation.
+func (s *Runs) Discard(ctx context.Context, runID string, options tfe.RunDiscardOptions) error {
+	return s.transition(runID, tfe.RunDiscarded, func(a *tfe.RunActions) bool {
+		return a.IsDiscardable
+	})
+}
+
+// transition moves a run to the given status, provided the allowed function
+// reports the transition is one of the actions of the run.
+func (s *Runs) transition(runID string, status tfe.RunStatus, allowed func(*tfe.RunActions) bool) error {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	r, ok := s.store.runs[runID]
+	if !ok {
+		return tfe.ErrResourceNotFound
+	}
+	if !allowed(r.Actions) {
+		return fmt.Errorf("run %s can't be %s from status %s", runID, status, r.Status)
+	}
+
+	setRunStatus(r, status)
+	return nil
+}
+
+// setRunStatus sets the status of a run, and the actions available in that
+// status.
+func setRunStatus(r *tfe.Run, status tfe.RunStatus) {
+	r.Status = status
+	r.Actions = &tfe.RunActions{}
+	if status == tfe.RunPlanned {
+		r.Actions.IsCancelable = true
+		r.Actions.IsConfirmable = true
+		r.Actions.IsDiscardable = true
+	}
+}
+
+// copyRun returns a copy of a stored run, so callers can't change the store.
+func copyRun(r *tfe.Run) *tfe.Run {
+	c := *r
+	actions := *r.Actions
+	c.Actions = &actions
+	c.Workspace = &tfe.Workspace{ID: r.Workspace.ID}
+	c.Plan = &tfe.Plan{ID: r.Plan.ID}
+	return &c
+}
+
+// The methods below are not faked.
+
+func (s *Runs) Count(ctx context.Context, workspaceID string, options tfe.RunListOptions) (int, error) {
+	panic("fake: Runs.Count not implemented")
+}
+
+func (s *Runs) ForEach(ctx context.Context, workspaceID string, options tfe.RunListOptions, fn func(*tfe.Run) error) error {
+	panic("fake: Runs.ForEach not implemented")
+}
+
+func (s *Runs) CreateAndWait(ctx context.Context, options tfe.RunCreateOptions, wait tfe.RunWaitOptions) (*tfe.Run, error) {
+	panic("fake: Runs.CreateAndWait not implemented")
+}
+
+func (s *Runs) WaitForStart(ctx context.Context, runID string, options tfe.RunWaitForStartOptions) (*tfe.Run, error) {
+	panic("fake: Runs.WaitForStart not implemented")
+}
+
+func (s *Runs) WaitForConfirmable(ctx context.Context, runID string) (*tfe.Run, error) {
+	panic("fake: Runs.WaitForConfirmable not implemented")
+}
+
+func (s *Runs) ReadWithOptions(ctx context.Context, runID string, options tfe.RunReadOptions) (*tfe.Run, error) {
+	panic("fake: Runs.ReadWithOptions not implemented")
+}
+
+func (s *Runs) ReadFull(ctx context.Context, runID string) (*tfe.Run, error) {
+	panic("fake: Runs.ReadFull not implemented")
+}
+
+func (s *Runs) ReadPlan(ctx context.Context, runID string) (*tfe.Plan, error) {
+	panic("fake: Runs.ReadPlan not implemented")
+}
+
+func (s *Runs) ReadApply(ctx context.Context, runID string) (*tfe.Apply, error) {
+	panic("fake: Runs.ReadApply not implemented")
+}
+
+func (s *Runs) CancelAll(ctx context.Context, workspaceID string, options tfe.RunCancelOptions) (int, error) {
+	panic("fake: Runs.CancelAll not implemented")
+}
+
+func (s *Runs) ForceCancel(ctx context.Context, runID string, options tfe.RunForceCancelOptions) error {
+	panic("fake: Runs.ForceCancel not implemented")
+}
+
+func (s *Runs) DiscardOlderThan(ctx context.Context, workspaceID string, age time.Duration, options tfe.RunDiscardOptions) (int, error) {
+	panic("fake: Runs.DiscardOlderThan not implemented")
+}
+
+func (s *Runs) GetPlanFile(ctx context.Context, runID string, options tfe.PlanFileOptions) ([]byte, error) {
+	panic("fake: Runs.GetPlanFile not implemented")
+}
+
+func (s *Runs) UploadPlanFile(ctx context.Context, runID string, plan []byte, options tfe.PlanFileOptions) error {
+	panic("fake: Runs.UploadPlanFile not implemented")
+}
+
+func (s *Runs) UploadLogs(ctx context.Context, runID string, chunk []byte, options tfe.RunUploadLogsOptions) error {
+	panic("fake: Runs.UploadLogs not implemented")
+}
+
+func (s *Runs) Tail(ctx context.Context, runID string, w io.Writer) error {
+	panic("fake: Runs.Tail not implemented")
+}
@@ -0,0 +1,162 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+
+	tfe "github.com/leg100/go-tfe"
+)
+
+// Compile-time proof of interface implementation.
+var _ tfe.Teams = (*Teams)(nil)
+
+// Teams is an in-memory fake of the teams service. It implements List,
+// Create, Read, Update and Delete; any other method panics.
+type Teams struct {
+	store *store
+}
+
+// List the teams of an organization.
+func (s *Teams) List(ctx context.Context, organization string, options tfe.TeamListOptions) (*tfe.TeamList, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var items []*tfe.Team
+	for _, id := range s.store.teamIDs {
+		if s.store.teamOrganizations[id] == organization {
+			items = append(items, copyTeam(s.store.teams[id]))
+		}
+	}
+
+	start, end, p := paginate(len(items), options.ListOptions)
+	return &tfe.TeamList{Pagination: p, Items: items[start:end]}, nil
+}
+
+// Create a team in an organization.
+func (s *Teams) Create(ctx context.Context, organization string, options tfe.TeamCreateOptions) (*tfe.Team, error) {
+	if options.Name == nil || *options.Name == "" {
+		return nil, tfe.ErrRequiredName
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for id, org := range s.store.teamOrganizations {
+		if org == organization && s.store.teams[id].Name == *options.Name {
+			return nil, fmt.Errorf("team %s already exists in organization %s", *options.Name, organization)
+		}
+	}
+
+	t := &tfe.Team{
+		ID:                 s.store.newID("team"),
+		Name:               *options.Name,
+		OrganizationAccess: &tfe.OrganizationAccess{},
+		Visibility:         tfe.TeamVisibilitySecret,
+		Permissions:        &tfe.TeamPermissions{CanDestroy: true, CanUpdateMembership: true},
+	}
+	if err := applyTeamOptions(t, options.OrganizationAccess, options.Visibility); err != nil {
+		return nil, err
+	}
+
+	s.store.teamOrganizations[t.ID] = organization
+	s.store.teams[t.ID] = t
+	s.store.teamIDs = append(s.store.teamIDs, t.ID)
+
+	return copyTeam(t), nil
+}
+
+// Read a team by its ID.
+func (s *Teams) Read(ctx context.Context, teamID string) (*tfe.Team, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	t, ok := s.store.teams[teamID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return copyTeam(t), nil
+}
+
+// Update a team by its ID.
+func (s *Teams) Update(ctx context.Context, teamID string, options tfe.TeamUpdateOptions) (*tfe.Team, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	t, ok := s.store.teams[teamID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+
+	updated := copyTeam(t)
+	if options.Name != nil {
+		updated.Name = *options.Name
+	}
+	if err := applyTeamOptions(updated, options.OrganizationAccess, options.Visibility); err != nil {
+		return nil, err
+	}
+	s.store.teams[teamID] = updated
+
+	return copyTeam(updated), nil
+}
+
+// Delete a team by its ID.
+func (s *Teams) Delete(ctx context.Context, teamID string) error {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.teams[teamID]; !ok {
+		return tfe.ErrResourceNotFound
+	}
+
+	delete(s.store.teams, teamID)
+	delete(s.store.teamOrganizations, teamID)
+	s.store.teamIDs = remove(s.store.teamIDs, teamID)
+
+	return nil
+}
+
+func applyTeamOptions(t *tfe.Team, access *tfe.OrganizationAccessOptions, visibility *tfe.TeamVisibility) error {
+	if visibility != nil {
+		if *visibility != tfe.TeamVisibilitySecret && *visibility != tfe.TeamVisibilityOrganization {
+			return tfe.ErrInvalidTeamVisibility
+		}
+		t.Visibility = *visibility
+	}
+	if access == nil {
+		return nil
+	}
+	if access.ManagePolicies != nil {
+		t.OrganizationAccess.ManagePolicies = *access.ManagePolicies
+	}
+	if access.ManagePolicyOverrides != nil {
+		t.OrganizationAccess.ManagePolicyOverrides = *access.ManagePolicyOverrides
+	}
+	if access.ManageWorkspaces != nil {
+		t.OrganizationAccess.ManageWorkspaces = *access.ManageWorkspaces
+	}
+	if access.ManageVCSSettings != nil {
+		t.OrganizationAccess.ManageVCSSettings = *access.ManageVCSSettings
+	}
+	return nil
+}
+
+// copyTeam returns a copy of a stored team, so callers can't change the
+// store.
+func copyTeam(t *tfe.Team) *tfe.Team {
+	c := *t
+	access := *t.OrganizationAccess
+	c.OrganizationAccess = &access
+	permissions := *t.Permissions
+	c.Permissions = &permissions
+	return &c
+}
+
+// The methods below are not faked.
+
+func (s *Teams) ReadWithOptions(ctx context.Context, teamID string, options tfe.TeamReadOptions) (*tfe.Team, error) {
+	panic("fake: Teams.ReadWithOptions not implemented")
+}
+
+func (s *Teams) ListWithPolicyManagement(ctx context.Context, organization string) ([]*tfe.Team, error) {
+	panic("fake: Teams.ListWithPolicyManagement not implemented")
+}
@@ -0,0 +1,174 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tfe "github.com/leg100/go-tfe"
+)
+
+// Compile-time proof of interface implementation.
+var _ tfe.Variables = (*Variables)(nil)
+
+// Variables is an in-memory fake of the variables service. It implements
+// List, Create, Read, Update and Delete; any other method panics.
+type Variables struct {
+	store *store
+}
+
+// List the variables of a workspace.
+func (s *Variables) List(ctx context.Context, workspaceID string, options tfe.VariableListOptions) (*tfe.VariableList, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.workspaces[workspaceID]; !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+
+	var items []*tfe.Variable
+	for _, id := range s.store.variableIDs {
+		v := s.store.variables[id]
+		if v.Workspace.ID == workspaceID {
+			items = append(items, copyVariable(v))
+		}
+	}
+
+	start, end, p := paginate(len(items), options.ListOptions)
+	return &tfe.VariableList{Pagination: p, Items: items[start:end]}, nil
+}
+
+// Create a variable in an existing workspace.
+func (s *Variables) Create(ctx context.Context, workspaceID string, options tfe.VariableCreateOptions) (*tfe.Variable, error) {
+	if options.Key == nil || *options.Key == "" {
+		return nil, errors.New("key is required")
+	}
+	if options.Category == nil {
+		return nil, errors.New("category is required")
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.workspaces[workspaceID]; !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	if s.find(workspaceID, *options.Key, *options.Category) != nil {
+		return nil, fmt.Errorf("%s variable %s already exists in workspace %s", *options.Category, *options.Key, workspaceID)
+	}
+
+	v := &tfe.Variable{
+		ID:        s.store.newID("var"),
+		Key:       *options.Key,
+		Category:  *options.Category,
+		Workspace: &tfe.Workspace{ID: workspaceID},
+	}
+	if options.Value != nil {
+		v.Value = *options.Value
+	}
+	if options.Description != nil {
+		v.Description = *options.Description
+	}
+	if options.HCL != nil {
+		v.HCL = *options.HCL
+	}
+	if options.Sensitive != nil {
+		v.Sensitive = *options.Sensitive
+	}
+
+	s.store.variables[v.ID] = v
+	s.store.variableIDs = append(s.store.variableIDs, v.ID)
+
+	return copyVariable(v), nil
+}
+
+// Read a variable of a workspace by its ID.
+func (s *Variables) Read(ctx context.Context, workspaceID string, variableID string) (*tfe.Variable, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	v, ok := s.store.variables[variableID]
+	if !ok || v.Workspace.ID != workspaceID {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return copyVariable(v), nil
+}
+
+// Update a variable of a workspace by its ID.
+func (s *Variables) Update(ctx context.Context, workspaceID string, variableID string, options tfe.VariableUpdateOptions) (*tfe.Variable, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	v, ok := s.store.variables[variableID]
+	if !ok || v.Workspace.ID != workspaceID {
+		return nil, tfe.ErrResourceNotFound
+	}
+
+	if options.Sensitive != nil && !*options.Sensitive && v.Sensitive {
+		return nil, errors.New("a sensitive variable can't be made non-sensitive")
+	}
+	if options.Key != nil && *options.Key != v.Key {
+		if s.find(workspaceID, *options.Key, v.Category) != nil {
+			return nil, fmt.Errorf("%s variable %s already exists in workspace %s", v.Category, *options.Key, workspaceID)
+		}
+		v.Key = *options.Key
+	}
+	if options.Value != nil {
+		v.Value = *options.Value
+	}
+	if options.Description != nil {
+		v.Description = *options.Description
+	}
+	if options.HCL != nil {
+		v.HCL = *options.HCL
+	}
+	if options.Sensitive != nil {
+		v.Sensitive = *options.Sensitive
+	}
+
+	return copyVariable(v), nil
+}
+
+// Delete a variable of a workspace by its ID.
+func (s *Variables) Delete(ctx context.Context, workspaceID string, variableID string) error {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	v, ok := s.store.variables[variableID]
+	if !ok || v.Workspace.ID != workspaceID {
+		return tfe.ErrResourceNotFound
+	}
+
+	delete(s.store.variables, variableID)
+	s.store.variableIDs = remove(s.store.variableIDs, variableID)
+
+	return nil
+}
+
+// find returns the variable of a workspace with the given key and category,
+// or nil. The store must be locked.
+func (s *Variables) find(workspaceID, key string, category tfe.CategoryType) *tfe.Variable {
+	for _, v := range s.store.variables {
+		if v.Workspace.ID == workspaceID && v.Key == key && v.Category == category {
+			return v
+		}
+	}
+	return nil
+}
+
+// copyVariable returns a copy of a stored variable, so callers can't change
+// the store. Like the API, the value of a sensitive variable is not returned.
+func copyVariable(v *tfe.Variable) *tfe.Variable {
+	c := *v
+	if c.Sensitive {
+		c.Value = ""
+	}
+	c.Workspace = &tfe.Workspace{ID: v.Workspace.ID}
+	return &c
+}
+
+// The methods below are not faked.
+
+func (s *Variables) Reconcile(ctx context.Context, workspaceID string, desired []tfe.VariableSpec) (created, updated, deleted int, err error) {
+	panic("fake: Variables.Reconcile not implemented")
+}
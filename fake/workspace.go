@@ -0,0 +1,339 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tfe "github.com/leg100/go-tfe"
+)
+
+// Compile-time proof of interface implementation.
+var _ tfe.Workspaces = (*Workspaces)(nil)
+
+// Workspaces is an in-memory fake of the workspaces service. It implements
+// List, Create, Read, ReadByID, Update, UpdateByID, Delete and DeleteByID;
+// any other method panics.
+type Workspaces struct {
+	store *store
+}
+
+// List the workspaces of an organization, filtered by name when searching.
+func (s *Workspaces) List(ctx context.Context, organization string, options tfe.WorkspaceListOptions) (*tfe.WorkspaceList, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	var items []*tfe.Workspace
+	for _, id := range s.store.workspaceIDs {
+		w := s.store.workspaces[id]
+		if w.Organization.Name != organization {
+			continue
+		}
+		if options.Search != nil && !strings.Contains(w.Name, *options.Search) {
+			continue
+		}
+		items = append(items, copyWorkspace(w))
+	}
+
+	start, end, p := paginate(len(items), options.ListOptions)
+	return &tfe.WorkspaceList{Pagination: p, Items: items[start:end]}, nil
+}
+
+// Create a workspace in an organization.
+func (s *Workspaces) Create(ctx context.Context, organization string, options tfe.WorkspaceCreateOptions) (*tfe.Workspace, error) {
+	if err := options.Valid(); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if s.find(organization, *options.Name) != nil {
+		return nil, fmt.Errorf("workspace %s already exists in organization %s", *options.Name, organization)
+	}
+
+	now := time.Now()
+	w := &tfe.Workspace{
+		ID:            s.store.newID("ws"),
+		Name:          *options.Name,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		ExecutionMode: "remote",
+		Operations:    true,
+		Actions:       &tfe.WorkspaceActions{},
+		Permissions:   &tfe.WorkspacePermissions{},
+		Organization:  &tfe.Organization{Name: organization},
+	}
+	applyWorkspaceOptions(w, workspaceOptions{
+		AutoApply:        options.AutoApply,
+		Description:      options.Description,
+		ExecutionMode:    options.ExecutionMode,
+		TerraformVersion: options.TerraformVersion,
+		WorkingDirectory: options.WorkingDirectory,
+	})
+
+	s.store.workspaces[w.ID] = w
+	s.store.workspaceIDs = append(s.store.workspaceIDs, w.ID)
+
+	return copyWorkspace(w), nil
+}
+
+// Read a workspace by its name.
+func (s *Workspaces) Read(ctx context.Context, organization, workspace string) (*tfe.Workspace, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	w := s.find(organization, workspace)
+	if w == nil {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return copyWorkspace(w), nil
+}
+
+// ReadByID reads a workspace by its ID.
+func (s *Workspaces) ReadByID(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	w, ok := s.store.workspaces[workspaceID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return copyWorkspace(w), nil
+}
+
+// Update a workspace by its name.
+func (s *Workspaces) Update(ctx context.Context, organization, workspace string, options tfe.WorkspaceUpdateOptions) (*tfe.Workspace, error) {
+	s.store.mu.Lock()
+	w := s.find(organization, workspace)
+	s.store.mu.Unlock()
+
+	if w == nil {
+		return nil, tfe.ErrResourceNotFound
+	}
+	return s.UpdateByID(ctx, w.ID, options)
+}
+
+// UpdateByID updates a workspace by its ID.
+func (s *Workspaces) UpdateByID(ctx context.Context, workspaceID string, options tfe.WorkspaceUpdateOptions) (*tfe.Workspace, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	w, ok := s.store.workspaces[workspaceID]
+	if !ok {
+		return nil, tfe.ErrResourceNotFound
+	}
+
+	if options.Name != nil && *options.Name != w.Name {
+		if s.find(w.Organization.Name, *options.Name) != nil {
+			return nil, fmt.Errorf("workspace %s already exists in organization %s", *options.Name, w.Organization.Name)
+		}
+		w.Name = *options.Name
+	}
+	applyWorkspaceOptions(w, workspaceOptions{
+		AutoApply:        options.AutoApply,
+		Description:      options.Description,
+		ExecutionMode:    options.ExecutionMode,
+		TerraformVersion: options.TerraformVersion,
+		WorkingDirectory: options.WorkingDirectory,
+	})
+	w.UpdatedAt = time.Now()
+
+	return copyWorkspace(w), nil
+}
+
+// Delete a workspace by its name.
+func (s *Workspaces) Delete(ctx context.Context, organization, workspace string) error {
+	s.store.mu.Lock()
+	w := s.find(organization, workspace)
+	s.store.mu.Unlock()
+
+	if w == nil {
+		return tfe.ErrResourceNotFound
+	}
+	return s.DeleteByID(ctx, w.ID)
+}
+
+// DeleteByID deletes a workspace by its ID, together with its runs, their
+// plans and its variables, and detaches it from policy sets.
+func (s *Workspaces) DeleteByID(ctx context.Context, workspaceID string) error {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.workspaces[workspaceID]; !ok {
+		return tfe.ErrResourceNotFound
+	}
+
+	delete(s.store.workspaces, workspaceID)
+	s.store.workspaceIDs = remove(s.store.workspaceIDs, workspaceID)
+
+	for id, r := range s.store.runs {
+		if r.Workspace.ID == workspaceID {
+			delete(s.store.runs, id)
+			delete(s.store.plans, r.Plan.ID)
+			s.store.runIDs = remove(s.store.runIDs, id)
+		}
+	}
+	for id, v := range s.store.variables {
+		if v.Workspace.ID == workspaceID {
+			delete(s.store.variables, id)
+			s.store.variableIDs = remove(s.store.variableIDs, id)
+		}
+	}
+	for _, ps := range s.store.policySets {
+		var workspaces []*tfe.Workspace
+		for _, w := range ps.Workspaces {
+			if w.ID != workspaceID {
+				workspaces = append(workspaces, w)
+			}
+		}
+		ps.Workspaces = workspaces
+		ps.WorkspaceCount = len(workspaces)
+	}
+
+	return nil
+}
+
+// find returns the workspace with the given name, or nil. The store must be
+// locked.
+func (s *Workspaces) find(organization, name string) *tfe.Workspace {
+	for _, w := range s.store.workspaces {
+		if w.Organization.Name == organization && w.Name == name {
+			return w
+		}
+	}
+	return nil
+}
+
+// workspaceOptions holds the workspace attributes shared by the create and
+// update options which the fake keeps.
+type workspaceOptions struct {
+	AutoApply        *bool
+	Description      *string
+	ExecutionMode    *string
+	TerraformVersion *string
+	WorkingDirectory *string
+}
+
+func applyWorkspaceOptions(w *tfe.Workspace, options workspaceOptions) {
+	if options.AutoApply != nil {
+		w.AutoApply = *options.AutoApply
+	}
+	if options.Description != nil {
+		w.Description = *options.Description
+	}
+	if options.ExecutionMode != nil {
+		w.ExecutionMode = *options.ExecutionMode
+		w.Operations = w.ExecutionMode != "local"
+	}
+	if options.TerraformVersion != nil {
+		w.TerraformVersion = *options.TerraformVersion
+	}
+	if options.WorkingDirectory != nil {
+		w.WorkingDirectory = *options.WorkingDirectory
+	}
+}
+
+// copyWorkspace returns a copy of a stored workspace, so callers can't
+// change the store.
+func copyWorkspace(w *tfe.Workspace) *tfe.Workspace {
+	c := *w
+	c.Organization = &tfe.Organization{Name: w.Organization.Name}
+	return &c
+}
+
+// The methods below are not faked.
+
+func (s *Workspaces) Count(ctx context.Context, organization string, options tfe.WorkspaceListOptions) (int, error) {
+	panic("fake: Workspaces.Count not implemented")
+}
+
+func (s *Workspaces) ForEach(ctx context.Context, organization string, options tfe.WorkspaceListOptions, fn func(*tfe.Workspace) error) error {
+	panic("fake: Workspaces.ForEach not implemented")
+}
+
+func (s *Workspaces) CreateMany(ctx context.Context, organization string, inputs []tfe.WorkspaceCreateOptions, concurrency int) ([]*tfe.Workspace, []error) {
+	panic("fake: Workspaces.CreateMany not implemented")
+}
+
+func (s *Workspaces) Readme(ctx context.Context, workspaceID string) (io.Reader, error) {
+	panic("fake: Workspaces.Readme not implemented")
+}
+
+func (s *Workspaces) ReadByIDWithOptions(ctx context.Context, workspaceID string, options tfe.WorkspaceReadOptions) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.ReadByIDWithOptions not implemented")
+}
+
+func (s *Workspaces) LatestRunLogs(ctx context.Context, workspaceID string, w io.Writer) error {
+	panic("fake: Workspaces.LatestRunLogs not implemented")
+}
+
+func (s *Workspaces) RemoveVCSConnection(ctx context.Context, organization, workspace string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.RemoveVCSConnection not implemented")
+}
+
+func (s *Workspaces) RemoveVCSConnectionByID(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.RemoveVCSConnectionByID not implemented")
+}
+
+func (s *Workspaces) EnableAutoApply(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.EnableAutoApply not implemented")
+}
+
+func (s *Workspaces) DisableAutoApply(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.DisableAutoApply not implemented")
+}
+
+func (s *Workspaces) RemoveAutoDestroy(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.RemoveAutoDestroy not implemented")
+}
+
+func (s *Workspaces) WaitForUnlock(ctx context.Context, workspaceID string, interval time.Duration) error {
+	panic("fake: Workspaces.WaitForUnlock not implemented")
+}
+
+func (s *Workspaces) Lock(ctx context.Context, workspaceID string, options tfe.WorkspaceLockOptions) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.Lock not implemented")
+}
+
+func (s *Workspaces) Unlock(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.Unlock not implemented")
+}
+
+func (s *Workspaces) ForceUnlock(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.ForceUnlock not implemented")
+}
+
+func (s *Workspaces) AssignSSHKey(ctx context.Context, workspaceID string, options tfe.WorkspaceAssignSSHKeyOptions) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.AssignSSHKey not implemented")
+}
+
+func (s *Workspaces) UnassignSSHKey(ctx context.Context, workspaceID string) (*tfe.Workspace, error) {
+	panic("fake: Workspaces.UnassignSSHKey not implemented")
+}
+
+func (s *Workspaces) RemoteStateConsumers(ctx context.Context, workspaceID string) (*tfe.WorkspaceList, error) {
+	panic("fake: Workspaces.RemoteStateConsumers not implemented")
+}
+
+func (s *Workspaces) AddRemoteStateConsumers(ctx context.Context, workspaceID string, options tfe.WorkspaceAddRemoteStateConsumersOptions) error {
+	panic("fake: Workspaces.AddRemoteStateConsumers not implemented")
+}
+
+func (s *Workspaces) RemoveRemoteStateConsumers(ctx context.Context, workspaceID string, options tfe.WorkspaceRemoveRemoteStateConsumersOptions) error {
+	panic("fake: Workspaces.RemoveRemoteStateConsumers not implemented")
+}
+
+func (s *Workspaces) UpdateRemoteStateConsumers(ctx context.Context, workspaceID string, options tfe.WorkspaceUpdateRemoteStateConsumersOptions) error {
+	panic("fake: Workspaces.UpdateRemoteStateConsumers not implemented")
+}
+
+func (s *Workspaces) ListPolicySets(ctx context.Context, workspaceID string, options tfe.ListOptions) (*tfe.PolicySetList, error) {
+	panic("fake: Workspaces.ListPolicySets not implemented")
+}
+
+func (s *Workspaces) EffectiveTags(ctx context.Context, workspaceID string) ([]*tfe.EffectiveTagBinding, error) {
+	panic("fake: Workspaces.EffectiveTags not implemented")
+}
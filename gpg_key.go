@@ -0,0 +1,229 @@
+package tfe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Compile-time proof of interface implementation.
+var _ GPGKeys = (*gpgKeys)(nil)
+
+// GPGKeys describes all the GPG key related methods that the Terraform
+// Enterprise API supports. GPG keys are used to verify the signatures of
+// providers published to the private registry of an organization.
+//
+// TFE API docs:
+// https://www.terraform.io/docs/cloud/api/private-registry/gpg-keys.html
+type GPGKeys interface {
+	// List all the GPG keys of the private registry of an organization.
+	List(ctx context.Context, organization string, options GPGKeyListOptions) (*GPGKeyList, error)
+
+	// Create a GPG key in the private registry of an organization.
+	Create(ctx context.Context, organization string, options GPGKeyCreateOptions) (*GPGKey, error)
+
+	// Read a GPG key by its key ID.
+	Read(ctx context.Context, organization string, keyID string) (*GPGKey, error)
+
+	// Update a GPG key by its key ID.
+	Update(ctx context.Context, organization string, keyID string, options GPGKeyUpdateOptions) (*GPGKey, error)
+
+	// Delete a GPG key by its key ID.
+	Delete(ctx context.Context, organization string, keyID string) error
+}
+
+// gpgKeys implements GPGKeys.
+type gpgKeys struct {
+	client *Client
+}
+
+// gpgKeysPath is the path of the GPG keys of the private registry. Unlike
+// most endpoints it is not served on the base path of the API.
+const gpgKeysPath = "/api/registry/private/v2/gpg-keys"
+
+// GPGKeyList represents a list of GPG keys.
+type GPGKeyList struct {
+	*Pagination
+	Items []*GPGKey
+}
+
+// GPGKey represents a GPG key in the private registry.
+type GPGKey struct {
+	ID             string    `jsonapi:"primary,gpg-keys"`
+	ASCIIArmor     string    `jsonapi:"attr,ascii-armor"`
+	CreatedAt      time.Time `jsonapi:"attr,created-at,iso8601"`
+	KeyID          string    `jsonapi:"attr,key-id"`
+	Namespace      string    `jsonapi:"attr,namespace"`
+	Source         string    `jsonapi:"attr,source"`
+	SourceURL      *string   `jsonapi:"attr,source-url"`
+	TrustSignature string    `jsonapi:"attr,trust-signature"`
+	UpdatedAt      time.Time `jsonapi:"attr,updated-at,iso8601"`
+}
+
+// GPGKeyListOptions represents the options for listing GPG keys.
+type GPGKeyListOptions struct {
+	ListOptions
+
+	// For internal use only!
+	Namespace string `schema:"filter[namespace]"`
+}
+
+// List all the GPG keys of the private registry of an organization.
+func (s *gpgKeys) List(ctx context.Context, organization string, options GPGKeyListOptions) (*GPGKeyList, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+
+	// The keys are filtered by the namespace they belong to.
+	options.Namespace = organization
+
+	req, err := s.client.newRequest("GET", gpgKeysPath, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	kl := &GPGKeyList{}
+	err = s.client.do(ctx, req, kl)
+	if err != nil {
+		return nil, err
+	}
+
+	return kl, nil
+}
+
+// GPGKeyCreateOptions represents the options for creating a GPG key.
+type GPGKeyCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,gpg-keys"`
+
+	// For internal use only!
+	Namespace string `jsonapi:"attr,namespace"`
+
+	// The ASCII armored public key.
+	ASCIIArmor *string `jsonapi:"attr,ascii-armor"`
+}
+
+func (o GPGKeyCreateOptions) valid() error {
+	if !validString(o.ASCIIArmor) {
+		return ErrRequiredASCIIArmor
+	}
+	return nil
+}
+
+// Create a GPG key in the private registry of an organization.
+func (s *gpgKeys) Create(ctx context.Context, organization string, options GPGKeyCreateOptions) (*GPGKey, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// The key is created in the namespace of the organization.
+	options.Namespace = organization
+
+	req, err := s.client.newRequest("POST", gpgKeysPath, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &GPGKey{}
+	err = s.client.do(ctx, req, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Read a GPG key by its key ID.
+func (s *gpgKeys) Read(ctx context.Context, organization string, keyID string) (*GPGKey, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+	if !validStringID(&keyID) {
+		return nil, ErrInvalidGPGKeyID
+	}
+
+	u := fmt.Sprintf("%s/%s/%s", gpgKeysPath, url.QueryEscape(organization), url.QueryEscape(keyID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &GPGKey{}
+	err = s.client.do(ctx, req, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// GPGKeyUpdateOptions represents the options for updating a GPG key.
+type GPGKeyUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,gpg-keys"`
+
+	// The organization to move the GPG key to.
+	Namespace *string `jsonapi:"attr,namespace"`
+}
+
+func (o GPGKeyUpdateOptions) valid() error {
+	if !validStringID(o.Namespace) {
+		return ErrInvalidOrg
+	}
+	return nil
+}
+
+// Update a GPG key by its key ID.
+func (s *gpgKeys) Update(ctx context.Context, organization string, keyID string, options GPGKeyUpdateOptions) (*GPGKey, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+	if !validStringID(&keyID) {
+		return nil, ErrInvalidGPGKeyID
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/%s/%s", gpgKeysPath, url.QueryEscape(organization), url.QueryEscape(keyID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &GPGKey{}
+	err = s.client.do(ctx, req, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Delete a GPG key by its key ID.
+func (s *gpgKeys) Delete(ctx context.Context, organization string, keyID string) error {
+	if !validStringID(&organization) {
+		return ErrInvalidOrg
+	}
+	if !validStringID(&keyID) {
+		return ErrInvalidGPGKeyID
+	}
+
+	u := fmt.Sprintf("%s/%s/%s", gpgKeysPath, url.QueryEscape(organization), url.QueryEscape(keyID))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
@@ -0,0 +1,226 @@
+package tfe
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGPGKeysList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	kTest, kTestCleanup := createGPGKey(t, client, orgTest)
+	defer kTestCleanup()
+
+	t.Run("without list options", func(t *testing.T) {
+		kl, err := client.GPGKeys.List(ctx, orgTest.Name, GPGKeyListOptions{})
+		require.NoError(t, err)
+		require.Len(t, kl.Items, 1)
+		assert.Equal(t, kTest.KeyID, kl.Items[0].KeyID)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		kl, err := client.GPGKeys.List(ctx, badIdentifier, GPGKeyListOptions{})
+		assert.Nil(t, kl)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+}
+
+func TestGPGKeysCreate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	armor, err := ioutil.ReadFile("test-fixtures/gpg-key/public.asc")
+	require.NoError(t, err)
+
+	t.Run("with valid options", func(t *testing.T) {
+		k, err := client.GPGKeys.Create(ctx, orgTest.Name, GPGKeyCreateOptions{
+			ASCIIArmor: String(string(armor)),
+		})
+		require.NoError(t, err)
+		defer client.GPGKeys.Delete(ctx, orgTest.Name, k.KeyID)
+
+		// Get a refreshed view from the API.
+		refreshed, err := client.GPGKeys.Read(ctx, orgTest.Name, k.KeyID)
+		require.NoError(t, err)
+
+		for _, item := range []*GPGKey{
+			k,
+			refreshed,
+		} {
+			assert.NotEmpty(t, item.ID)
+			assert.NotEmpty(t, item.KeyID)
+			assert.Equal(t, orgTest.Name, item.Namespace)
+			assert.Equal(t, string(armor), item.ASCIIArmor)
+		}
+	})
+
+	t.Run("when options is missing the ASCII armor", func(t *testing.T) {
+		k, err := client.GPGKeys.Create(ctx, orgTest.Name, GPGKeyCreateOptions{})
+		assert.Nil(t, k)
+		assert.EqualError(t, err, ErrRequiredASCIIArmor.Error())
+	})
+
+	t.Run("when options has an invalid organization", func(t *testing.T) {
+		k, err := client.GPGKeys.Create(ctx, badIdentifier, GPGKeyCreateOptions{
+			ASCIIArmor: String(string(armor)),
+		})
+		assert.Nil(t, k)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+}
+
+func TestGPGKeysRead(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	kTest, kTestCleanup := createGPGKey(t, client, orgTest)
+	defer kTestCleanup()
+
+	t.Run("when the GPG key exists", func(t *testing.T) {
+		k, err := client.GPGKeys.Read(ctx, orgTest.Name, kTest.KeyID)
+		require.NoError(t, err)
+		assert.Equal(t, kTest, k)
+	})
+
+	t.Run("when the GPG key does not exist", func(t *testing.T) {
+		k, err := client.GPGKeys.Read(ctx, orgTest.Name, "nonexisting")
+		assert.Nil(t, k)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid GPG key ID", func(t *testing.T) {
+		k, err := client.GPGKeys.Read(ctx, orgTest.Name, badIdentifier)
+		assert.Nil(t, k)
+		assert.EqualError(t, err, ErrInvalidGPGKeyID.Error())
+	})
+}
+
+func TestGPGKeysUpdate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+	orgTest2, orgTest2Cleanup := createOrganization(t, client)
+	defer orgTest2Cleanup()
+
+	kTest, kTestCleanup := createGPGKey(t, client, orgTest)
+	defer kTestCleanup()
+
+	t.Run("when moving the GPG key to another organization", func(t *testing.T) {
+		k, err := client.GPGKeys.Update(ctx, orgTest.Name, kTest.KeyID, GPGKeyUpdateOptions{
+			Namespace: String(orgTest2.Name),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, orgTest2.Name, k.Namespace)
+
+		// Move the key back so it can be cleaned up.
+		k, err = client.GPGKeys.Update(ctx, orgTest2.Name, kTest.KeyID, GPGKeyUpdateOptions{
+			Namespace: String(orgTest.Name),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, orgTest.Name, k.Namespace)
+	})
+
+	t.Run("without a valid namespace", func(t *testing.T) {
+		k, err := client.GPGKeys.Update(ctx, orgTest.Name, kTest.KeyID, GPGKeyUpdateOptions{})
+		assert.Nil(t, k)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+
+	t.Run("without a valid GPG key ID", func(t *testing.T) {
+		k, err := client.GPGKeys.Update(ctx, orgTest.Name, badIdentifier, GPGKeyUpdateOptions{
+			Namespace: String(orgTest2.Name),
+		})
+		assert.Nil(t, k)
+		assert.EqualError(t, err, ErrInvalidGPGKeyID.Error())
+	})
+}
+
+func TestGPGKeysDelete(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	kTest, _ := createGPGKey(t, client, orgTest)
+
+	t.Run("with valid options", func(t *testing.T) {
+		err := client.GPGKeys.Delete(ctx, orgTest.Name, kTest.KeyID)
+		require.NoError(t, err)
+
+		// Try loading the GPG key - it should fail.
+		_, err = client.GPGKeys.Read(ctx, orgTest.Name, kTest.KeyID)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid GPG key ID", func(t *testing.T) {
+		err := client.GPGKeys.Delete(ctx, orgTest.Name, badIdentifier)
+		assert.EqualError(t, err, ErrInvalidGPGKeyID.Error())
+	})
+}
+
+func TestGPGKeys_requests(t *testing.T) {
+	var requests []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+		switch r.Method {
+		case "GET":
+			if r.URL.Path == "/api/registry/private/v2/gpg-keys" {
+				checkedWrite(t, w, []byte(`{"data":[{"id":"1","type":"gpg-keys","attributes":{"key-id":"3FA6BBC9FA357C09","namespace":"acme"}}]}`))
+				return
+			}
+			fallthrough
+		case "POST", "PATCH":
+			checkedWrite(t, w, []byte(`{"data":{"id":"1","type":"gpg-keys","attributes":{"key-id":"3FA6BBC9FA357C09","namespace":"acme"}}}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+
+	kl, err := client.GPGKeys.List(ctx, "acme", GPGKeyListOptions{})
+	require.NoError(t, err)
+	require.Len(t, kl.Items, 1)
+	assert.Equal(t, "3FA6BBC9FA357C09", kl.Items[0].KeyID)
+
+	_, err = client.GPGKeys.Create(ctx, "acme", GPGKeyCreateOptions{ASCIIArmor: String("armor")})
+	require.NoError(t, err)
+
+	k, err := client.GPGKeys.Read(ctx, "acme", "3FA6BBC9FA357C09")
+	require.NoError(t, err)
+	assert.Equal(t, "acme", k.Namespace)
+
+	_, err = client.GPGKeys.Update(ctx, "acme", "3FA6BBC9FA357C09", GPGKeyUpdateOptions{Namespace: String("other")})
+	require.NoError(t, err)
+
+	err = client.GPGKeys.Delete(ctx, "acme", "3FA6BBC9FA357C09")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /api/registry/private/v2/gpg-keys?filter%5Bnamespace%5D=acme",
+		"POST /api/registry/private/v2/gpg-keys",
+		"GET /api/registry/private/v2/gpg-keys/acme/3FA6BBC9FA357C09",
+		"PATCH /api/registry/private/v2/gpg-keys/acme/3FA6BBC9FA357C09",
+		"DELETE /api/registry/private/v2/gpg-keys/acme/3FA6BBC9FA357C09",
+	}, requests)
+}
@@ -9,7 +9,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +34,49 @@ func testClient(t *testing.T) *Client {
 	return client
 }
 
+// testServerClient returns a client for a test server passing the requests
+// of the client to handler. The ping made by NewClient is answered by the
+// server itself, and the server is closed when the test ends.
+func testServerClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ping" {
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "123", HTTPClient: ts.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+// assertHydrated asserts that v, a pointer to a resource decoded from a
+// relation, holds more than just its ID: at least one of its attributes must
+// be set.
+func assertHydrated(t *testing.T, v interface{}) {
+	t.Helper()
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		t.Fatalf("expected a non-nil pointer to a resource, got %T", v)
+	}
+	rv = rv.Elem()
+
+	for i := 0; i < rv.NumField(); i++ {
+		tag := rv.Type().Field(i).Tag.Get("jsonapi")
+		if strings.HasPrefix(tag, "attr,") && !rv.Field(i).IsZero() {
+			return
+		}
+	}
+	t.Fatalf("expected %T to be hydrated, but only its ID is set", v)
+}
+
 func fetchTestAccountDetails(t *testing.T, client *Client) *TestAccountDetails {
 	if _testAccountDetails == nil {
 		_testAccountDetails = FetchTestAccountDetails(t, client)
@@ -702,6 +749,39 @@ func createRegistryModuleWithVersion(t *testing.T, client *Client, org *Organiza
 	}
 }
 
+func createGPGKey(t *testing.T, client *Client, org *Organization) (*GPGKey, func()) {
+	var orgCleanup func()
+
+	if org == nil {
+		org, orgCleanup = createOrganization(t, client)
+	}
+
+	armor, err := ioutil.ReadFile("test-fixtures/gpg-key/public.asc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	key, err := client.GPGKeys.Create(ctx, org.Name, GPGKeyCreateOptions{
+		ASCIIArmor: String(string(armor)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, func() {
+		if err := client.GPGKeys.Delete(ctx, key.Namespace, key.KeyID); err != nil {
+			t.Errorf("Error destroying GPG key! WARNING: Dangling resources\n"+
+				"may exist! The full error is shown below.\n\n"+
+				"GPGKey: %s\nError: %s", key.KeyID, err)
+		}
+
+		if orgCleanup != nil {
+			orgCleanup()
+		}
+	}
+}
+
 func createSSHKey(t *testing.T, client *Client, org *Organization) (*SSHKey, func()) {
 	var orgCleanup func()
 
@@ -32,6 +32,21 @@ func backoff(min, max float64, iter int) time.Duration {
 	return time.Duration(backoff) * time.Millisecond
 }
 
+// pollWait waits until interval has passed since start, the time at which the
+// last poll started, so a slow poll doesn't delay the next one any further.
+// It returns ctx.Err() if ctx is done first.
+func pollWait(ctx context.Context, start time.Time, interval time.Duration) error {
+	timer := time.NewTimer(interval - time.Since(start))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func (r *LogReader) Read(l []byte) (int, error) {
 	if written, err := r.read(l); err != io.ErrNoProgress {
 		return written, err
@@ -63,10 +78,11 @@ func (r *LogReader) read(l []byte) (int, error) {
 	}
 	req = req.WithContext(r.ctx)
 
-	// Attach the default headers.
+	// Attach the default headers and those of the context.
 	for k, v := range r.client.headers {
 		req.Header[k] = v
 	}
+	setContextHeaders(r.ctx, req.Header)
 
 	// Retrieve the next chunk.
 	resp, err := r.client.http.HTTPClient.Do(req)
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 // checkedWrite writes message to w and fails the test if there's an error.
@@ -260,3 +261,36 @@ func TestLogReader_withoutEndOfTextMarker(t *testing.T) {
 		t.Fatalf("expected 42 log reads, got %d reads", logReads)
 	}
 }
+
+func TestPollWait(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("after a slow poll", func(t *testing.T) {
+		start := time.Now()
+		if err := pollWait(ctx, start.Add(-time.Second), 500*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		if waited := time.Since(start); waited > 100*time.Millisecond {
+			t.Fatalf("expected no wait, waited %s", waited)
+		}
+	})
+
+	t.Run("after a fast poll", func(t *testing.T) {
+		start := time.Now()
+		if err := pollWait(ctx, start, 50*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		if waited := time.Since(start); waited < 50*time.Millisecond {
+			t.Fatalf("expected to wait 50ms, waited %s", waited)
+		}
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		if err := pollWait(ctx, time.Now(), time.Minute); err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
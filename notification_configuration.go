@@ -2,6 +2,7 @@ package tfe
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -71,33 +72,61 @@ type NotificationConfigurationList struct {
 
 // NotificationConfiguration represents a Notification Configuration.
 type NotificationConfiguration struct {
-	ID                string                      `jsonapi:"primary,notification-configurations"`
-	CreatedAt         time.Time                   `jsonapi:"attr,created-at,iso8601"`
-	DeliveryResponses []*DeliveryResponse         `jsonapi:"attr,delivery-responses"`
-	DestinationType   NotificationDestinationType `jsonapi:"attr,destination-type"`
-	Enabled           bool                        `jsonapi:"attr,enabled"`
-	Name              string                      `jsonapi:"attr,name"`
-	Token             string                      `jsonapi:"attr,token"`
-	Triggers          []string                    `jsonapi:"attr,triggers"`
-	UpdatedAt         time.Time                   `jsonapi:"attr,updated-at,iso8601"`
-	URL               string                      `jsonapi:"attr,url"`
+	ID              string                      `jsonapi:"primary,notification-configurations"`
+	CreatedAt       time.Time                   `jsonapi:"attr,created-at,iso8601"`
+	DestinationType NotificationDestinationType `jsonapi:"attr,destination-type"`
+	Enabled         bool                        `jsonapi:"attr,enabled"`
+	Name            string                      `jsonapi:"attr,name"`
+	Token           string                      `jsonapi:"attr,token"`
+	Triggers        []string                    `jsonapi:"attr,triggers"`
+	UpdatedAt       time.Time                   `jsonapi:"attr,updated-at,iso8601"`
+	URL             string                      `jsonapi:"attr,url"`
 
 	// EmailAddresses is only available for TFE users. It is not available in TFC.
 	EmailAddresses []string `jsonapi:"attr,email-addresses"`
 
+	// DeliveryResponses holds the results of the recent deliveries. It is
+	// only populated when reading, listing, creating, updating or verifying
+	// notification configurations, not when they are included.
+	DeliveryResponses []*DeliveryResponse
+
 	// Relations
 	Subscribable *Workspace `jsonapi:"relation,subscribable"`
 	EmailUsers   []*User    `jsonapi:"relation,users"`
 }
 
+// unmarshalRawResource implements rawResourceUnmarshaler. The delivery
+// responses are decoded here, as the jsonapi package doesn't support lists
+// of objects as attributes.
+func (nc *NotificationConfiguration) unmarshalRawResource(r *rawResource) error {
+	raw, ok := r.Attributes["delivery-responses"]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(raw, &nc.DeliveryResponses)
+}
+
+// LatestDeliveryResponse returns the most recently sent delivery response, or
+// nil if nothing was delivered yet. Use Verify to deliver a verification
+// payload and get the notification configuration with its result.
+func (nc *NotificationConfiguration) LatestDeliveryResponse() *DeliveryResponse {
+	var latest *DeliveryResponse
+	for _, dr := range nc.DeliveryResponses {
+		if latest == nil || dr.SentAt.After(latest.SentAt) {
+			latest = dr
+		}
+	}
+	return latest
+}
+
 // DeliveryResponse represents a notification configuration delivery response.
 type DeliveryResponse struct {
-	Body       string              `jsonapi:"attr,body"`
-	Code       string              `jsonapi:"attr,code"`
-	Headers    map[string][]string `jsonapi:"attr,headers"`
-	SentAt     time.Time           `jsonapi:"attr,sent-at,rfc3339"`
-	Successful string              `jsonapi:"attr,successful"`
-	URL        string              `jsonapi:"attr,url"`
+	Body       string              `json:"body"`
+	Code       string              `json:"code"`
+	Headers    map[string][]string `json:"headers"`
+	SentAt     time.Time           `json:"sent-at"`
+	Successful string              `json:"successful"`
+	URL        string              `json:"url"`
 }
 
 // NotificationConfigurationListOptions represents the options for listing
@@ -2,7 +2,9 @@ package tfe
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -316,3 +318,43 @@ func TestNotificationConfigurationVerify(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for notification configuration ID")
 	})
 }
+
+func TestNotificationConfigurationDeliveryResponses(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/notification-configurations/nc-123/actions/verify":
+			checkedWrite(t, w, []byte(`{"data":{"id":"nc-123","type":"notification-configurations","attributes":{"name":"slack","delivery-responses":[`+
+				`{"url":"https://example.com/hook","body":"ok","code":"200","headers":{"content-type":["text/plain"]},"sent-at":"2021-10-21T22:07:34+00:00","successful":"true"},`+
+				`{"url":"https://example.com/hook","body":"no_service","code":"404","headers":{},"sent-at":"2021-10-22T08:00:00+00:00","successful":"false"}`+
+				`]}}}`))
+		case "/api/v2/notification-configurations/nc-456/actions/verify":
+			checkedWrite(t, w, []byte(`{"data":{"id":"nc-456","type":"notification-configurations","attributes":{"name":"email","delivery-responses":[]}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with delivery responses", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Verify(ctx, "nc-123")
+		require.NoError(t, err)
+		assert.Equal(t, "slack", nc.Name)
+		require.Len(t, nc.DeliveryResponses, 2)
+		assert.Equal(t, "200", nc.DeliveryResponses[0].Code)
+		assert.Equal(t, []string{"text/plain"}, nc.DeliveryResponses[0].Headers["content-type"])
+
+		latest := nc.LatestDeliveryResponse()
+		require.NotNil(t, latest)
+		assert.Equal(t, "404", latest.Code)
+		assert.Equal(t, "false", latest.Successful)
+		assert.Equal(t, time.Date(2021, 10, 22, 8, 0, 0, 0, time.UTC), latest.SentAt.UTC())
+	})
+
+	t.Run("without delivery responses", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Verify(ctx, "nc-456")
+		require.NoError(t, err)
+		assert.Empty(t, nc.DeliveryResponses)
+		assert.Nil(t, nc.LatestDeliveryResponse())
+	})
+}
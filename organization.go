@@ -40,6 +40,10 @@ type Organizations interface {
 
 	// RunQueue shows the current run queue of an organization.
 	RunQueue(ctx context.Context, organization string, options RunQueueOptions) (*RunQueue, error)
+
+	// ReadSSOSettings reads a summary of the single sign-on and
+	// authentication settings of an organization.
+	ReadSSOSettings(ctx context.Context, organization string) (*SSOSettings, error)
 }
 
 // organizations implements Organizations.
@@ -65,9 +69,11 @@ type OrganizationList struct {
 // Organization represents a Terraform Enterprise organization.
 type Organization struct {
 	Name                   string                   `jsonapi:"primary,organizations"`
+	AssessmentsEnforced    bool                     `jsonapi:"attr,assessments-enforced"`
 	CollaboratorAuthPolicy AuthPolicyType           `jsonapi:"attr,collaborator-auth-policy"`
 	CostEstimationEnabled  bool                     `jsonapi:"attr,cost-estimation-enabled"`
 	CreatedAt              time.Time                `jsonapi:"attr,created-at,iso8601"`
+	DefaultExecutionMode   string                   `jsonapi:"attr,default-execution-mode"`
 	Email                  string                   `jsonapi:"attr,email"`
 	ExternalID             string                   `jsonapi:"attr,external-id"`
 	OwnersTeamSAMLRoleID   string                   `jsonapi:"attr,owners-team-saml-role-id"`
@@ -77,11 +83,17 @@ type Organization struct {
 	SessionTimeout         int                      `jsonapi:"attr,session-timeout"`
 	TrialExpiresAt         time.Time                `jsonapi:"attr,trial-expires-at,iso8601"`
 	TwoFactorConformant    bool                     `jsonapi:"attr,two-factor-conformant"`
+
+	// Relations
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool"`
 }
 
-// Capacity represents the current run capacity of an organization.
+// Capacity represents the current run capacity of an organization. Limit is
+// the number of runs the organization can run concurrently, it is zero when
+// the server doesn't report it.
 type Capacity struct {
 	Organization string `jsonapi:"primary,organization-capacity"`
+	Limit        int    `jsonapi:"attr,limit"`
 	Pending      int    `jsonapi:"attr,pending"`
 	Running      int    `jsonapi:"attr,running"`
 }
@@ -167,8 +179,25 @@ type OrganizationCreateOptions struct {
 	// Enable Cost Estimation
 	CostEstimationEnabled *bool `jsonapi:"attr,cost-estimation-enabled,omitempty"`
 
+	// Whether health assessments, such as drift detection, are enforced for
+	// all the workspaces of the organization, regardless of their own
+	// setting.
+	AssessmentsEnforced *bool `jsonapi:"attr,assessments-enforced,omitempty"`
+
 	// The name of the "owners" team
 	OwnersTeamSAMLRoleID *string `jsonapi:"attr,owners-team-saml-role-id,omitempty"`
+
+	// The execution mode inherited by new workspaces of the organization.
+	// Valid values are remote, local, and agent.
+	DefaultExecutionMode *string `jsonapi:"attr,default-execution-mode,omitempty"`
+
+	// The ID of the agent pool inherited by new workspaces of the
+	// organization. Required when DefaultExecutionMode is set to agent, and
+	// must be omitted otherwise.
+	DefaultAgentPoolID *string
+
+	// For internal use only!
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool,omitempty"`
 }
 
 func (o OrganizationCreateOptions) Valid() error {
@@ -181,6 +210,21 @@ func (o OrganizationCreateOptions) Valid() error {
 	if !validString(o.Email) {
 		return errors.New("email is required")
 	}
+	return validDefaultExecutionMode(o.DefaultExecutionMode, o.DefaultAgentPoolID)
+}
+
+// validDefaultExecutionMode checks that a default agent pool is given if, and
+// only if, the default execution mode is agent.
+func validDefaultExecutionMode(executionMode, agentPoolID *string) error {
+	if agentPoolID != nil && (executionMode == nil || *executionMode != "agent") {
+		return errors.New("specifying a default agent pool ID requires 'agent' default execution mode")
+	}
+	if agentPoolID == nil && (executionMode != nil && *executionMode == "agent") {
+		return errors.New("'agent' default execution mode requires a default agent pool ID to be specified")
+	}
+	if agentPoolID != nil && !validStringID(agentPoolID) {
+		return ErrInvalidAgentPoolID
+	}
 	return nil
 }
 
@@ -190,6 +234,10 @@ func (s *organizations) Create(ctx context.Context, options OrganizationCreateOp
 		return nil, err
 	}
 
+	if options.DefaultAgentPoolID != nil {
+		options.DefaultAgentPool = &AgentPool{ID: *options.DefaultAgentPoolID}
+	}
+
 	req, err := s.client.newRequest("POST", "organizations", &options)
 	if err != nil {
 		return nil, err
@@ -225,6 +273,45 @@ func (s *organizations) Read(ctx context.Context, organization string) (*Organiz
 	return org, nil
 }
 
+// SSOSettings summarizes the single sign-on and authentication settings of
+// an organization, such as for compliance audits.
+type SSOSettings struct {
+	// Organization is the name of the organization.
+	Organization string
+
+	// SAMLEnabled reports whether SAML single sign-on is enabled. Once
+	// enabled, members must sign in through the identity provider to access
+	// the organization.
+	SAMLEnabled bool
+
+	// OwnersTeamSAMLRoleID is the SAML role mapped to the owners team.
+	OwnersTeamSAMLRoleID string
+
+	// CollaboratorAuthPolicy is the authentication policy members must meet.
+	CollaboratorAuthPolicy AuthPolicyType
+
+	// TwoFactorConformant reports whether all the members meet the two
+	// factor authentication policy.
+	TwoFactorConformant bool
+}
+
+// ReadSSOSettings reads a summary of the single sign-on and authentication
+// settings of an organization from its attributes.
+func (s *organizations) ReadSSOSettings(ctx context.Context, organization string) (*SSOSettings, error) {
+	org, err := s.Read(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SSOSettings{
+		Organization:           org.Name,
+		SAMLEnabled:            org.SAMLEnabled,
+		OwnersTeamSAMLRoleID:   org.OwnersTeamSAMLRoleID,
+		CollaboratorAuthPolicy: org.CollaboratorAuthPolicy,
+		TwoFactorConformant:    org.TwoFactorConformant,
+	}, nil
+}
+
 // OrganizationUpdateOptions represents the options for updating an organization.
 type OrganizationUpdateOptions struct {
 	// Type is a public field utilized by JSON:API to
@@ -251,8 +338,25 @@ type OrganizationUpdateOptions struct {
 	// Enable Cost Estimation
 	CostEstimationEnabled *bool `jsonapi:"attr,cost-estimation-enabled,omitempty"`
 
+	// Whether health assessments, such as drift detection, are enforced for
+	// all the workspaces of the organization, regardless of their own
+	// setting.
+	AssessmentsEnforced *bool `jsonapi:"attr,assessments-enforced,omitempty"`
+
 	// The name of the "owners" team
 	OwnersTeamSAMLRoleID *string `jsonapi:"attr,owners-team-saml-role-id,omitempty"`
+
+	// The execution mode inherited by new workspaces of the organization.
+	// Valid values are remote, local, and agent.
+	DefaultExecutionMode *string `jsonapi:"attr,default-execution-mode,omitempty"`
+
+	// The ID of the agent pool inherited by new workspaces of the
+	// organization. Required when DefaultExecutionMode is set to agent, and
+	// must be omitted otherwise.
+	DefaultAgentPoolID *string
+
+	// For internal use only!
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool,omitempty"`
 }
 
 // Update attributes of an existing organization.
@@ -260,6 +364,13 @@ func (s *organizations) Update(ctx context.Context, organization string, options
 	if !validStringID(&organization) {
 		return nil, ErrInvalidOrg
 	}
+	if err := validDefaultExecutionMode(options.DefaultExecutionMode, options.DefaultAgentPoolID); err != nil {
+		return nil, err
+	}
+
+	if options.DefaultAgentPoolID != nil {
+		options.DefaultAgentPool = &AgentPool{ID: *options.DefaultAgentPoolID}
+	}
 
 	u := fmt.Sprintf("organizations/%s", url.QueryEscape(organization))
 	req, err := s.client.newRequest("PATCH", u, &options)
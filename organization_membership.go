@@ -69,6 +69,23 @@ type OrganizationMembershipListOptions struct {
 	ListOptions
 
 	Include string `schema:"include"`
+
+	// Filter the memberships by status ("invited" or "active").
+	Status *string `schema:"filter[status],omitempty"`
+
+	// Filter the memberships by the email addresses of their users, given as
+	// a comma-separated list.
+	Email *string `schema:"filter[email],omitempty"`
+
+	// A search query matching the names and email addresses of the users.
+	Query *string `schema:"q,omitempty"`
+}
+
+func (o OrganizationMembershipListOptions) valid() error {
+	if o.Status != nil && *o.Status != OrganizationMembershipActive && *o.Status != OrganizationMembershipInvited {
+		return errors.New("invalid value for status")
+	}
+	return nil
 }
 
 // List all the organization memberships of the given organization.
@@ -76,6 +93,9 @@ func (s *organizationMemberships) List(ctx context.Context, organization string,
 	if !validStringID(&organization) {
 		return nil, ErrInvalidOrg
 	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
 
 	u := fmt.Sprintf("organizations/%s/organization-memberships", url.QueryEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
@@ -69,6 +69,44 @@ func TestOrganizationMembershipsList(t *testing.T) {
 		assert.Contains(t, ml.Items, memTest2)
 	})
 
+	t.Run("with filter options", func(t *testing.T) {
+		memTest1, memTest1Cleanup := createOrganizationMembership(t, client, orgTest)
+		defer memTest1Cleanup()
+		_, memTest2Cleanup := createOrganizationMembership(t, client, orgTest)
+		defer memTest2Cleanup()
+
+		ml, err := client.OrganizationMemberships.List(ctx, orgTest.Name, OrganizationMembershipListOptions{
+			Status: String(OrganizationMembershipInvited),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(ml.Items))
+		for _, mem := range ml.Items {
+			assert.Equal(t, OrganizationMembershipStatus(OrganizationMembershipInvited), mem.Status)
+		}
+
+		ml, err = client.OrganizationMemberships.List(ctx, orgTest.Name, OrganizationMembershipListOptions{
+			Email: String(memTest1.Email),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(ml.Items))
+		assert.Equal(t, memTest1.ID, ml.Items[0].ID)
+
+		ml, err = client.OrganizationMemberships.List(ctx, orgTest.Name, OrganizationMembershipListOptions{
+			Query: String(memTest1.Email),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(ml.Items))
+		assert.Equal(t, memTest1.ID, ml.Items[0].ID)
+	})
+
+	t.Run("with an invalid status", func(t *testing.T) {
+		ml, err := client.OrganizationMemberships.List(ctx, orgTest.Name, OrganizationMembershipListOptions{
+			Status: String("pending"),
+		})
+		assert.Nil(t, ml)
+		assert.EqualError(t, err, "invalid value for status")
+	})
+
 	t.Run("without a valid organization", func(t *testing.T) {
 		ml, err := client.OrganizationMemberships.List(ctx, badIdentifier, OrganizationMembershipListOptions{})
 		assert.Nil(t, ml)
@@ -0,0 +1,75 @@
+package tfe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Compile-time proof of interface implementation.
+var _ OrganizationTags = (*organizationTags)(nil)
+
+// OrganizationTags describes all the organization tag related methods that
+// the Terraform Enterprise API supports.
+//
+// TFE API docs:
+// https://www.terraform.io/docs/cloud/api/organization-tags.html
+type OrganizationTags interface {
+	// List all the tags used by the workspaces of an organization.
+	List(ctx context.Context, organization string, options OrganizationTagsListOptions) (*OrganizationTagList, error)
+}
+
+// organizationTags implements OrganizationTags.
+type organizationTags struct {
+	client *Client
+}
+
+// OrganizationTagList represents a list of organization tags.
+type OrganizationTagList struct {
+	*Pagination
+	Items []*OrganizationTag
+}
+
+// OrganizationTag represents a tag used by the workspaces of an
+// organization.
+type OrganizationTag struct {
+	ID   string `jsonapi:"primary,tags"`
+	Name string `jsonapi:"attr,name"`
+
+	// The number of workspaces tagged with the tag.
+	InstanceCount int `jsonapi:"attr,instance-count"`
+}
+
+// OrganizationTagsListOptions represents the options for listing organization
+// tags.
+type OrganizationTagsListOptions struct {
+	ListOptions
+
+	// A search string (partial tag name) used to filter the results.
+	Query *string `schema:"q,omitempty"`
+
+	// Exclude the tags of the workspace with this ID.
+	ExcludeTaggableID *string `schema:"filter[exclude][taggable][id],omitempty"`
+}
+
+// List all the tags used by the workspaces of an organization, together with
+// the number of workspaces using each of them.
+func (s *organizationTags) List(ctx context.Context, organization string, options OrganizationTagsListOptions) (*OrganizationTagList, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+
+	u := fmt.Sprintf("organizations/%s/tags", url.QueryEscape(organization))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	tl := &OrganizationTagList{}
+	err = s.client.do(ctx, req, tl)
+	if err != nil {
+		return nil, err
+	}
+
+	return tl, nil
+}
@@ -0,0 +1,64 @@
+package tfe
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationTagsList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	t.Run("without list options", func(t *testing.T) {
+		tl, err := client.OrganizationTags.List(ctx, orgTest.Name, OrganizationTagsListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, tl.Items)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		tl, err := client.OrganizationTags.List(ctx, badIdentifier, OrganizationTagsListOptions{})
+		assert.Nil(t, tl)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+}
+
+func TestOrganizationTagsList_filters(t *testing.T) {
+	var query url.Values
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme/tags":
+			query = r.URL.Query()
+			checkedWrite(t, w, []byte(`{"data":[`+
+				`{"id":"tag-1","type":"tags","attributes":{"name":"prod","instance-count":3}},`+
+				`{"id":"tag-2","type":"tags","attributes":{"name":"production","instance-count":1}}`+
+				`],"meta":{"pagination":{"current-page":1,"total-pages":1,"total-count":2}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	tl, err := client.OrganizationTags.List(context.Background(), "acme", OrganizationTagsListOptions{
+		ListOptions:       ListOptions{PageSize: 50},
+		Query:             String("prod"),
+		ExcludeTaggableID: String("ws-123"),
+	})
+	require.NoError(t, err)
+	require.Len(t, tl.Items, 2)
+	assert.Equal(t, &OrganizationTag{ID: "tag-1", Name: "prod", InstanceCount: 3}, tl.Items[0])
+	assert.Equal(t, 2, tl.TotalCount)
+
+	assert.Equal(t, url.Values{
+		"page[size]":                    []string{"50"},
+		"q":                             []string{"prod"},
+		"filter[exclude][taggable][id]": []string{"ws-123"},
+	}, query)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"testing"
 	"time"
 
@@ -179,6 +181,28 @@ func TestOrganizationsUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("with health assessments enforced", func(t *testing.T) {
+		skipIfFreeOnly(t)
+
+		orgTest, orgTestCleanup := createOrganization(t, client)
+		defer orgTestCleanup()
+
+		org, err := client.Organizations.Update(ctx, orgTest.Name, OrganizationUpdateOptions{
+			AssessmentsEnforced: Bool(true),
+		})
+		require.NoError(t, err)
+		assert.True(t, org.AssessmentsEnforced)
+
+		ws, wsCleanup := createWorkspace(t, client, orgTest)
+		defer wsCleanup()
+
+		ws, err = client.Workspaces.UpdateByID(ctx, ws.ID, WorkspaceUpdateOptions{
+			AssessmentsEnabled: Bool(true),
+		})
+		require.NoError(t, err)
+		assert.True(t, ws.AssessmentsEnabled)
+	})
+
 	t.Run("with invalid name", func(t *testing.T) {
 		org, err := client.Organizations.Update(ctx, badIdentifier, OrganizationUpdateOptions{})
 		assert.Nil(t, org)
@@ -406,6 +430,81 @@ func TestOrganizationsRunQueue(t *testing.T) {
 	})
 }
 
+func TestOrganizationsDefaultExecutionMode(t *testing.T) {
+	var body map[string]interface{}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(b, &body))
+
+		checkedWrite(t, w, []byte(`{"data":{"id":"acme","type":"organizations",`+
+			`"attributes":{"default-execution-mode":"agent"},`+
+			`"relationships":{"default-agent-pool":{"data":{"id":"apool-123","type":"agent-pools"}}}}}`))
+	})
+
+	ctx := context.Background()
+
+	assertSent := func(t *testing.T) {
+		data := body["data"].(map[string]interface{})
+		assert.Equal(t, "agent", data["attributes"].(map[string]interface{})["default-execution-mode"])
+		assert.Equal(t, map[string]interface{}{
+			"default-agent-pool": map[string]interface{}{
+				"data": map[string]interface{}{"id": "apool-123", "type": "agent-pools"},
+			},
+		}, data["relationships"])
+	}
+
+	t.Run("when creating an organization", func(t *testing.T) {
+		org, err := client.Organizations.Create(ctx, OrganizationCreateOptions{
+			Name:                 String("acme"),
+			Email:                String("info@acme.com"),
+			DefaultExecutionMode: String("agent"),
+			DefaultAgentPoolID:   String("apool-123"),
+		})
+		require.NoError(t, err)
+		assertSent(t)
+
+		assert.Equal(t, "agent", org.DefaultExecutionMode)
+		require.NotNil(t, org.DefaultAgentPool)
+		assert.Equal(t, "apool-123", org.DefaultAgentPool.ID)
+	})
+
+	t.Run("when updating an organization", func(t *testing.T) {
+		_, err := client.Organizations.Update(ctx, "acme", OrganizationUpdateOptions{
+			DefaultExecutionMode: String("agent"),
+			DefaultAgentPoolID:   String("apool-123"),
+		})
+		require.NoError(t, err)
+		assertSent(t)
+	})
+
+	t.Run("with an agent pool without agent execution mode", func(t *testing.T) {
+		_, err := client.Organizations.Update(ctx, "acme", OrganizationUpdateOptions{
+			DefaultExecutionMode: String("remote"),
+			DefaultAgentPoolID:   String("apool-123"),
+		})
+		assert.EqualError(t, err, "specifying a default agent pool ID requires 'agent' default execution mode")
+	})
+
+	t.Run("with agent execution mode without an agent pool", func(t *testing.T) {
+		_, err := client.Organizations.Create(ctx, OrganizationCreateOptions{
+			Name:                 String("acme"),
+			Email:                String("info@acme.com"),
+			DefaultExecutionMode: String("agent"),
+		})
+		assert.EqualError(t, err, "'agent' default execution mode requires a default agent pool ID to be specified")
+	})
+
+	t.Run("with an invalid agent pool ID", func(t *testing.T) {
+		_, err := client.Organizations.Update(ctx, "acme", OrganizationUpdateOptions{
+			DefaultExecutionMode: String("agent"),
+			DefaultAgentPoolID:   String(badIdentifier),
+		})
+		assert.EqualError(t, err, ErrInvalidAgentPoolID.Error())
+	})
+}
+
 func TestOrganization_Unmarshal(t *testing.T) {
 	data := map[string]interface{}{
 		"data": map[string]interface{}{
@@ -441,3 +540,96 @@ func TestOrganization_Unmarshal(t *testing.T) {
 	assert.NotEmpty(t, org.Permissions)
 	assert.Equal(t, org.Permissions.CanCreateTeam, true)
 }
+
+func TestCapacity_Unmarshal(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "organization-capacity",
+			"id":   "org-name",
+			"attributes": map[string]interface{}{
+				"limit":   5,
+				"pending": 3,
+				"running": 5,
+			},
+		},
+	}
+	byteData, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	responseBody := bytes.NewReader(byteData)
+	c := &Capacity{}
+	err = unmarshalResponse(responseBody, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "org-name", c.Organization)
+	assert.Equal(t, 5, c.Limit)
+	assert.Equal(t, 3, c.Pending)
+	assert.Equal(t, 5, c.Running)
+}
+
+func TestOrganizationUpdateOptions_assessments(t *testing.T) {
+	var attributes map[string]interface{}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme":
+			var doc struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&doc))
+			attributes = doc.Data.Attributes
+
+			checkedWrite(t, w, []byte(`{"data":{"id":"acme","type":"organizations","attributes":{"assessments-enforced":true}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	org, err := client.Organizations.Update(context.Background(), "acme", OrganizationUpdateOptions{
+		AssessmentsEnforced: Bool(true),
+	})
+	require.NoError(t, err)
+	assert.True(t, org.AssessmentsEnforced)
+	assert.Equal(t, map[string]interface{}{"assessments-enforced": true}, attributes)
+}
+
+func TestOrganizationsReadSSOSettings(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme":
+			checkedWrite(t, w, []byte(`{"data":{"id":"acme","type":"organizations","attributes":{"saml-enabled":true,"owners-team-saml-role-id":"owners","collaborator-auth-policy":"two_factor_mandatory","two-factor-conformant":true}}}`))
+		case "/api/v2/organizations/nonexisting":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when the organization exists", func(t *testing.T) {
+		settings, err := client.Organizations.ReadSSOSettings(ctx, "acme")
+		require.NoError(t, err)
+		assert.Equal(t, &SSOSettings{
+			Organization:           "acme",
+			SAMLEnabled:            true,
+			OwnersTeamSAMLRoleID:   "owners",
+			CollaboratorAuthPolicy: AuthPolicyTwoFactor,
+			TwoFactorConformant:    true,
+		}, settings)
+	})
+
+	t.Run("when the organization does not exist", func(t *testing.T) {
+		settings, err := client.Organizations.ReadSSOSettings(ctx, "nonexisting")
+		assert.Nil(t, settings)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("with invalid name", func(t *testing.T) {
+		settings, err := client.Organizations.ReadSSOSettings(ctx, badIdentifier)
+		assert.Nil(t, settings)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+}
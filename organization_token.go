@@ -19,7 +19,8 @@ type OrganizationTokens interface {
 	// Generate a new organization token, replacing any existing token.
 	Generate(ctx context.Context, organization string) (*OrganizationToken, error)
 
-	// Read an organization token.
+	// Read an organization token. The secret token value is only returned
+	// when the token is generated, so the Token field will be empty.
 	Read(ctx context.Context, organization string) (*OrganizationToken, error)
 
 	// Delete an organization token.
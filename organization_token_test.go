@@ -50,6 +50,7 @@ func TestOrganizationTokensRead(t *testing.T) {
 		ot, err := client.OrganizationTokens.Read(ctx, orgTest.Name)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, ot)
+		assert.Empty(t, ot.Token)
 
 		otTestCleanup()
 	})
@@ -1,12 +1,16 @@
 package tfe
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"regexp"
+	"strconv"
 	"time"
 )
 
@@ -21,11 +25,19 @@ type Plans interface {
 	// Read a plan by its ID.
 	Read(ctx context.Context, planID string) (*Plan, error)
 
+	// ReadWithResourceDrift reads a plan by its ID, along with the count of
+	// resources that changed outside of Terraform.
+	ReadWithResourceDrift(ctx context.Context, planID string) (*Plan, error)
+
 	// Logs retrieves the logs of a plan.
 	Logs(ctx context.Context, planID string) (io.Reader, error)
 
 	// Retrieve the JSON execution plan
 	JSONOutput(ctx context.Context, planID string) ([]byte, error)
+
+	// GeneratedConfiguration retrieves the configuration generated by a
+	// plan for the resources it imports.
+	GeneratedConfiguration(ctx context.Context, planID string) ([]byte, error)
 }
 
 // plans implements Plans.
@@ -49,6 +61,17 @@ const (
 	PlanUnreachable PlanStatus = "unreachable"
 )
 
+// IsTerminal returns true if the plan status is a final state, after which
+// the plan will not change status anymore.
+func (s PlanStatus) IsTerminal() bool {
+	switch s {
+	case PlanCanceled, PlanErrored, PlanFinished, PlanUnreachable:
+		return true
+	default:
+		return false
+	}
+}
+
 // Plan represents a Terraform Enterprise plan.
 type Plan struct {
 	ID                   string                `jsonapi:"primary,plans"`
@@ -57,9 +80,15 @@ type Plan struct {
 	ResourceAdditions    int                   `jsonapi:"attr,resource-additions"`
 	ResourceChanges      int                   `jsonapi:"attr,resource-changes"`
 	ResourceDestructions int                   `jsonapi:"attr,resource-destructions"`
+	ResourceImports      int                   `jsonapi:"attr,resource-imports"`
 	Status               PlanStatus            `jsonapi:"attr,status"`
 	StatusTimestamps     *PlanStatusTimestamps `jsonapi:"attr,status-timestamps"`
 
+	// ResourceDrift counts the resources that changed outside of Terraform
+	// since the last apply. The plans API doesn't report it, so it is only
+	// set by Plans.ReadWithResourceDrift, from the JSON execution plan.
+	ResourceDrift int
+
 	// Relations
 	Exports []*PlanExport `jsonapi:"relation,exports"`
 }
@@ -95,6 +124,30 @@ func (s *plans) Read(ctx context.Context, planID string) (*Plan, error) {
 	return p, nil
 }
 
+// ReadWithResourceDrift reads a plan by its ID, along with the count of
+// resources that changed outside of Terraform, which is taken from the JSON
+// execution plan. This takes an additional request, and ErrPlanNotFinished
+// is returned when the plan hasn't finished yet.
+func (s *plans) ReadWithResourceDrift(ctx context.Context, planID string) (*Plan, error) {
+	p, err := s.Read(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.JSONOutput(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := ParsePlanResourceChanges(data)
+	if err != nil {
+		return nil, err
+	}
+	p.ResourceDrift = c.Drift
+
+	return p, nil
+}
+
 // Logs retrieves the logs of a plan.
 func (s *plans) Logs(ctx context.Context, planID string) (io.Reader, error) {
 	if !validStringID(&planID) {
@@ -123,12 +176,7 @@ func (s *plans) Logs(ctx context.Context, planID string) (io.Reader, error) {
 			return false, err
 		}
 
-		switch p.Status {
-		case PlanCanceled, PlanErrored, PlanFinished, PlanUnreachable:
-			return true, nil
-		default:
-			return false, nil
-		}
+		return p.Status.IsTerminal(), nil
 	}
 
 	return &LogReader{
@@ -139,7 +187,8 @@ func (s *plans) Logs(ctx context.Context, planID string) (io.Reader, error) {
 	}, nil
 }
 
-// Retrieve the JSON execution plan
+// Retrieve the JSON execution plan. ErrPlanNotFinished is returned when the
+// plan hasn't finished yet, and so the JSON execution plan isn't available.
 func (s *plans) JSONOutput(ctx context.Context, planID string) ([]byte, error) {
 	if !validStringID(&planID) {
 		return nil, errors.New("invalid value for plan ID")
@@ -152,10 +201,172 @@ func (s *plans) JSONOutput(ctx context.Context, planID string) ([]byte, error) {
 	}
 
 	var buf bytes.Buffer
-	err = s.client.do(ctx, req, &buf)
+	err = s.client.download(ctx, req, &buf)
+	if err != nil {
+		return nil, planNotFinishedError(ctx, s.client, planID, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// planNotFinishedError translates err, returned when retrieving an output of
+// a plan, into ErrPlanNotFinished when the plan hasn't finished yet, as the
+// API then responds with an opaque error. Otherwise err is returned as is.
+func planNotFinishedError(ctx context.Context, client *Client, planID string, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+
+	p, readErr := client.Plans.Read(ctx, planID)
+	if readErr != nil || p.Status.IsTerminal() {
+		return err
+	}
+
+	return fmt.Errorf("%w: plan %s is %s", ErrPlanNotFinished, planID, p.Status)
+}
+
+// GeneratedConfiguration retrieves the HCL configuration generated by a plan
+// for the resources imported without configuration. ErrResourceNotFound is
+// returned when the plan didn't generate any configuration.
+func (s *plans) GeneratedConfiguration(ctx context.Context, planID string) ([]byte, error) {
+	if !validStringID(&planID) {
+		return nil, errors.New("invalid value for plan ID")
+	}
+
+	u := fmt.Sprintf("plans/%s/generated-config", url.QueryEscape(planID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = s.client.download(ctx, req, &buf)
 	if err != nil {
 		return nil, err
 	}
 
 	return buf.Bytes(), nil
 }
+
+var (
+	// reANSIEscape matches the escape sequences used to color logs.
+	reANSIEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+	// rePlanSummary matches the change summary of a plan, which may also
+	// count the resources to import.
+	rePlanSummary = regexp.MustCompile(`Plan: (?:[0-9]+ to import, )?([0-9]+) to add, ([0-9]+) to change, ([0-9]+) to destroy\.`)
+
+	// reNoChanges matches the summary of a plan without any changes.
+	reNoChanges = regexp.MustCompile(`No changes\.`)
+)
+
+// ParsePlanSummary parses the number of resources to add, change and destroy
+// out of the text logs of a plan, such as those returned by Plans.Logs. It
+// serves as a fallback when the JSON output of a plan isn't available. Color
+// codes are ignored, and a plan without any changes is reported as such.
+// ErrNoPlanSummary is returned when the logs don't contain a change summary.
+func ParsePlanSummary(r io.Reader) (adds, changes, destroys int, err error) {
+	var found bool
+
+	// Read whole lines, however long, and however the reader buffers them.
+	br := bufio.NewReader(r)
+	for {
+		line, readErr := br.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return 0, 0, 0, readErr
+		}
+
+		line = reANSIEscape.ReplaceAllString(line, "")
+		if m := rePlanSummary.FindStringSubmatch(line); m != nil {
+			// The counts are all digits, so only overflows can fail.
+			if adds, err = strconv.Atoi(m[1]); err != nil {
+				return 0, 0, 0, err
+			}
+			if changes, err = strconv.Atoi(m[2]); err != nil {
+				return 0, 0, 0, err
+			}
+			if destroys, err = strconv.Atoi(m[3]); err != nil {
+				return 0, 0, 0, err
+			}
+			found = true
+		} else if reNoChanges.MatchString(line) {
+			adds, changes, destroys = 0, 0, 0
+			found = true
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	if !found {
+		return 0, 0, 0, ErrNoPlanSummary
+	}
+
+	return adds, changes, destroys, nil
+}
+
+// PlanResourceChanges counts the resource changes of a JSON execution plan by
+// the kind of change.
+type PlanResourceChanges struct {
+	Additions    int
+	Changes      int
+	Destructions int
+
+	// Imports counts the resources to import into the state.
+	Imports int
+
+	// Moves counts the resources whose address changed, as declared by
+	// moved blocks.
+	Moves int
+
+	// Drift counts the resources that changed outside of Terraform since
+	// the last apply.
+	Drift int
+}
+
+// ParsePlanResourceChanges counts the resource changes of a JSON execution
+// plan, such as the one returned by Plans.JSONOutput. A replaced resource is
+// counted both as an addition and a destruction, as Terraform does in the
+// change summary of the plan logs. Imported and moved resources are counted
+// whatever other action is taken on them.
+func ParsePlanResourceChanges(data []byte) (*PlanResourceChanges, error) {
+	type resourceChange struct {
+		Address         string `json:"address"`
+		PreviousAddress string `json:"previous_address"`
+		Change          struct {
+			Actions   []string        `json:"actions"`
+			Importing json.RawMessage `json:"importing"`
+		} `json:"change"`
+	}
+
+	var plan struct {
+		ResourceChanges []resourceChange `json:"resource_changes"`
+		ResourceDrift   []resourceChange `json:"resource_drift"`
+	}
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, err
+	}
+
+	c := &PlanResourceChanges{Drift: len(plan.ResourceDrift)}
+	for _, rc := range plan.ResourceChanges {
+		for _, action := range rc.Change.Actions {
+			switch action {
+			case "create":
+				c.Additions++
+			case "update":
+				c.Changes++
+			case "delete":
+				c.Destructions++
+			}
+		}
+		if len(rc.Change.Importing) > 0 && string(rc.Change.Importing) != "null" {
+			c.Imports++
+		}
+		if rc.PreviousAddress != "" && rc.PreviousAddress != rc.Address {
+			c.Moves++
+		}
+	}
+
+	return c, nil
+}
@@ -166,7 +166,7 @@ func (s *planExports) Download(ctx context.Context, planExportID string) ([]byte
 	}
 
 	var buf bytes.Buffer
-	err = s.client.do(ctx, req, &buf)
+	err = s.client.download(ctx, req, &buf)
 	if err != nil {
 		return nil, err
 	}
@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -80,6 +84,7 @@ func TestPlan_Unmarshal(t *testing.T) {
 				"resource-additions":    1,
 				"resource-changes":      1,
 				"resource-destructions": 1,
+				"resource-imports":      2,
 				"status":                PlanCanceled,
 				"status-timestamps": map[string]string{
 					"queued-at":  "2020-03-16T23:15:59+00:00",
@@ -107,6 +112,7 @@ func TestPlan_Unmarshal(t *testing.T) {
 	assert.Equal(t, plan.ResourceAdditions, 1)
 	assert.Equal(t, plan.ResourceChanges, 1)
 	assert.Equal(t, plan.ResourceDestructions, 1)
+	assert.Equal(t, plan.ResourceImports, 2)
 	assert.Equal(t, plan.Status, PlanCanceled)
 	assert.NotEmpty(t, plan.StatusTimestamps)
 	assert.Equal(t, plan.StatusTimestamps.QueuedAt, queuedParsedTime)
@@ -135,3 +141,203 @@ func TestPlansJSONOutput(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestPlansGeneratedConfiguration(t *testing.T) {
+	config := "resource \"aws_instance\" \"web\" {\n  ami = \"ami-123\"\n}\n"
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/plans/plan-123/generated-config":
+			w.Header().Set("Content-Type", "text/plain")
+			checkedWrite(t, w, []byte(config))
+		case "/api/v2/plans/plan-456/generated-config":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when configuration was generated", func(t *testing.T) {
+		d, err := client.Plans.GeneratedConfiguration(ctx, "plan-123")
+		require.NoError(t, err)
+		assert.Equal(t, config, string(d))
+	})
+
+	t.Run("when no configuration was generated", func(t *testing.T) {
+		d, err := client.Plans.GeneratedConfiguration(ctx, "plan-456")
+		assert.Nil(t, d)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid plan ID", func(t *testing.T) {
+		d, err := client.Plans.GeneratedConfiguration(ctx, badIdentifier)
+		assert.Nil(t, d)
+		assert.EqualError(t, err, "invalid value for plan ID")
+	})
+}
+
+func TestPlanStatus_IsTerminal(t *testing.T) {
+	terminal := []PlanStatus{PlanCanceled, PlanErrored, PlanFinished, PlanUnreachable}
+	nonTerminal := []PlanStatus{PlanCreated, PlanMFAWaiting, PlanPending, PlanQueued, PlanRunning}
+
+	for _, s := range terminal {
+		assert.True(t, s.IsTerminal(), "expected %s to be terminal", s)
+	}
+	for _, s := range nonTerminal {
+		assert.False(t, s.IsTerminal(), "expected %s not to be terminal", s)
+	}
+}
+
+func TestParsePlanSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		logs     string
+		adds     int
+		changes  int
+		destroys int
+		err      error
+	}{
+		{
+			name: "with changes",
+			logs: "Terraform will perform the following actions:\n\n" +
+				"Plan: 1 to add, 2 to change, 3 to destroy.\n",
+			adds:     1,
+			changes:  2,
+			destroys: 3,
+		},
+		{
+			name:     "with color codes",
+			logs:     "\x1b[0m\x1b[1mPlan:\x1b[0m 4 to add, 0 to change, 1 to destroy.\x1b[0m\n",
+			adds:     4,
+			destroys: 1,
+		},
+		{
+			name:    "with resources to import",
+			logs:    "Plan: 2 to import, 1 to add, 5 to change, 0 to destroy.",
+			adds:    1,
+			changes: 5,
+		},
+		{
+			name: "without changes",
+			logs: "\x1b[32mNo changes.\x1b[0m Your infrastructure matches the configuration.\n",
+		},
+		{
+			name: "without a summary",
+			logs: "Error: Invalid reference\n",
+			err:  ErrNoPlanSummary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Read the logs one byte at a time, so lines are split across reads.
+			adds, changes, destroys, err := ParsePlanSummary(iotest.OneByteReader(strings.NewReader(tt.logs)))
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.adds, adds)
+			assert.Equal(t, tt.changes, changes)
+			assert.Equal(t, tt.destroys, destroys)
+		})
+	}
+}
+
+func TestParsePlanResourceChanges(t *testing.T) {
+	data := []byte(`{
+		"format_version": "1.2",
+		"resource_drift": [
+			{"address": "null_resource.drifted", "change": {"actions": ["update"]}}
+		],
+		"resource_changes": [
+			{"address": "null_resource.created", "change": {"actions": ["create"]}},
+			{"address": "null_resource.updated", "change": {"actions": ["update"]}},
+			{"address": "null_resource.replaced", "change": {"actions": ["delete", "create"]}},
+			{"address": "null_resource.deleted", "change": {"actions": ["delete"]}},
+			{"address": "null_resource.imported", "change": {"actions": ["no-op"], "importing": {"id": "123"}}},
+			{"address": "null_resource.moved", "previous_address": "null_resource.old", "change": {"actions": ["no-op"]}},
+			{"address": "null_resource.unchanged", "change": {"actions": ["no-op"], "importing": null}}
+		]
+	}`)
+
+	c, err := ParsePlanResourceChanges(data)
+	require.NoError(t, err)
+	assert.Equal(t, &PlanResourceChanges{
+		Additions:    2,
+		Changes:      1,
+		Destructions: 2,
+		Imports:      1,
+		Moves:        1,
+		Drift:        1,
+	}, c)
+
+	t.Run("with invalid JSON", func(t *testing.T) {
+		_, err := ParsePlanResourceChanges([]byte("Error: Invalid reference"))
+		assert.Error(t, err)
+	})
+}
+
+func TestPlansReadWithResourceDrift(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/plans/plan-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"plan-123","type":"plans","attributes":{"status":"finished","resource-changes":1}}}`))
+		case "/api/v2/plans/plan-123/json-output":
+			checkedWrite(t, w, []byte(`{"resource_drift":[`+
+				`{"address":"null_resource.a","change":{"actions":["update"]}},`+
+				`{"address":"null_resource.b","change":{"actions":["delete"]}}`+
+				`],"resource_changes":[{"address":"null_resource.a","change":{"actions":["update"]}}]}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	p, err := client.Plans.ReadWithResourceDrift(context.Background(), "plan-123")
+	require.NoError(t, err)
+	assert.Equal(t, "plan-123", p.ID)
+	assert.Equal(t, 1, p.ResourceChanges)
+	assert.Equal(t, 2, p.ResourceDrift)
+
+	t.Run("with invalid plan ID", func(t *testing.T) {
+		_, err := client.Plans.ReadWithResourceDrift(context.Background(), badIdentifier)
+		assert.EqualError(t, err, "invalid value for plan ID")
+	})
+}
+
+func TestPlansNotFinished(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/plans/plan-running":
+			checkedWrite(t, w, []byte(`{"data":{"id":"plan-running","type":"plans","attributes":{"status":"running"}}}`))
+		case "/api/v2/plans/plan-finished":
+			checkedWrite(t, w, []byte(`{"data":{"id":"plan-finished","type":"plans","attributes":{"status":"finished"}}}`))
+		case "/api/v2/runs/run-planning":
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-planning","type":"runs","attributes":{"status":"planning"},"relationships":{"plan":{"data":{"id":"plan-running","type":"plans"}}}}}`))
+		case "/api/v2/plans/plan-running/json-output", "/api/v2/plans/plan-finished/json-output":
+			w.WriteHeader(http.StatusNotFound)
+		case "/runs/run-planning/plan":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			checkedWrite(t, w, []byte(`{"errors":[{"status":"422","title":"unprocessable entity"}]}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when the plan is running", func(t *testing.T) {
+		_, err := client.Plans.JSONOutput(ctx, "plan-running")
+		assert.True(t, errors.Is(err, ErrPlanNotFinished))
+		assert.EqualError(t, err, "plan has not finished: plan plan-running is running")
+	})
+
+	t.Run("when the plan is finished", func(t *testing.T) {
+		_, err := client.Plans.JSONOutput(ctx, "plan-finished")
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("when the plan of the run is running", func(t *testing.T) {
+		_, err := client.Runs.GetPlanFile(ctx, "run-planning", PlanFileOptions{Format: "json"})
+		assert.True(t, errors.Is(err, ErrPlanNotFinished))
+	})
+}
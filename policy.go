@@ -277,7 +277,7 @@ func (s *policies) Download(ctx context.Context, policyID string) ([]byte, error
 	}
 
 	var buf bytes.Buffer
-	err = s.client.do(ctx, req, &buf)
+	err = s.client.download(ctx, req, &buf)
 	if err != nil {
 		return nil, err
 	}
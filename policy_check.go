@@ -3,10 +3,13 @@ package tfe
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -22,6 +25,10 @@ type PolicyChecks interface {
 	// List all policy checks of the given run.
 	List(ctx context.Context, runID string, options PolicyCheckListOptions) (*PolicyCheckList, error)
 
+	// ListForOrganization lists the policy checks of the recent runs of all
+	// the workspaces within an organization.
+	ListForOrganization(ctx context.Context, organization string, options PolicyCheckListForOrganizationOptions) (*PolicyCheckList, error)
+
 	// Read a policy check by its ID.
 	Read(ctx context.Context, policyCheckID string) (*PolicyCheck, error)
 
@@ -30,6 +37,10 @@ type PolicyChecks interface {
 
 	// Logs retrieves the logs of a policy check.
 	Logs(ctx context.Context, policyCheckID string) (io.Reader, error)
+
+	// DownloadResults retrieves the complete result document of a policy
+	// check as raw JSON, including the full evaluation of each policy.
+	DownloadResults(ctx context.Context, policyCheckID string) ([]byte, error)
 }
 
 // policyChecks implements PolicyChecks.
@@ -137,6 +148,167 @@ func (s *policyChecks) List(ctx context.Context, runID string, options PolicyChe
 	return pcl, nil
 }
 
+// PolicyCheckListForOrganizationOptions represents the options for listing
+// the policy checks of an organization.
+type PolicyCheckListForOrganizationOptions struct {
+	// Only return policy checks with one of the given statuses. When empty,
+	// policy checks are returned regardless of their status.
+	Statuses []PolicyStatus
+
+	// The number of most recent runs to inspect in each workspace. Defaults
+	// to 20.
+	RunsPerWorkspace int
+
+	// The maximum number of workspaces to inspect concurrently. Defaults
+	// to 5.
+	Concurrency int
+}
+
+// ListForOrganization lists the policy checks of the recent runs of all the
+// workspaces within an organization. The API only exposes policy checks per
+// run, so this walks the runs of every workspace and aggregates their policy
+// checks, most recent run first. The Run relation of each returned policy
+// check is populated, including its Workspace.
+func (s *policyChecks) ListForOrganization(ctx context.Context, organization string, options PolicyCheckListForOrganizationOptions) (*PolicyCheckList, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+
+	runsPerWorkspace := options.RunsPerWorkspace
+	if runsPerWorkspace <= 0 {
+		runsPerWorkspace = 20
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+
+	var workspaces []*Workspace
+	for page := 1; page != 0; {
+		wl, err := s.client.Workspaces.List(ctx, organization, WorkspaceListOptions{
+			ListOptions: ListOptions{PageNumber: page, PageSize: 100},
+		})
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, wl.Items...)
+
+		page = 0
+		if wl.Pagination != nil {
+			page = wl.NextPage
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		checks   []*PolicyCheck
+	)
+
+	// Stop listing the remaining workspaces on the first error.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, concurrency)
+	for _, ws := range workspaces {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(ws *Workspace) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			pcs, err := s.listForWorkspace(ctx, ws, runsPerWorkspace, options.Statuses)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			checks = append(checks, pcs...)
+		}(ws)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].Run.CreatedAt.After(checks[j].Run.CreatedAt)
+	})
+
+	return &PolicyCheckList{
+		Pagination: &Pagination{
+			CurrentPage: 1,
+			TotalPages:  1,
+			TotalCount:  len(checks),
+		},
+		Items: checks,
+	}, nil
+}
+
+// listForWorkspace lists the policy checks of the most recent runs of the
+// given workspace, keeping only the checks matching one of the statuses.
+func (s *policyChecks) listForWorkspace(ctx context.Context, ws *Workspace, runs int, statuses []PolicyStatus) ([]*PolicyCheck, error) {
+	rl, err := s.client.Runs.List(ctx, ws.ID, RunListOptions{
+		ListOptions: ListOptions{PageSize: runs},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var checks []*PolicyCheck
+	for _, r := range rl.Items {
+		// Skip runs without any policy checks to save a request.
+		if len(r.PolicyChecks) == 0 {
+			continue
+		}
+
+		pcl, err := s.List(ctx, r.ID, PolicyCheckListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		r.Workspace = ws
+		for _, pc := range pcl.Items {
+			if len(statuses) > 0 && !hasPolicyStatus(statuses, pc.Status) {
+				continue
+			}
+			pc.Run = r
+			checks = append(checks, pc)
+		}
+	}
+
+	return checks, nil
+}
+
+// hasPolicyStatus checks if the given status is one of statuses.
+func hasPolicyStatus(statuses []PolicyStatus, status PolicyStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
 // Read a policy check by its ID.
 func (s *policyChecks) Read(ctx context.Context, policyCheckID string) (*PolicyCheck, error) {
 	if !validStringID(&policyCheckID) {
@@ -219,3 +391,45 @@ func (s *policyChecks) Logs(ctx context.Context, policyCheckID string) (io.Reade
 		return logs, nil
 	}
 }
+
+// DownloadResults retrieves the complete result document of a policy check
+// as raw JSON. Unlike the typed PolicyResult, which only holds the counts of
+// the policy check, it includes the full evaluation of each policy, such as
+// the Sentinel trace, to be stored or processed by the caller.
+func (s *policyChecks) DownloadResults(ctx context.Context, policyCheckID string) ([]byte, error) {
+	if !validStringID(&policyCheckID) {
+		return nil, errors.New("invalid value for policy check ID")
+	}
+
+	u := fmt.Sprintf("policy-checks/%s", url.QueryEscape(policyCheckID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body := bytes.NewBuffer(nil)
+	err = s.client.do(ctx, req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// The result is decoded as is, without going through the jsonapi
+	// package which would only keep the attributes known to PolicyResult.
+	var raw struct {
+		Data struct {
+			Attributes struct {
+				Result json.RawMessage `json:"result"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body.Bytes(), &raw); err != nil {
+		return nil, err
+	}
+
+	result := raw.Data.Attributes.Result
+	if len(result) == 0 || string(result) == "null" {
+		return nil, ErrNoPolicyResult
+	}
+
+	return result, nil
+}
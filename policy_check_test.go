@@ -5,6 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -67,6 +71,78 @@ func TestPolicyChecksList(t *testing.T) {
 	})
 }
 
+func TestPolicyChecksListForOrganization(t *testing.T) {
+	skipIfFreeOnly(t)
+
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	pTest, policyCleanup := createUploadedPolicy(t, client, true, orgTest)
+	defer policyCleanup()
+	wTest, wsCleanup := createWorkspace(t, client, orgTest)
+	defer wsCleanup()
+	createPolicySet(t, client, orgTest, []*Policy{pTest}, []*Workspace{wTest})
+
+	rTest, runCleanup := createPolicyCheckedRun(t, client, wTest)
+	defer runCleanup()
+
+	t.Run("without options", func(t *testing.T) {
+		pcl, err := client.PolicyChecks.ListForOrganization(ctx, orgTest.Name, PolicyCheckListForOrganizationOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(pcl.Items))
+		assert.Equal(t, 1, pcl.TotalCount)
+		assert.Equal(t, PolicyPasses, pcl.Items[0].Status)
+		require.NotNil(t, pcl.Items[0].Run)
+		assert.Equal(t, rTest.ID, pcl.Items[0].Run.ID)
+		require.NotNil(t, pcl.Items[0].Run.Workspace)
+		assert.Equal(t, wTest.Name, pcl.Items[0].Run.WorkspaceName())
+	})
+
+	t.Run("with a status filter", func(t *testing.T) {
+		pcl, err := client.PolicyChecks.ListForOrganization(ctx, orgTest.Name, PolicyCheckListForOrganizationOptions{
+			Statuses: []PolicyStatus{PolicyHardFailed, PolicySoftFailed},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, pcl.Items)
+	})
+
+	t.Run("with an invalid organization", func(t *testing.T) {
+		pcl, err := client.PolicyChecks.ListForOrganization(ctx, badIdentifier, PolicyCheckListForOrganizationOptions{})
+		assert.Nil(t, pcl)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+}
+
+func TestPolicyChecksListForOrganization_error(t *testing.T) {
+	var runLists int32
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/organizations/acme/workspaces":
+			var data []string
+			for i := 0; i < 10; i++ {
+				data = append(data, `{"id":"ws-`+strconv.Itoa(i)+`","type":"workspaces"}`)
+			}
+			checkedWrite(t, w, []byte(`{"data":[`+strings.Join(data, ",")+`]}`))
+		case strings.HasSuffix(r.URL.Path, "/runs"):
+			atomic.AddInt32(&runLists, 1)
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	pcl, err := client.PolicyChecks.ListForOrganization(context.Background(), "acme", PolicyCheckListForOrganizationOptions{
+		Concurrency: 1,
+	})
+	assert.Nil(t, pcl)
+	assert.Equal(t, ErrResourceNotFound, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runLists))
+}
+
 func TestPolicyChecksRead(t *testing.T) {
 	skipIfEnterprise(t)
 	skipIfFreeOnly(t)
@@ -209,6 +285,69 @@ func TestPolicyChecksLogs(t *testing.T) {
 	})
 }
 
+func TestPolicyChecksDownloadResults(t *testing.T) {
+	skipIfFreeOnly(t)
+
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	pTest, pTestCleanup := createUploadedPolicy(t, client, true, orgTest)
+	defer pTestCleanup()
+	wTest, wTestCleanup := createWorkspace(t, client, orgTest)
+	defer wTestCleanup()
+	createPolicySet(t, client, orgTest, []*Policy{pTest}, []*Workspace{wTest})
+
+	rTest, rTestCleanup := createPolicyCheckedRun(t, client, wTest)
+	defer rTestCleanup()
+	require.Equal(t, 1, len(rTest.PolicyChecks))
+
+	t.Run("when the policy check exists", func(t *testing.T) {
+		results, err := client.PolicyChecks.DownloadResults(ctx, rTest.PolicyChecks[0].ID)
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(results, &result))
+		assert.Equal(t, float64(1), result["passed"])
+		assert.Contains(t, result, "sentinel")
+	})
+
+	t.Run("without a valid policy check ID", func(t *testing.T) {
+		results, err := client.PolicyChecks.DownloadResults(ctx, badIdentifier)
+		assert.Nil(t, results)
+		assert.EqualError(t, err, "invalid value for policy check ID")
+	})
+}
+
+func TestPolicyChecksDownloadResults_raw(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/policy-checks/polchk-passed":
+			checkedWrite(t, w, []byte(`{"data":{"id":"polchk-passed","type":"policy-checks","attributes":{"status":"passed","result":{"result":true,"passed":1,"total-failed":0,"sentinel":{"schema-version":"1.0.0","data":{"sentinel-policy-networking":{"policies":[{"policy":"sentinel-policy-networking/only-one-resource","result":true,"trace":{"print":""}}]}}}}}}}`))
+		case "/api/v2/policy-checks/polchk-queued":
+			checkedWrite(t, w, []byte(`{"data":{"id":"polchk-queued","type":"policy-checks","attributes":{"status":"queued","result":null}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a result", func(t *testing.T) {
+		results, err := client.PolicyChecks.DownloadResults(ctx, "polchk-passed")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"result":true,"passed":1,"total-failed":0,"sentinel":{"schema-version":"1.0.0","data":{"sentinel-policy-networking":{"policies":[{"policy":"sentinel-policy-networking/only-one-resource","result":true,"trace":{"print":""}}]}}}}`, string(results))
+	})
+
+	t.Run("without a result yet", func(t *testing.T) {
+		results, err := client.PolicyChecks.DownloadResults(ctx, "polchk-queued")
+		assert.Nil(t, results)
+		assert.Equal(t, ErrNoPolicyResult, err)
+	})
+}
+
 func TestPolicyCheck_Unmarshal(t *testing.T) {
 	data := map[string]interface{}{
 		"data": map[string]interface{}{
@@ -45,6 +45,10 @@ type PolicySets interface {
 	// Remove workspaces from a policy set.
 	RemoveWorkspaces(ctx context.Context, policySetID string, options PolicySetRemoveWorkspacesOptions) error
 
+	// ReconcileWorkspaces attaches exactly the given workspaces to a policy
+	// set, and returns the IDs of the workspaces it added and removed.
+	ReconcileWorkspaces(ctx context.Context, policySetID string, desired []string) (added, removed []string, err error)
+
 	// Delete a policy set by its ID.
 	Delete(ctx context.Context, policyID string) error
 }
@@ -205,8 +209,14 @@ func (s *policySets) ReadWithOptions(ctx context.Context, policySetID string, op
 		return nil, errors.New("invalid value for policy set ID")
 	}
 
+	// Don't pass a nil pointer on, as it can't be encoded as query parameters.
+	var v interface{}
+	if options != nil {
+		v = options
+	}
+
 	u := fmt.Sprintf("policy-sets/%s", url.QueryEscape(policySetID))
-	req, err := s.client.newRequest("GET", u, options)
+	req, err := s.client.newRequest("GET", u, v)
 	if err != nil {
 		return nil, err
 	}
@@ -422,6 +432,68 @@ func (s *policySets) RemoveWorkspaces(ctx context.Context, policySetID string, o
 	return s.client.do(ctx, req, nil)
 }
 
+// ReconcileWorkspaces attaches exactly the desired workspaces, given by their
+// IDs, to a policy set. It reads the current workspaces of the policy set and
+// only adds and removes those that differ. The IDs of the workspaces added
+// and removed are returned, in the order of desired and of the current
+// workspaces respectively. When removing fails, the workspaces that were
+// already added are returned along with the error.
+func (s *policySets) ReconcileWorkspaces(ctx context.Context, policySetID string, desired []string) (added, removed []string, err error) {
+	if !validStringID(&policySetID) {
+		return nil, nil, errors.New("invalid value for policy set ID")
+	}
+
+	want := make(map[string]bool, len(desired))
+	for _, workspaceID := range desired {
+		workspaceID := workspaceID
+		if !validStringID(&workspaceID) {
+			return nil, nil, ErrInvalidWorkspaceID
+		}
+		want[workspaceID] = true
+	}
+
+	ps, err := s.Read(ctx, policySetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	have := make(map[string]bool, len(ps.Workspaces))
+	var toRemove []*Workspace
+	for _, w := range ps.Workspaces {
+		have[w.ID] = true
+		if !want[w.ID] {
+			toRemove = append(toRemove, &Workspace{ID: w.ID})
+			removed = append(removed, w.ID)
+		}
+	}
+
+	var toAdd []*Workspace
+	for _, workspaceID := range desired {
+		if !have[workspaceID] {
+			// Make sure duplicate IDs are only added once.
+			have[workspaceID] = true
+			toAdd = append(toAdd, &Workspace{ID: workspaceID})
+			added = append(added, workspaceID)
+		}
+	}
+
+	if len(toAdd) > 0 {
+		err := s.AddWorkspaces(ctx, policySetID, PolicySetAddWorkspacesOptions{Workspaces: toAdd})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(toRemove) > 0 {
+		err := s.RemoveWorkspaces(ctx, policySetID, PolicySetRemoveWorkspacesOptions{Workspaces: toRemove})
+		if err != nil {
+			return added, nil, err
+		}
+	}
+
+	return added, removed, nil
+}
+
 // Delete a policy set by its ID.
 func (s *policySets) Delete(ctx context.Context, policySetID string) error {
 	if !validStringID(&policySetID) {
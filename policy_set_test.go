@@ -2,8 +2,11 @@ package tfe
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -578,6 +581,103 @@ func TestPolicySetsRemoveWorkspaces(t *testing.T) {
 	})
 }
 
+func TestPolicySetsReconcileWorkspaces(t *testing.T) {
+	attached := map[string]bool{}
+	var calls []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/policy-sets/ps-123":
+			var ids []string
+			for id := range attached {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			data := []map[string]string{}
+			for _, id := range ids {
+				data = append(data, map[string]string{"type": "workspaces", "id": id})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":   "ps-123",
+					"type": "policy-sets",
+					"relationships": map[string]interface{}{
+						"workspaces": map[string]interface{}{"data": data},
+					},
+				},
+			})
+		case "/api/v2/policy-sets/ps-123/relationships/workspaces":
+			var body struct {
+				Data []struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			var ids []string
+			for _, ws := range body.Data {
+				ids = append(ids, ws.ID)
+				if r.Method == "POST" {
+					attached[ws.ID] = true
+				} else {
+					delete(attached, ws.ID)
+				}
+			}
+			calls = append(calls, fmt.Sprintf("%s %v", r.Method, ids))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with workspaces to add and remove", func(t *testing.T) {
+		attached = map[string]bool{"ws-1": true, "ws-2": true, "ws-3": true}
+		calls = nil
+
+		added, removed, err := client.PolicySets.ReconcileWorkspaces(ctx, "ps-123", []string{"ws-4", "ws-2", "ws-5", "ws-4"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ws-4", "ws-5"}, added)
+		assert.Equal(t, []string{"ws-1", "ws-3"}, removed)
+		assert.Equal(t, map[string]bool{"ws-2": true, "ws-4": true, "ws-5": true}, attached)
+		assert.Equal(t, []string{"POST [ws-4 ws-5]", "DELETE [ws-1 ws-3]"}, calls)
+	})
+
+	t.Run("when already reconciled", func(t *testing.T) {
+		attached = map[string]bool{"ws-1": true}
+		calls = nil
+
+		added, removed, err := client.PolicySets.ReconcileWorkspaces(ctx, "ps-123", []string{"ws-1"})
+		require.NoError(t, err)
+		assert.Empty(t, added)
+		assert.Empty(t, removed)
+		assert.Empty(t, calls)
+	})
+
+	t.Run("when detaching all workspaces", func(t *testing.T) {
+		attached = map[string]bool{"ws-1": true}
+		calls = nil
+
+		added, removed, err := client.PolicySets.ReconcileWorkspaces(ctx, "ps-123", nil)
+		require.NoError(t, err)
+		assert.Empty(t, added)
+		assert.Equal(t, []string{"ws-1"}, removed)
+		assert.Empty(t, attached)
+	})
+
+	t.Run("with an invalid workspace ID", func(t *testing.T) {
+		_, _, err := client.PolicySets.ReconcileWorkspaces(ctx, "ps-123", []string{badIdentifier})
+		assert.Equal(t, ErrInvalidWorkspaceID, err)
+	})
+
+	t.Run("without a valid ID", func(t *testing.T) {
+		_, _, err := client.PolicySets.ReconcileWorkspaces(ctx, badIdentifier, nil)
+		assert.EqualError(t, err, "invalid value for policy set ID")
+	})
+}
+
 func TestPolicySetsDelete(t *testing.T) {
 	skipIfFreeOnly(t)
 
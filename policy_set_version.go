@@ -26,6 +26,10 @@ type PolicySetVersions interface {
 	// to the set of sentinel files, which will be packaged by hashicorp/go-slug
 	// before being uploaded.
 	Upload(ctx context.Context, psv PolicySetVersion, path string) error
+
+	// WaitForVersionReady waits until a Policy Set Version is ready or
+	// errored.
+	WaitForVersionReady(ctx context.Context, policySetVersionID string, options PolicySetVersionWaitOptions) (*PolicySetVersion, error)
 }
 
 // policySetVersions implements PolicySetVersions.
@@ -78,8 +82,16 @@ type PolicySetVersion struct {
 	// Relations
 	PolicySet *PolicySet `jsonapi:"relation,policy-set"`
 
-	// Links
-	Links map[string]interface{} `jsonapi:"links,omitempty"`
+	// Links of the resource. As the jsonapi package doesn't support links,
+	// they are decoded separately and only for the primary data of a
+	// response, not for included resources.
+	Links map[string]interface{}
+}
+
+// unmarshalRawResource implements rawResourceUnmarshaler.
+func (p *PolicySetVersion) unmarshalRawResource(r *rawResource) error {
+	p.Links = r.Links
+	return nil
 }
 
 func (p PolicySetVersion) uploadURL() (string, error) {
@@ -158,3 +170,46 @@ func (p *policySetVersions) Upload(ctx context.Context, psv PolicySetVersion, pa
 
 	return p.client.do(ctx, req, nil)
 }
+
+// PolicySetVersionWaitOptions represents the options for waiting for a
+// Policy Set Version.
+type PolicySetVersionWaitOptions struct {
+	// The maximum time to wait for the version to be processed. Defaults
+	// to waiting until the context is done.
+	Timeout time.Duration
+}
+
+// WaitForVersionReady polls a Policy Set Version until it is ready or
+// errored. When the version is errored, it is returned together with a
+// *PolicySetVersionError holding the error message of the version.
+func (p *policySetVersions) WaitForVersionReady(ctx context.Context, policySetVersionID string, options PolicySetVersionWaitOptions) (*PolicySetVersion, error) {
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	for i := 0; ; i++ {
+		start := time.Now()
+
+		psv, err := p.Read(ctx, policySetVersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch psv.Status {
+		case PolicySetVersionReady:
+			return psv, nil
+		case PolicySetVersionErrored:
+			return psv, &PolicySetVersionError{
+				ID:      psv.ID,
+				Code:    psv.Error,
+				Message: psv.ErrorMessage,
+			}
+		}
+
+		if err := pollWait(ctx, start, backoff(500, 2000, i)); err != nil {
+			return psv, err
+		}
+	}
+}
@@ -2,6 +2,8 @@ package tfe
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -96,6 +98,46 @@ func TestPolicySetVersionsUpload(t *testing.T) {
 	})
 }
 
+func TestPolicySetVersionsWaitForVersionReady(t *testing.T) {
+	skipIfFreeOnly(t)
+
+	client := testClient(t)
+	ctx := context.Background()
+
+	psv, psvCleanup := createPolicySetVersion(t, client, nil)
+	defer psvCleanup()
+
+	t.Run("with an uploaded version", func(t *testing.T) {
+		err := client.PolicySetVersions.Upload(ctx, *psv, "test-fixtures/policy-set-version")
+		require.NoError(t, err)
+
+		psv, err := client.PolicySetVersions.WaitForVersionReady(ctx, psv.ID, PolicySetVersionWaitOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, PolicySetVersionReady, psv.Status)
+	})
+
+	t.Run("with an errored version", func(t *testing.T) {
+		client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			checkedWrite(t, w, []byte(`{"data":{"id":"polsetver-123","type":"policy-set-versions","attributes":{"status":"errored","error":"invalid_slug","error-message":"sentinel.hcl is invalid"}}}`))
+		})
+
+		psv, err := client.PolicySetVersions.WaitForVersionReady(ctx, "polsetver-123", PolicySetVersionWaitOptions{})
+		require.NotNil(t, psv)
+		assert.Equal(t, PolicySetVersionErrored, psv.Status)
+
+		var psvErr *PolicySetVersionError
+		require.True(t, errors.As(err, &psvErr))
+		assert.Equal(t, "invalid_slug", psvErr.Code)
+		assert.Equal(t, "sentinel.hcl is invalid", psvErr.Message)
+	})
+
+	t.Run("with invalid policy set version ID", func(t *testing.T) {
+		_, err := client.PolicySetVersions.WaitForVersionReady(ctx, badIdentifier, PolicySetVersionWaitOptions{})
+		assert.Error(t, err)
+	})
+}
+
 func TestPolicySetVersionsUploadURL(t *testing.T) {
 	t.Run("successfully returns upload link", func(t *testing.T) {
 		links := map[string]interface{}{
@@ -0,0 +1,115 @@
+package tfe
+
+import (
+	"context"
+	"fmt"
+)
+
+// Refresh re-reads a resource previously fetched from the API, and replaces
+// the value v points to with its latest version. The resource is read by its
+// ID, so v must be a non-nil pointer with at least its ID set, e.g.:
+//
+//	r := &Run{ID: "run-123"}
+//	err := client.Refresh(ctx, r)
+//
+// Relations are only populated as far as the plain read of the resource
+// populates them. ErrUnsupportedRefresh is returned for a type of resource
+// which can't be refreshed.
+func (c *Client) Refresh(ctx context.Context, v interface{}) error {
+	switch v := v.(type) {
+	case *AgentPool:
+		fresh, err := c.AgentPools.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *Apply:
+		fresh, err := c.Applies.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *ConfigurationVersion:
+		fresh, err := c.ConfigurationVersions.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *CostEstimate:
+		fresh, err := c.CostEstimates.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *NotificationConfiguration:
+		fresh, err := c.NotificationConfigurations.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *Organization:
+		fresh, err := c.Organizations.Read(ctx, v.Name)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *Plan:
+		fresh, err := c.Plans.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *PlanExport:
+		fresh, err := c.PlanExports.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *PolicyCheck:
+		fresh, err := c.PolicyChecks.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *PolicySet:
+		fresh, err := c.PolicySets.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *PolicySetVersion:
+		fresh, err := c.PolicySetVersions.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *Run:
+		fresh, err := c.Runs.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *StateVersion:
+		fresh, err := c.StateVersions.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *Team:
+		fresh, err := c.Teams.Read(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	case *Workspace:
+		fresh, err := c.Workspaces.ReadByID(ctx, v.ID)
+		if err != nil {
+			return err
+		}
+		*v = *fresh
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedRefresh, v)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Refresh(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/runs/run-1":
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-1","type":"runs","attributes":{"status":"applied"}}}`))
+		case "/api/v2/workspaces/ws-1":
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-1","type":"workspaces","attributes":{"name":"app","locked":true}}}`))
+		case "/api/v2/organizations/acme":
+			checkedWrite(t, w, []byte(`{"data":{"id":"acme","type":"organizations","attributes":{"email":"info@acme.com"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a run", func(t *testing.T) {
+		r := &Run{ID: "run-1", Status: RunPlanning}
+		require.NoError(t, client.Refresh(ctx, r))
+		assert.Equal(t, RunApplied, r.Status)
+	})
+
+	t.Run("with a workspace", func(t *testing.T) {
+		w := &Workspace{ID: "ws-1", Name: "app"}
+		require.NoError(t, client.Refresh(ctx, w))
+		assert.True(t, w.Locked)
+	})
+
+	t.Run("with an organization", func(t *testing.T) {
+		org := &Organization{Name: "acme"}
+		require.NoError(t, client.Refresh(ctx, org))
+		assert.Equal(t, "info@acme.com", org.Email)
+	})
+
+	t.Run("when the resource does not exist", func(t *testing.T) {
+		r := &Run{ID: "run-2", Status: RunPlanning}
+		err := client.Refresh(ctx, r)
+		assert.Equal(t, ErrResourceNotFound, err)
+		assert.Equal(t, RunPlanning, r.Status)
+	})
+
+	t.Run("with an unsupported type", func(t *testing.T) {
+		err := client.Refresh(ctx, &User{ID: "user-1"})
+		assert.True(t, errors.Is(err, ErrUnsupportedRefresh))
+	})
+}
@@ -100,8 +100,16 @@ type RegistryModuleVersion struct {
 	// Relations
 	RegistryModule *RegistryModule `jsonapi:"relation,registry-module"`
 
-	// Links
-	Links map[string]interface{} `jsonapi:"links,omitempty"`
+	// Links of the resource. As the jsonapi package doesn't support links,
+	// they are decoded separately and only for the primary data of a
+	// response, not for included resources.
+	Links map[string]interface{}
+}
+
+// unmarshalRawResource implements rawResourceUnmarshaler.
+func (rmv *RegistryModuleVersion) unmarshalRawResource(r *rawResource) error {
+	rmv.Links = r.Links
+	return nil
 }
 
 // Upload uploads Terraform configuration files for the provided registry module version. It
@@ -0,0 +1,424 @@
+package tfe
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Compile-time proof of interface implementation.
+var _ RegistryProviders = (*registryProviders)(nil)
+
+// RegistryProviders describes all the registry provider related methods that
+// the Terraform Enterprise API supports. Publishing a provider to the private
+// registry of an organization takes several steps: create the provider,
+// create a version of it, upload the shasums and their signature, and then
+// register and upload a binary for each supported platform.
+//
+// TFE API docs:
+// https://www.terraform.io/docs/cloud/api/private-registry/provider-versions-platforms.html
+type RegistryProviders interface {
+	// Create a provider in the private registry of an organization.
+	Create(ctx context.Context, organization string, options RegistryProviderCreateOptions) (*RegistryProvider, error)
+
+	// Read a provider of the private registry of an organization.
+	Read(ctx context.Context, organization string, name string) (*RegistryProvider, error)
+
+	// Delete a provider, with all its versions and platforms.
+	Delete(ctx context.Context, organization string, name string) error
+
+	// Create a version of a provider.
+	CreateVersion(ctx context.Context, organization string, name string, options RegistryProviderVersionCreateOptions) (*RegistryProviderVersion, error)
+
+	// Delete a version of a provider, with all its platforms.
+	DeleteVersion(ctx context.Context, organization string, name string, version string) error
+
+	// Create a platform of a provider version.
+	CreatePlatform(ctx context.Context, organization string, name string, version string, options RegistryProviderPlatformCreateOptions) (*RegistryProviderPlatform, error)
+
+	// Delete a platform of a provider version.
+	DeletePlatform(ctx context.Context, organization string, name string, version string, os string, arch string) error
+}
+
+// registryProviders implements RegistryProviders.
+type registryProviders struct {
+	client *Client
+}
+
+// privateRegistryName is the name of the registry of the providers published
+// by an organization. Versions and platforms can only be managed for them.
+const privateRegistryName = "private"
+
+// RegistryProvider represents a provider of the private registry.
+type RegistryProvider struct {
+	ID           string    `jsonapi:"primary,registry-providers"`
+	Name         string    `jsonapi:"attr,name"`
+	Namespace    string    `jsonapi:"attr,namespace"`
+	RegistryName string    `jsonapi:"attr,registry-name"`
+	CreatedAt    time.Time `jsonapi:"attr,created-at,iso8601"`
+	UpdatedAt    time.Time `jsonapi:"attr,updated-at,iso8601"`
+
+	// Relations
+	Organization *Organization `jsonapi:"relation,organization"`
+}
+
+// RegistryProviderVersion represents a version of a provider.
+type RegistryProviderVersion struct {
+	ID                 string    `jsonapi:"primary,registry-provider-versions"`
+	Version            string    `jsonapi:"attr,version"`
+	KeyID              string    `jsonapi:"attr,key-id"`
+	Protocols          []string  `jsonapi:"attr,protocols"`
+	ShasumsUploaded    bool      `jsonapi:"attr,shasums-uploaded"`
+	ShasumsSigUploaded bool      `jsonapi:"attr,shasums-sig-uploaded"`
+	CreatedAt          time.Time `jsonapi:"attr,created-at,iso8601"`
+	UpdatedAt          time.Time `jsonapi:"attr,updated-at,iso8601"`
+
+	// Relations
+	RegistryProvider *RegistryProvider `jsonapi:"relation,registry-provider"`
+
+	// Links of the resource. As the jsonapi package doesn't support links,
+	// they are decoded separately and only for the primary data of a
+	// response, not for included resources.
+	Links map[string]interface{}
+}
+
+// unmarshalRawResource implements rawResourceUnmarshaler.
+func (v *RegistryProviderVersion) unmarshalRawResource(r *rawResource) error {
+	v.Links = r.Links
+	return nil
+}
+
+// ShasumsUploadURL returns the URL to upload the SHA256SUMS file of the
+// version to, or an empty string when the version doesn't hold one.
+func (v *RegistryProviderVersion) ShasumsUploadURL() string {
+	u, _ := v.Links["shasums-upload"].(string)
+	return u
+}
+
+// ShasumsSigUploadURL returns the URL to upload the signature of the
+// SHA256SUMS file of the version to, or an empty string when the version
+// doesn't hold one.
+func (v *RegistryProviderVersion) ShasumsSigUploadURL() string {
+	u, _ := v.Links["shasums-sig-upload"].(string)
+	return u
+}
+
+// RegistryProviderPlatform represents a platform of a provider version.
+type RegistryProviderPlatform struct {
+	ID                     string `jsonapi:"primary,registry-provider-platforms"`
+	OS                     string `jsonapi:"attr,os"`
+	Arch                   string `jsonapi:"attr,arch"`
+	Filename               string `jsonapi:"attr,filename"`
+	Shasum                 string `jsonapi:"attr,shasum"`
+	ProviderBinaryUploaded bool   `jsonapi:"attr,provider-binary-uploaded"`
+
+	// Relations
+	RegistryProviderVersion *RegistryProviderVersion `jsonapi:"relation,registry-provider-version"`
+
+	// Links of the resource. As the jsonapi package doesn't support links,
+	// they are decoded separately and only for the primary data of a
+	// response, not for included resources.
+	Links map[string]interface{}
+}
+
+// unmarshalRawResource implements rawResourceUnmarshaler.
+func (p *RegistryProviderPlatform) unmarshalRawResource(r *rawResource) error {
+	p.Links = r.Links
+	return nil
+}
+
+// BinaryUploadURL returns the URL to upload the archive of the provider
+// binary of the platform to, or an empty string when the platform doesn't
+// hold one.
+func (p *RegistryProviderPlatform) BinaryUploadURL() string {
+	u, _ := p.Links["provider-binary-upload"].(string)
+	return u
+}
+
+// RegistryProviderCreateOptions represents the options for creating a
+// provider in the private registry.
+type RegistryProviderCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-providers"`
+
+	// The name of the provider.
+	Name *string `jsonapi:"attr,name"`
+
+	// For internal use only!
+	Namespace    string `jsonapi:"attr,namespace"`
+	RegistryName string `jsonapi:"attr,registry-name"`
+}
+
+func (o RegistryProviderCreateOptions) valid() error {
+	if !validString(o.Name) {
+		return ErrRequiredName
+	}
+	if !validStringID(o.Name) {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// Create a provider in the private registry of an organization.
+func (s *registryProviders) Create(ctx context.Context, organization string, options RegistryProviderCreateOptions) (*RegistryProvider, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Private providers are namespaced by the organization publishing them.
+	options.Namespace = organization
+	options.RegistryName = privateRegistryName
+
+	u := fmt.Sprintf("organizations/%s/registry-providers", url.QueryEscape(organization))
+	req, err := s.client.newRequest("POST", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	rp := &RegistryProvider{}
+	err = s.client.do(ctx, req, rp)
+	if err != nil {
+		return nil, err
+	}
+
+	return rp, nil
+}
+
+// Read a provider of the private registry of an organization.
+func (s *registryProviders) Read(ctx context.Context, organization string, name string) (*RegistryProvider, error) {
+	u, err := registryProviderPath(organization, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rp := &RegistryProvider{}
+	err = s.client.do(ctx, req, rp)
+	if err != nil {
+		return nil, err
+	}
+
+	return rp, nil
+}
+
+// Delete a provider, with all its versions and platforms.
+func (s *registryProviders) Delete(ctx context.Context, organization string, name string) error {
+	u, err := registryProviderPath(organization, name)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// RegistryProviderVersionCreateOptions represents the options for creating a
+// version of a provider.
+type RegistryProviderVersionCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-provider-versions"`
+
+	// The semantic version of the provider version.
+	Version *string `jsonapi:"attr,version"`
+
+	// The ID of the GPG key used to sign the shasums of the version, as
+	// returned by the GPGKeys service.
+	KeyID *string `jsonapi:"attr,key-id"`
+
+	// The Terraform plugin protocol versions supported by the version, such
+	// as "5.0".
+	Protocols []string `jsonapi:"attr,protocols,omitempty"`
+}
+
+func (o RegistryProviderVersionCreateOptions) valid() error {
+	if !validString(o.Version) {
+		return ErrRequiredVersion
+	}
+	if !validStringID(o.Version) {
+		return ErrInvalidVersion
+	}
+	if !validString(o.KeyID) {
+		return ErrRequiredKeyID
+	}
+	return nil
+}
+
+// CreateVersion creates a version of a provider. The returned version holds
+// the URLs to upload its shasums and their signature to.
+func (s *registryProviders) CreateVersion(ctx context.Context, organization string, name string, options RegistryProviderVersionCreateOptions) (*RegistryProviderVersion, error) {
+	u, err := registryProviderPath(organization, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("POST", u+"/versions", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	rpv := &RegistryProviderVersion{}
+	err = s.client.do(ctx, req, rpv)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpv, nil
+}
+
+// DeleteVersion deletes a version of a provider, with all its platforms.
+func (s *registryProviders) DeleteVersion(ctx context.Context, organization string, name string, version string) error {
+	u, err := registryProviderVersionPath(organization, name, version)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// RegistryProviderPlatformCreateOptions represents the options for creating
+// a platform of a provider version.
+type RegistryProviderPlatformCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,registry-provider-platforms"`
+
+	// The operating system of the platform, such as "linux".
+	OS *string `jsonapi:"attr,os"`
+
+	// The architecture of the platform, such as "amd64".
+	Arch *string `jsonapi:"attr,arch"`
+
+	// The SHA256 checksum of the archive of the provider binary.
+	Shasum *string `jsonapi:"attr,shasum"`
+
+	// The filename of the archive of the provider binary.
+	Filename *string `jsonapi:"attr,filename"`
+}
+
+func (o RegistryProviderPlatformCreateOptions) valid() error {
+	if !validString(o.OS) {
+		return ErrRequiredOS
+	}
+	if !validString(o.Arch) {
+		return ErrRequiredArch
+	}
+	if !validString(o.Shasum) {
+		return ErrRequiredShasum
+	}
+	if !validString(o.Filename) {
+		return ErrRequiredFilename
+	}
+	return nil
+}
+
+// CreatePlatform creates a platform of a provider version. The returned
+// platform holds the URL to upload the archive of its binary to.
+func (s *registryProviders) CreatePlatform(ctx context.Context, organization string, name string, version string, options RegistryProviderPlatformCreateOptions) (*RegistryProviderPlatform, error) {
+	u, err := registryProviderVersionPath(organization, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("POST", u+"/platforms", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	rpp := &RegistryProviderPlatform{}
+	err = s.client.do(ctx, req, rpp)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpp, nil
+}
+
+// DeletePlatform deletes a platform of a provider version.
+func (s *registryProviders) DeletePlatform(ctx context.Context, organization string, name string, version string, os string, arch string) error {
+	u, err := registryProviderVersionPath(organization, name, version)
+	if err != nil {
+		return err
+	}
+	if !validString(&os) {
+		return ErrRequiredOS
+	}
+	if !validString(&arch) {
+		return ErrRequiredArch
+	}
+
+	u = fmt.Sprintf("%s/platforms/%s/%s", u, url.QueryEscape(os), url.QueryEscape(arch))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// registryProviderPath validates the identifiers of a private provider and
+// returns its path.
+func registryProviderPath(organization, name string) (string, error) {
+	if !validStringID(&organization) {
+		return "", ErrInvalidOrg
+	}
+	if !validString(&name) {
+		return "", ErrRequiredName
+	}
+	if !validStringID(&name) {
+		return "", ErrInvalidName
+	}
+	return fmt.Sprintf(
+		"organizations/%s/registry-providers/%s/%s/%s",
+		url.QueryEscape(organization),
+		privateRegistryName,
+		url.QueryEscape(organization),
+		url.QueryEscape(name),
+	), nil
+}
+
+// registryProviderVersionPath validates the identifiers of a version of a
+// private provider and returns its path.
+func registryProviderVersionPath(organization, name, version string) (string, error) {
+	u, err := registryProviderPath(organization, name)
+	if err != nil {
+		return "", err
+	}
+	if !validString(&version) {
+		return "", ErrRequiredVersion
+	}
+	if !validStringID(&version) {
+		return "", ErrInvalidVersion
+	}
+	return fmt.Sprintf("%s/versions/%s", u, url.QueryEscape(version)), nil
+}
@@ -0,0 +1,129 @@
+package tfe
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryProviders_requests(t *testing.T) {
+	var requests []string
+	bodies := make(map[string]map[string]interface{})
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+
+		if r.Method == "POST" {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var doc struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(body, &doc))
+			bodies[r.URL.Path] = doc.Data
+		}
+
+		base := "/api/v2/organizations/acme/registry-providers/private/acme/aws"
+		switch {
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == base+"/versions":
+			checkedWrite(t, w, []byte(`{"data":{"id":"provider-version-1","type":"registry-provider-versions","attributes":{"version":"3.1.1","key-id":"32966F3FB5AC1129","protocols":["5.0"],"shasums-uploaded":false},"links":{"shasums-upload":"https://archivist.example.com/v1/object/shasums","shasums-sig-upload":"https://archivist.example.com/v1/object/shasums-sig"}}}`))
+		case r.URL.Path == base+"/versions/3.1.1/platforms":
+			checkedWrite(t, w, []byte(`{"data":{"id":"provpltfrm-1","type":"registry-provider-platforms","attributes":{"os":"linux","arch":"amd64","filename":"terraform-provider-aws_3.1.1_linux_amd64.zip","shasum":"8f69533b","provider-binary-uploaded":false},"links":{"provider-binary-upload":"https://archivist.example.com/v1/object/binary"}}}`))
+		default:
+			checkedWrite(t, w, []byte(`{"data":{"id":"prov-1","type":"registry-providers","attributes":{"name":"aws","namespace":"acme","registry-name":"private"}}}`))
+		}
+	})
+
+	ctx := context.Background()
+
+	rp, err := client.RegistryProviders.Create(ctx, "acme", RegistryProviderCreateOptions{Name: String("aws")})
+	require.NoError(t, err)
+	assert.Equal(t, "aws", rp.Name)
+	assert.Equal(t, map[string]interface{}{
+		"name":          "aws",
+		"namespace":     "acme",
+		"registry-name": "private",
+	}, bodies["/api/v2/organizations/acme/registry-providers"]["attributes"])
+
+	rp, err = client.RegistryProviders.Read(ctx, "acme", "aws")
+	require.NoError(t, err)
+	assert.Equal(t, "private", rp.RegistryName)
+
+	rpv, err := client.RegistryProviders.CreateVersion(ctx, "acme", "aws", RegistryProviderVersionCreateOptions{
+		Version:   String("3.1.1"),
+		KeyID:     String("32966F3FB5AC1129"),
+		Protocols: []string{"5.0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.1", rpv.Version)
+	assert.Equal(t, []string{"5.0"}, rpv.Protocols)
+	assert.Equal(t, "https://archivist.example.com/v1/object/shasums", rpv.ShasumsUploadURL())
+	assert.Equal(t, "https://archivist.example.com/v1/object/shasums-sig", rpv.ShasumsSigUploadURL())
+
+	rpp, err := client.RegistryProviders.CreatePlatform(ctx, "acme", "aws", "3.1.1", RegistryProviderPlatformCreateOptions{
+		OS:       String("linux"),
+		Arch:     String("amd64"),
+		Shasum:   String("8f69533b"),
+		Filename: String("terraform-provider-aws_3.1.1_linux_amd64.zip"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "linux", rpp.OS)
+	assert.Equal(t, "amd64", rpp.Arch)
+	assert.Equal(t, "https://archivist.example.com/v1/object/binary", rpp.BinaryUploadURL())
+
+	err = client.RegistryProviders.DeletePlatform(ctx, "acme", "aws", "3.1.1", "linux", "amd64")
+	require.NoError(t, err)
+
+	err = client.RegistryProviders.DeleteVersion(ctx, "acme", "aws", "3.1.1")
+	require.NoError(t, err)
+
+	err = client.RegistryProviders.Delete(ctx, "acme", "aws")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST /api/v2/organizations/acme/registry-providers",
+		"GET /api/v2/organizations/acme/registry-providers/private/acme/aws",
+		"POST /api/v2/organizations/acme/registry-providers/private/acme/aws/versions",
+		"POST /api/v2/organizations/acme/registry-providers/private/acme/aws/versions/3.1.1/platforms",
+		"DELETE /api/v2/organizations/acme/registry-providers/private/acme/aws/versions/3.1.1/platforms/linux/amd64",
+		"DELETE /api/v2/organizations/acme/registry-providers/private/acme/aws/versions/3.1.1",
+		"DELETE /api/v2/organizations/acme/registry-providers/private/acme/aws",
+	}, requests)
+
+	t.Run("with invalid options", func(t *testing.T) {
+		_, err := client.RegistryProviders.Create(ctx, badIdentifier, RegistryProviderCreateOptions{Name: String("aws")})
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+
+		_, err = client.RegistryProviders.Create(ctx, "acme", RegistryProviderCreateOptions{})
+		assert.EqualError(t, err, ErrRequiredName.Error())
+
+		_, err = client.RegistryProviders.Read(ctx, "acme", badIdentifier)
+		assert.EqualError(t, err, ErrInvalidName.Error())
+
+		_, err = client.RegistryProviders.CreateVersion(ctx, "acme", "aws", RegistryProviderVersionCreateOptions{KeyID: String("32966F3FB5AC1129")})
+		assert.EqualError(t, err, ErrRequiredVersion.Error())
+
+		_, err = client.RegistryProviders.CreateVersion(ctx, "acme", "aws", RegistryProviderVersionCreateOptions{Version: String("3.1.1")})
+		assert.EqualError(t, err, ErrRequiredKeyID.Error())
+
+		_, err = client.RegistryProviders.CreatePlatform(ctx, "acme", "aws", badIdentifier, RegistryProviderPlatformCreateOptions{})
+		assert.EqualError(t, err, ErrInvalidVersion.Error())
+
+		_, err = client.RegistryProviders.CreatePlatform(ctx, "acme", "aws", "3.1.1", RegistryProviderPlatformCreateOptions{
+			OS:       String("linux"),
+			Arch:     String("amd64"),
+			Filename: String("terraform-provider-aws_3.1.1_linux_amd64.zip"),
+		})
+		assert.EqualError(t, err, ErrRequiredShasum.Error())
+
+		err = client.RegistryProviders.DeletePlatform(ctx, "acme", "aws", "3.1.1", "linux", "")
+		assert.EqualError(t, err, ErrRequiredArch.Error())
+	})
+}
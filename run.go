@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -25,27 +27,61 @@ type Runs interface {
 	// List all the runs of the given workspace.
 	List(ctx context.Context, workspaceID string, options RunListOptions) (*RunList, error)
 
+	// Count the runs of the given workspace matching the list options.
+	Count(ctx context.Context, workspaceID string, options RunListOptions) (int, error)
+
+	// ForEach calls fn for each run of the given workspace matching the list
+	// options, one page at a time.
+	ForEach(ctx context.Context, workspaceID string, options RunListOptions, fn func(*Run) error) error
+
 	// Create a new run with the given options.
 	Create(ctx context.Context, options RunCreateOptions) (*Run, error)
 
+	// CreateAndWait creates a new run with the given options and waits for
+	// it to reach the stage given by the wait options.
+	CreateAndWait(ctx context.Context, options RunCreateOptions, wait RunWaitOptions) (*Run, error)
+
+	// WaitForStart waits for a run to leave the queue and start executing.
+	WaitForStart(ctx context.Context, runID string, options RunWaitForStartOptions) (*Run, error)
+
+	// WaitForConfirmable waits for a run to be ready to be confirmed, once
+	// it is planned, cost estimated and policy checked.
+	WaitForConfirmable(ctx context.Context, runID string) (*Run, error)
+
 	// Read a run by its ID.
 	Read(ctx context.Context, runID string) (*Run, error)
 
 	// ReadWithOptions reads a run by its ID using the options supplied
 	ReadWithOptions(ctx context.Context, runID string, options RunReadOptions) (*Run, error)
 
+	// ReadFull reads a run by its ID with all its relations populated.
+	ReadFull(ctx context.Context, runID string) (*Run, error)
+
+	// ReadPlan reads the plan of a run by the ID of the run.
+	ReadPlan(ctx context.Context, runID string) (*Plan, error)
+
+	// ReadApply reads the apply of a run by the ID of the run.
+	ReadApply(ctx context.Context, runID string) (*Apply, error)
+
 	// Apply a run by its ID.
 	Apply(ctx context.Context, runID string, options RunApplyOptions) error
 
 	// Cancel a run by its ID.
 	Cancel(ctx context.Context, runID string, options RunCancelOptions) error
 
+	// CancelAll cancels all the cancelable runs of a workspace.
+	CancelAll(ctx context.Context, workspaceID string, options RunCancelOptions) (int, error)
+
 	// Force-cancel a run by its ID.
 	ForceCancel(ctx context.Context, runID string, options RunForceCancelOptions) error
 
 	// Discard a run by its ID.
 	Discard(ctx context.Context, runID string, options RunDiscardOptions) error
 
+	// DiscardOlderThan discards all the discardable runs of a workspace
+	// created more than age ago.
+	DiscardOlderThan(ctx context.Context, workspaceID string, age time.Duration, options RunDiscardOptions) (int, error)
+
 	// GetPlanFile gets the plan file for a run by its run ID
 	GetPlanFile(ctx context.Context, runID string, options PlanFileOptions) ([]byte, error)
 
@@ -54,6 +90,10 @@ type Runs interface {
 
 	// UploadLogs uploads logs for a run. For use by an agent rather than user.
 	UploadLogs(ctx context.Context, runID string, chunk []byte, options RunUploadLogsOptions) error
+
+	// Tail streams the plan and apply logs of a run to w as they are
+	// produced, returning once the run has reached a final state.
+	Tail(ctx context.Context, runID string, w io.Writer) error
 }
 
 // runs implements Runs.
@@ -86,6 +126,19 @@ const (
 	RunPolicySoftFailed   RunStatus = "policy_soft_failed"
 )
 
+// IsTerminal returns true if the run status is a final state, after which
+// the run will not change status anymore. Whether a run is waiting to be
+// confirmed can't be told from its status alone, use Run.IsConfirmable
+// instead.
+func (s RunStatus) IsTerminal() bool {
+	switch s {
+	case RunApplied, RunCanceled, RunDiscarded, RunErrored, RunPlannedAndFinished, RunPolicySoftFailed:
+		return true
+	default:
+		return false
+	}
+}
+
 // RunSource represents a source type of a run.
 type RunSource string
 
@@ -96,22 +149,51 @@ const (
 	RunSourceUI                   RunSource = "tfe-ui"
 )
 
+// RunOperation represents the kind of operation a run performs, to filter
+// lists of runs with.
+type RunOperation string
+
+// List all available run operations.
+const (
+	RunOperationPlanOnly     RunOperation = "plan_only"
+	RunOperationPlanAndApply RunOperation = "plan_and_apply"
+	RunOperationRefreshOnly  RunOperation = "refresh_only"
+	RunOperationDestroy      RunOperation = "destroy"
+	RunOperationEmptyApply   RunOperation = "empty_apply"
+)
+
 // RunList represents a list of runs.
 type RunList struct {
 	*Pagination
 	Items []*Run
 }
 
+// DestroyRuns returns the runs of the list which destroy the infrastructure
+// of their workspace. To only fetch destroy runs in the first place, list
+// runs with the RunOperationDestroy operation.
+func (l *RunList) DestroyRuns() []*Run {
+	var runs []*Run
+	for _, r := range l.Items {
+		if r.IsDestroy {
+			runs = append(runs, r)
+		}
+	}
+	return runs
+}
+
 // Run represents a Terraform Enterprise run.
 type Run struct {
 	ID                     string               `jsonapi:"primary,runs"`
 	Actions                *RunActions          `jsonapi:"attr,actions"`
+	AutoApply              bool                 `jsonapi:"attr,auto-apply"`
 	CreatedAt              time.Time            `jsonapi:"attr,created-at,iso8601"`
+	DebuggingMode          bool                 `jsonapi:"attr,debugging-mode"`
 	ForceCancelAvailableAt time.Time            `jsonapi:"attr,force-cancel-available-at,iso8601"`
 	HasChanges             bool                 `jsonapi:"attr,has-changes"`
 	IsDestroy              bool                 `jsonapi:"attr,is-destroy"`
 	Message                string               `jsonapi:"attr,message"`
 	Permissions            *RunPermissions      `jsonapi:"attr,permissions"`
+	PlanOnly               bool                 `jsonapi:"attr,plan-only"`
 	PositionInQueue        int                  `jsonapi:"attr,position-in-queue"`
 	Refresh                bool                 `jsonapi:"attr,refresh"`
 	RefreshOnly            bool                 `jsonapi:"attr,refresh-only"`
@@ -120,10 +202,12 @@ type Run struct {
 	Status                 RunStatus            `jsonapi:"attr,status"`
 	StatusTimestamps       *RunStatusTimestamps `jsonapi:"attr,status-timestamps"`
 	TargetAddrs            []string             `jsonapi:"attr,target-addrs,omitempty"`
+	Variables              []RunVariable        `jsonapi:"attr,variables"`
 
 	// Relations
 	Apply                *Apply                `jsonapi:"relation,apply"`
-	ConfigurationVersion *ConfigurationVersion `jsonapi:"relation,configuration-version"`
+	ConfigurationVersion *ConfigurationVersion `jsonapi:"relation,configuration-version,omitempty"`
+	ConfirmedBy          *User                 `jsonapi:"relation,confirmed-by"`
 	CostEstimate         *CostEstimate         `jsonapi:"relation,cost-estimate"`
 	CreatedBy            *User                 `jsonapi:"relation,created-by"`
 	Plan                 *Plan                 `jsonapi:"relation,plan"`
@@ -131,6 +215,72 @@ type Run struct {
 	Workspace            *Workspace            `jsonapi:"relation,workspace"`
 }
 
+// RunVariable represents a variable set for a single run. Sensitive
+// variables are not returned when reading a run.
+type RunVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// IsSpeculative returns true if the run is a plan-only run, which can't be
+// applied. Runs created from a speculative configuration version are only
+// recognized as such when the configuration version is included.
+func (r *Run) IsSpeculative() bool {
+	if r.PlanOnly {
+		return true
+	}
+	return r.ConfigurationVersion != nil && r.ConfigurationVersion.Speculative
+}
+
+// IsConfirmable returns true if the run is waiting to be confirmed (applied)
+// or discarded, according to its actions. The status of a run alone isn't
+// enough to tell: a planned or cost estimated run may still have checks to go
+// through, a run on an auto-apply workspace is never confirmed by a user, and
+// a policy_override run waits for an override rather than a confirmation.
+func (r *Run) IsConfirmable() bool {
+	return r.Actions != nil && r.Actions.IsConfirmable
+}
+
+// IsAutoApplied returns true if the run is applied by the system rather than
+// confirmed by a user. The user who confirmed a run is available as
+// ConfirmedBy, which is fully populated when "confirmed_by" is included.
+func (r *Run) IsAutoApplied() bool {
+	return r.AutoApply && r.ConfirmedBy == nil
+}
+
+// Warnings returns the warnings Terraform emits for a run which only plans
+// part of the configuration, as with -target, or forces resources to be
+// replaced, as with -replace. They are derived from TargetAddrs and
+// ReplaceAddrs, so automation can log and audit such exceptional runs without
+// having to parse their logs.
+func (r *Run) Warnings() []string {
+	var warnings []string
+	if len(r.TargetAddrs) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"Resource targeting is in effect, the run only considers %s and their dependencies",
+			strings.Join(r.TargetAddrs, ", "),
+		))
+	}
+	if len(r.ReplaceAddrs) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"The run forces the replacement of %s",
+			strings.Join(r.ReplaceAddrs, ", "),
+		))
+	}
+	return warnings
+}
+
+// WorkspaceName returns the name of the run's workspace, or an empty string
+// if the workspace relation is not populated. The name is only available when
+// the workspace is included in the response, e.g. by listing runs with
+// RunListOptions.Include set to "workspace".
+func (r *Run) WorkspaceName() string {
+	if r.Workspace == nil {
+		return ""
+	}
+	return r.Workspace.Name
+}
+
 // RunActions represents the run actions.
 type RunActions struct {
 	IsCancelable      bool `json:"is-cancelable"`
@@ -173,9 +323,26 @@ type RunStatusTimestamps struct {
 type RunListOptions struct {
 	ListOptions
 
+	// A search string matched against the run ID, the run message, the
+	// commit SHA of the run's configuration version and the VCS username of
+	// the user that triggered the run.
+	Search *string `schema:"search[basic],omitempty"`
+
+	// A search string matched against the commit SHA of the run's
+	// configuration version.
+	SearchCommit *string `schema:"search[commit],omitempty"`
+
+	// A search string matched against the VCS username of the user that
+	// triggered the run.
+	SearchUser *string `schema:"search[user],omitempty"`
+
+	// Only list the runs performing the given operation, such as
+	// RunOperationDestroy to audit destroy runs.
+	Operation *RunOperation `schema:"filter[operation],omitempty"`
+
 	// A list of relations to include. See available resources:
 	// https://www.terraform.io/docs/cloud/api/run.html#available-related-resources
-	Include *string `schema:"include"`
+	Include *string `schema:"include,omitempty"`
 }
 
 // List all the runs of the given workspace.
@@ -199,6 +366,49 @@ func (s *runs) List(ctx context.Context, workspaceID string, options RunListOpti
 	return rl, nil
 }
 
+// Count the runs of the given workspace matching the list options, e.g. to
+// tell how long listing all of them will take. It makes a single request for
+// one run, and reads the total count of the pagination. The pagination and
+// included relations of the options are ignored.
+func (s *runs) Count(ctx context.Context, workspaceID string, options RunListOptions) (int, error) {
+	options.ListOptions = countOptions
+	options.Include = nil
+
+	rl, err := s.List(ctx, workspaceID, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalCount(rl.Pagination)
+}
+
+// ForEach calls fn for each run of the given workspace matching the list
+// options, most recent first. Only one page of runs is held at a time, so
+// the runs of a busy workspace can be processed with bounded memory. It
+// stops at the first error returned by fn, which it returns, or when the
+// context is done. The pagination of the options sets the first page and
+// the page size, which defaults to 100.
+func (s *runs) ForEach(ctx context.Context, workspaceID string, options RunListOptions, fn func(*Run) error) error {
+	return forEachPage(ctx, options.ListOptions, func(lo ListOptions) (*Pagination, error) {
+		options.ListOptions = lo
+
+		rl, err := s.List(ctx, workspaceID, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rl.Items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(r); err != nil {
+				return nil, err
+			}
+		}
+
+		return rl.Pagination, nil
+	})
+}
+
 // RunCreateOptions represents the options for creating a new run.
 type RunCreateOptions struct {
 	// Type is a public field utilized by JSON:API to
@@ -222,10 +432,20 @@ type RunCreateOptions struct {
 	// Specifies the message to be associated with this run.
 	Message *string `jsonapi:"attr,message,omitempty"`
 
+	// Specifies if this is a plan-only run, which can't be applied.
+	PlanOnly *bool `jsonapi:"attr,plan-only,omitempty"`
+
+	// DebuggingMode enables debug logging for this run only, as if TF_LOG
+	// was set to DEBUG, without changing the variables of the workspace.
+	// Supported by Terraform Cloud and recent Terraform Enterprise releases.
+	// Older servers ignore it; on those, set TF_LOG as an environment
+	// variable of the workspace instead, for the duration of the run.
+	DebuggingMode *bool `jsonapi:"attr,debugging-mode,omitempty"`
+
 	// Specifies the configuration version to use for this run. If the
 	// configuration version object is omitted, the run will be created using the
 	// workspace's latest configuration version.
-	ConfigurationVersion *ConfigurationVersion `jsonapi:"relation,configuration-version"`
+	ConfigurationVersion *ConfigurationVersion `jsonapi:"relation,configuration-version,omitempty"`
 
 	// Specifies the workspace where the run will be executed.
 	Workspace *Workspace `jsonapi:"relation,workspace"`
@@ -247,6 +467,30 @@ type RunCreateOptions struct {
 	// (destroys and then re-creates) the objects specified by the given
 	// resource addresses.
 	ReplaceAddrs []string `jsonapi:"attr,replace-addrs,omitempty"`
+
+	// Variables to set for this run only, overriding the workspace
+	// variables with the same key. Values are HCL expressions.
+	Variables []*RunVariable `jsonapi:"attr,variables,omitempty"`
+
+	// CheckConfigurationVersion makes Create verify that the latest
+	// non-speculative configuration version of the workspace is uploaded
+	// when no ConfigurationVersion is given, as the run would use it,
+	// returning ErrNoConfigurationVersion instead of a less helpful API
+	// error if it isn't. This costs additional requests and is not sent to
+	// the API.
+	CheckConfigurationVersion bool
+
+	// IdempotencyKey is an optional client-generated key sent with the
+	// request in the Idempotency-Key header, allowing the server to
+	// recognize retries of the same request. When set, the request is not
+	// retried by the client's retry policy. Instead, a request failing
+	// without a response, with a server error or with an undecodable
+	// response is retried with the same key, after checking the runs
+	// created since the first attempt for one the current user created
+	// through the API with the attributes of the options. As the key isn't
+	// stored on the run, an identical run created by the same user around
+	// the same time is taken for the run created by the request.
+	IdempotencyKey string
 }
 
 func (o RunCreateOptions) valid() error {
@@ -262,10 +506,27 @@ func (s *runs) Create(ctx context.Context, options RunCreateOptions) (*Run, erro
 		return nil, err
 	}
 
+	if options.CheckConfigurationVersion && options.ConfigurationVersion == nil {
+		if err := s.checkConfigurationVersion(ctx, options.Workspace.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.IdempotencyKey != "" {
+		return s.createIdempotent(ctx, options)
+	}
+
+	return s.create(ctx, options)
+}
+
+func (s *runs) create(ctx context.Context, options RunCreateOptions) (*Run, error) {
 	req, err := s.client.newRequest("POST", "runs", &options)
 	if err != nil {
 		return nil, err
 	}
+	if options.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", options.IdempotencyKey)
+	}
 
 	r := &Run{}
 	err = s.client.do(ctx, req, r)
@@ -276,6 +537,301 @@ func (s *runs) Create(ctx context.Context, options RunCreateOptions) (*Run, erro
 	return r, nil
 }
 
+// createIdempotent creates a run, retrying with the same idempotency key when
+// the outcome of a request is unknown. The request is sent without the
+// client's retries, as the server might not deduplicate requests using the
+// key, and before creating the run again the recent runs of the workspace are
+// checked for a run created by a previous attempt.
+func (s *runs) createIdempotent(ctx context.Context, options RunCreateOptions) (*Run, error) {
+	// Allow for some clock skew between the client and the server.
+	since := time.Now().Add(-time.Minute)
+
+	var user *User
+	for i := 0; ; i++ {
+		r, err := s.create(withoutRetries(ctx), options)
+		if err == nil || !createOutcomeUnknown(err) || i == maxIdempotentCreateAttempts-1 {
+			return r, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff(500, 2000, i)):
+		}
+
+		if user == nil {
+			if user, err = s.client.Users.ReadCurrent(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		created, err := s.findCreated(ctx, options, user.ID, since)
+		if err != nil {
+			return nil, err
+		}
+		if created != nil {
+			return created, nil
+		}
+	}
+}
+
+// maxIdempotentCreateAttempts is the number of times a run is attempted to be
+// created when using an idempotency key.
+const maxIdempotentCreateAttempts = 3
+
+// createOutcomeUnknown returns true if a failed request to create a run
+// might have created the run anyway: the request failed without a response,
+// the server failed or its response could not be decoded.
+func createOutcomeUnknown(err error) bool {
+	var urlErr *url.Error
+	var decodeErr *DecodeError
+	var apiErr *APIError
+	var statusErr *statusError
+	switch {
+	case errors.As(err, &urlErr), errors.As(err, &decodeErr):
+		return true
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode >= 500
+	case errors.As(err, &statusErr):
+		return statusErr.StatusCode >= 500
+	}
+	return false
+}
+
+// findCreated returns the most recent run of the workspace created through
+// the API by the given user since the given time, with the attributes set by
+// the options, or nil if there is none. Runs are read page by page, most
+// recent first, until one is older than the given time.
+func (s *runs) findCreated(ctx context.Context, options RunCreateOptions, userID string, since time.Time) (*Run, error) {
+	var created *Run
+	err := forEachPage(ctx, ListOptions{}, func(lo ListOptions) (*Pagination, error) {
+		rl, err := s.List(ctx, options.Workspace.ID, RunListOptions{ListOptions: lo})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range rl.Items {
+			if r.CreatedAt.Before(since) {
+				return nil, nil
+			}
+			if r.Source == RunSourceAPI && r.CreatedBy != nil && r.CreatedBy.ID == userID && options.matches(r) {
+				created = r
+				return nil, nil
+			}
+		}
+
+		return rl.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// matches returns true if the run has the attributes set by the options.
+func (o RunCreateOptions) matches(r *Run) bool {
+	switch {
+	case o.Message != nil && r.Message != *o.Message:
+		return false
+	case o.IsDestroy != nil && r.IsDestroy != *o.IsDestroy:
+		return false
+	case o.RefreshOnly != nil && r.RefreshOnly != *o.RefreshOnly:
+		return false
+	case o.PlanOnly != nil && r.PlanOnly != *o.PlanOnly:
+		return false
+	case o.ConfigurationVersion != nil && (r.ConfigurationVersion == nil || r.ConfigurationVersion.ID != o.ConfigurationVersion.ID):
+		return false
+	case !equalStrings(r.TargetAddrs, o.TargetAddrs), !equalStrings(r.ReplaceAddrs, o.ReplaceAddrs):
+		return false
+	}
+	return true
+}
+
+// equalStrings returns true if both slices hold the same strings in the same
+// order.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RunWaitStage represents a stage of a run that can be waited for.
+type RunWaitStage string
+
+// List all available run wait stages.
+const (
+	RunWaitPlanned RunWaitStage = "planned"
+	RunWaitApplied RunWaitStage = "applied"
+)
+
+// RunWaitOptions represents the options for waiting for a run.
+type RunWaitOptions struct {
+	// The stage to wait for. When waiting for RunWaitApplied, the run is
+	// confirmed as soon as its actions allow it to be confirmed. Defaults to
+	// RunWaitPlanned.
+	Stage RunWaitStage
+}
+
+// CreateAndWait creates a new run with the given options and waits for it to
+// reach the stage given by the wait options. The plan stage is reached once
+// the run is planned, cost estimated and policy checked, and awaits a
+// decision to confirm it or to override its policy checks, or once it has
+// moved on to be applied. A run finishing without changes to apply is
+// considered to have reached either stage. If the run reaches a final state
+// before the stage, it is returned together with an error wrapping
+// ErrRunTerminated.
+func (s *runs) CreateAndWait(ctx context.Context, options RunCreateOptions, wait RunWaitOptions) (*Run, error) {
+	r, err := s.Create(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed := false
+	return s.poll(ctx, r.ID, func(r *Run) (bool, error) {
+		switch {
+		case r.Status == RunPlannedAndFinished:
+			return true, nil
+		case r.Status == RunApplied:
+			return true, nil
+		case r.Status == RunPolicySoftFailed && wait.Stage != RunWaitApplied:
+			return true, nil
+		case r.Status.IsTerminal():
+			return true, fmt.Errorf("%w: run %s is %s", ErrRunTerminated, r.ID, r.Status)
+		case wait.Stage == RunWaitApplied:
+			if r.IsConfirmable() && !confirmed {
+				if err := s.Apply(ctx, r.ID, RunApplyOptions{}); err != nil {
+					return true, err
+				}
+				confirmed = true
+			}
+			return false, nil
+		default:
+			switch r.Status {
+			case RunPolicyOverride, RunConfirmed, RunApplyQueued, RunApplying:
+				return true, nil
+			}
+			return r.IsConfirmable(), nil
+		}
+	})
+}
+
+// RunWaitForStartOptions represents the options for waiting for a run to
+// start.
+type RunWaitForStartOptions struct {
+	// OnQueuePositionChange is called with the position of the run in the
+	// queue whenever it changes while the run is waiting to start.
+	OnQueuePositionChange func(position int)
+}
+
+// WaitForStart waits for a run to leave the pending and queued states and
+// returns the run as soon as it has started planning or has moved beyond.
+// The run is returned as well when it reaches a final state without being
+// started, so the caller should check its status.
+func (s *runs) WaitForStart(ctx context.Context, runID string, options RunWaitForStartOptions) (*Run, error) {
+	if !validStringID(&runID) {
+		return nil, ErrInvalidRunID
+	}
+
+	position := -1
+	return s.poll(ctx, runID, func(r *Run) (bool, error) {
+		if r.Status != RunPending && r.Status != RunPlanQueued {
+			return true, nil
+		}
+		if r.PositionInQueue != position {
+			position = r.PositionInQueue
+			if options.OnQueuePositionChange != nil {
+				options.OnQueuePositionChange(position)
+			}
+		}
+		return false, nil
+	})
+}
+
+// WaitForConfirmable waits for a run to be ready for an apply decision: once
+// it is planned, cost estimated and policy checked, and its actions allow it
+// to be confirmed. A run awaiting a policy override is returned as well, as
+// it awaits a decision to override its policy checks first, and so is a
+// plan-only run whose policy check soft failed, which ends the run without
+// an error. If the run reaches another final state instead, it is returned
+// together with an error wrapping ErrRunTerminated.
+func (s *runs) WaitForConfirmable(ctx context.Context, runID string) (*Run, error) {
+	if !validStringID(&runID) {
+		return nil, ErrInvalidRunID
+	}
+
+	return s.poll(ctx, runID, func(r *Run) (bool, error) {
+		switch {
+		case r.IsConfirmable():
+			return true, nil
+		case r.Status == RunPolicyOverride, r.Status == RunPolicySoftFailed:
+			return true, nil
+		case r.Status.IsTerminal():
+			return true, fmt.Errorf("%w: run %s is %s", ErrRunTerminated, r.ID, r.Status)
+		default:
+			return false, nil
+		}
+	})
+}
+
+// poll reads the run until fn reports it is done, backing off between reads.
+// The last read run is returned along with any error returned by fn.
+func (s *runs) poll(ctx context.Context, runID string, fn func(*Run) (bool, error)) (*Run, error) {
+	for i := 0; ; i++ {
+		start := time.Now()
+
+		r, err := s.Read(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+
+		if done, err := fn(r); done {
+			return r, err
+		}
+
+		if err := pollWait(ctx, start, backoff(500, 2000, i)); err != nil {
+			return r, err
+		}
+	}
+}
+
+// checkConfigurationVersion checks if the latest non-speculative
+// configuration version of the workspace, which a run created without a
+// configuration version uses, is uploaded.
+func (s *runs) checkConfigurationVersion(ctx context.Context, workspaceID string) error {
+	var latest *ConfigurationVersion
+	err := forEachPage(ctx, ListOptions{}, func(lo ListOptions) (*Pagination, error) {
+		cvl, err := s.client.ConfigurationVersions.List(ctx, workspaceID, ConfigurationVersionListOptions{ListOptions: lo})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cv := range cvl.Items {
+			if !cv.Speculative {
+				latest = cv
+				return nil, nil
+			}
+		}
+
+		return cvl.Pagination, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if latest == nil || latest.Status != ConfigurationUploaded {
+		return ErrNoConfigurationVersion
+	}
+
+	return nil
+}
+
 // Read a run by its ID.
 func (s *runs) Read(ctx context.Context, runID string) (*Run, error) {
 	return s.ReadWithOptions(ctx, runID, RunReadOptions{})
@@ -307,10 +863,76 @@ func (s *runs) ReadWithOptions(ctx context.Context, runID string, options RunRea
 	return r, nil
 }
 
+// runFullInclude lists every relation of a run which can be included when
+// reading it.
+const runFullInclude = "plan,apply,cost_estimate,configuration_version,configuration_version.ingress_attributes,workspace,created_by,confirmed_by"
+
+// ReadFull reads a run by its ID with its plan, apply, cost estimate,
+// configuration version and its ingress attributes, workspace, creator,
+// confirmer and policy checks populated, as needed to show every detail of
+// the run. Policy checks can't be included, so they are listed with a second
+// request, only when the run has any. Relations the run doesn't have, such as
+// the cost estimate when cost estimation is disabled or the confirmer of a
+// run which isn't confirmed yet, are left nil.
+func (s *runs) ReadFull(ctx context.Context, runID string) (*Run, error) {
+	r, err := s.ReadWithOptions(ctx, runID, RunReadOptions{Include: runFullInclude})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.PolicyChecks) > 0 {
+		r.PolicyChecks = nil
+		err := forEachPage(ctx, ListOptions{}, func(lo ListOptions) (*Pagination, error) {
+			pcl, err := s.client.PolicyChecks.List(ctx, runID, PolicyCheckListOptions{ListOptions: lo})
+			if err != nil {
+				return nil, err
+			}
+			r.PolicyChecks = append(r.PolicyChecks, pcl.Items...)
+
+			return pcl.Pagination, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// ReadPlan reads the plan of a run by the ID of the run, sparing callers
+// from reading the run first to learn the ID of its plan. The plan is
+// included when reading the run, so this takes a single request.
+func (s *runs) ReadPlan(ctx context.Context, runID string) (*Plan, error) {
+	r, err := s.ReadWithOptions(ctx, runID, RunReadOptions{Include: "plan"})
+	if err != nil {
+		return nil, err
+	}
+	if r.Plan == nil {
+		return nil, ErrResourceNotFound
+	}
+
+	return r.Plan, nil
+}
+
+// ReadApply reads the apply of a run by the ID of the run, sparing callers
+// from reading the run first to learn the ID of its apply. The apply is
+// included when reading the run, so this takes a single request.
+func (s *runs) ReadApply(ctx context.Context, runID string) (*Apply, error) {
+	r, err := s.ReadWithOptions(ctx, runID, RunReadOptions{Include: "apply"})
+	if err != nil {
+		return nil, err
+	}
+	if r.Apply == nil {
+		return nil, ErrResourceNotFound
+	}
+
+	return r.Apply, nil
+}
+
 // RunApplyOptions represents the options for applying a run.
 type RunApplyOptions struct {
 	// An optional comment about the run.
-	Comment *string `jsonapi:"attr,comment,omitempty"`
+	Comment *string `json:"comment,omitempty"`
 }
 
 // Apply a run by its ID.
@@ -331,7 +953,7 @@ func (s *runs) Apply(ctx context.Context, runID string, options RunApplyOptions)
 // RunCancelOptions represents the options for canceling a run.
 type RunCancelOptions struct {
 	// An optional explanation for why the run was canceled.
-	Comment *string `jsonapi:"attr,comment,omitempty"`
+	Comment *string `json:"comment,omitempty"`
 }
 
 // Cancel a run by its ID.
@@ -349,10 +971,69 @@ func (s *runs) Cancel(ctx context.Context, runID string, options RunCancelOption
 	return s.client.do(ctx, req, nil)
 }
 
+// CancelAll cancels all the cancelable runs of the given workspace and
+// returns the number of canceled runs. Runs failing to be canceled don't stop
+// the others from being canceled, their errors are returned together in a
+// *RunActionError.
+func (s *runs) CancelAll(ctx context.Context, workspaceID string, options RunCancelOptions) (int, error) {
+	if !validStringID(&workspaceID) {
+		return 0, ErrInvalidWorkspaceID
+	}
+
+	return s.actOnRuns(ctx, workspaceID, "cancel",
+		func(r *Run) bool {
+			return r.Actions != nil && r.Actions.IsCancelable
+		},
+		func(runID string) error {
+			return s.Cancel(ctx, runID, options)
+		},
+	)
+}
+
+// actOnRuns calls act with the ID of each run of the given workspace matching
+// match, and returns the number of runs act succeeded for. Runs act fails for
+// don't stop the others, their errors are returned together in a
+// *RunActionError for the given action.
+func (s *runs) actOnRuns(ctx context.Context, workspaceID, action string, match func(*Run) bool, act func(runID string) error) (int, error) {
+	// Collect the matching runs before acting on any, as changing runs
+	// while paging through them can shift the runs of the later pages,
+	// skipping some of them.
+	var runIDs []string
+	err := s.ForEach(ctx, workspaceID, RunListOptions{}, func(r *Run) error {
+		if match(r) {
+			runIDs = append(runIDs, r.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	actionErr := &RunActionError{Action: action, Errors: make(map[string]error)}
+	for _, runID := range runIDs {
+		if err := ctx.Err(); err != nil {
+			return done, err
+		}
+
+		if err := act(runID); err != nil {
+			actionErr.Errors[runID] = err
+			continue
+		}
+		done++
+	}
+
+	if len(actionErr.Errors) > 0 {
+		return done, actionErr
+	}
+
+	return done, nil
+}
+
 // RunForceCancelOptions represents the options for force-canceling a run.
 type RunForceCancelOptions struct {
 	// An optional comment explaining the reason for the force-cancel.
-	Comment *string `jsonapi:"attr,comment,omitempty"`
+	Comment *string `json:"comment,omitempty"`
 }
 
 // ForceCancel is used to forcefully cancel a run by its ID.
@@ -373,7 +1054,7 @@ func (s *runs) ForceCancel(ctx context.Context, runID string, options RunForceCa
 // RunDiscardOptions represents the options for discarding a run.
 type RunDiscardOptions struct {
 	// An optional explanation for why the run was discarded.
-	Comment *string `jsonapi:"attr,comment,omitempty"`
+	Comment *string `json:"comment,omitempty"`
 }
 
 // Discard a run by its ID.
@@ -391,13 +1072,37 @@ func (s *runs) Discard(ctx context.Context, runID string, options RunDiscardOpti
 	return s.client.do(ctx, req, nil)
 }
 
+// DiscardOlderThan discards all the discardable runs of the given workspace
+// created more than age ago, and returns the number of runs discarded. When
+// some runs fail to be discarded, the others are still discarded and a
+// *RunActionError is returned.
+func (s *runs) DiscardOlderThan(ctx context.Context, workspaceID string, age time.Duration, options RunDiscardOptions) (int, error) {
+	if !validStringID(&workspaceID) {
+		return 0, ErrInvalidWorkspaceID
+	}
+	if age <= 0 {
+		return 0, errors.New("invalid value for age, must be positive")
+	}
+
+	cutoff := time.Now().Add(-age)
+	return s.actOnRuns(ctx, workspaceID, "discard",
+		func(r *Run) bool {
+			return r.Actions != nil && r.Actions.IsDiscardable && r.CreatedAt.Before(cutoff)
+		},
+		func(runID string) error {
+			return s.Discard(ctx, runID, options)
+		},
+	)
+}
+
 // PlanFileOptions represents the options for getting the plan file for a run.
 type PlanFileOptions struct {
 	// Format of plan file. Valid values are json and binary.
 	Format string `schema:"format"`
 }
 
-// GetPlanFile gets the plan file for a run.
+// GetPlanFile gets the plan file for a run. ErrPlanNotFinished is returned
+// when the plan of the run hasn't finished yet.
 func (s *runs) GetPlanFile(ctx context.Context, runID string, options PlanFileOptions) ([]byte, error) {
 	u := fmt.Sprintf("/runs/%s/plan", url.QueryEscape(runID))
 
@@ -407,13 +1112,29 @@ func (s *runs) GetPlanFile(ctx context.Context, runID string, options PlanFileOp
 	}
 
 	var buf bytes.Buffer
-	if err := s.client.do(ctx, req, &buf); err != nil {
-		return nil, err
+	if err := s.client.download(ctx, req, &buf); err != nil {
+		return nil, s.planNotFinishedError(ctx, runID, err)
 	}
 
 	return buf.Bytes(), nil
 }
 
+// planNotFinishedError translates err, returned when retrieving the plan
+// file of a run, into ErrPlanNotFinished when the plan of the run hasn't
+// finished yet. Otherwise err is returned as is.
+func (s *runs) planNotFinishedError(ctx context.Context, runID string, err error) error {
+	if ctx.Err() != nil || !validStringID(&runID) {
+		return err
+	}
+
+	r, readErr := s.Read(ctx, runID)
+	if readErr != nil || r.Plan == nil {
+		return err
+	}
+
+	return planNotFinishedError(ctx, s.client, r.Plan.ID, err)
+}
+
 // UploadPlan uploads the plan file for a run.
 func (s *runs) UploadPlanFile(ctx context.Context, runID string, plan []byte, options PlanFileOptions) error {
 	q := url.Values{}
@@ -459,3 +1180,99 @@ func (s *runs) UploadLogs(ctx context.Context, runID string, plan []byte, option
 
 	return s.client.do(ctx, req, nil)
 }
+
+// Tail streams the plan and apply logs of a run to w as they are produced,
+// returning once the run has reached a final state.
+func (s *runs) Tail(ctx context.Context, runID string, w io.Writer) error {
+	if !validStringID(&runID) {
+		return ErrInvalidRunID
+	}
+
+	r, err := s.Read(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	if r.Plan == nil {
+		return fmt.Errorf("run %s does not have a plan", runID)
+	}
+
+	logs, err := s.client.Plans.Logs(ctx, r.Plan.ID)
+	if err != nil {
+		return err
+	}
+	if err := tailLogs(w, logs); err != nil {
+		return err
+	}
+
+	// The plan has finished, so wait for the run to either start applying
+	// or to finish without an apply (e.g. discarded or errored).
+	for i := 0; ; i++ {
+		start := time.Now()
+
+		r, err = s.Read(ctx, runID)
+		if err != nil {
+			return err
+		}
+
+		switch r.Status {
+		case RunConfirmed, RunApplyQueued, RunApplying, RunApplied:
+			return s.tailApply(ctx, r, w)
+		case RunCanceled, RunDiscarded, RunErrored:
+			// The run may have been canceled or errored during the apply,
+			// in which case the apply logs still need to be streamed.
+			if r.StatusTimestamps != nil && r.StatusTimestamps.ApplyingAt != nil {
+				return s.tailApply(ctx, r, w)
+			}
+			return nil
+		case RunPlannedAndFinished, RunPolicySoftFailed:
+			return nil
+		}
+
+		if err := pollWait(ctx, start, backoff(500, 2000, i)); err != nil {
+			return err
+		}
+	}
+}
+
+// tailApply streams the apply logs of the given run to w.
+func (s *runs) tailApply(ctx context.Context, r *Run, w io.Writer) error {
+	if r.Apply == nil {
+		return fmt.Errorf("run %s does not have an apply", r.ID)
+	}
+
+	logs, err := s.client.Applies.Logs(ctx, r.Apply.ID)
+	if err != nil {
+		return err
+	}
+
+	return tailLogs(w, logs)
+}
+
+// tailLogs copies logs to w chunk by chunk. If w is buffered and implements
+// a Flush method, it is flushed after every chunk so partial lines are
+// written out as soon as they are received.
+func tailLogs(w io.Writer, logs io.Reader) error {
+	flusher, canFlush := w.(interface{ Flush() error })
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := logs.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			if canFlush {
+				if ferr := flusher.Flush(); ferr != nil {
+					return ferr
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,6 +77,33 @@ func TestRunsList(t *testing.T) {
 		assert.NotEmpty(t, rl.Items)
 		assert.NotNil(t, rl.Items[0].Workspace)
 		assert.NotEmpty(t, rl.Items[0].Workspace.Name)
+		assert.Equal(t, wTest.Name, rl.Items[0].WorkspaceName())
+	})
+
+	t.Run("with a search string", func(t *testing.T) {
+		rl, err := client.Runs.List(ctx, wTest.ID, RunListOptions{
+			Search: String(rTest1.ID),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, rl.Items, 1)
+		assert.Equal(t, rTest1.ID, rl.Items[0].ID)
+	})
+
+	t.Run("with a search string matching nothing", func(t *testing.T) {
+		rl, err := client.Runs.List(ctx, wTest.ID, RunListOptions{
+			SearchCommit: String("0000000000000000000000000000000000000000"),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, rl.Items)
+	})
+
+	t.Run("without workspace included", func(t *testing.T) {
+		rl, err := client.Runs.List(ctx, wTest.ID, RunListOptions{})
+		require.NoError(t, err)
+
+		require.NotEmpty(t, rl.Items)
+		assert.Empty(t, rl.Items[0].WorkspaceName())
 	})
 
 	t.Run("without a valid workspace ID", func(t *testing.T) {
@@ -145,6 +178,48 @@ func TestRunsCreate(t *testing.T) {
 		assert.EqualError(t, err, "workspace is required")
 	})
 
+	t.Run("when checking the configuration version", func(t *testing.T) {
+		r, err := client.Runs.Create(ctx, RunCreateOptions{
+			Workspace:                 wTest,
+			CheckConfigurationVersion: true,
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, r.ID)
+	})
+
+	t.Run("when checking the configuration version of an empty workspace", func(t *testing.T) {
+		wEmpty, wEmptyCleanup := createWorkspace(t, client, nil)
+		defer wEmptyCleanup()
+
+		r, err := client.Runs.Create(ctx, RunCreateOptions{
+			Workspace:                 wEmpty,
+			CheckConfigurationVersion: true,
+		})
+		assert.Nil(t, r)
+		assert.Equal(t, ErrNoConfigurationVersion, err)
+	})
+
+	t.Run("with a destroy plan on a workspace forbidding them", func(t *testing.T) {
+		wNoDestroy, wNoDestroyCleanup := createWorkspace(t, client, nil)
+		defer wNoDestroyCleanup()
+
+		wNoDestroy, err := client.Workspaces.UpdateByID(ctx, wNoDestroy.ID, WorkspaceUpdateOptions{
+			AllowDestroyPlan: Bool(false),
+		})
+		require.NoError(t, err)
+		require.False(t, wNoDestroy.AllowDestroyPlan)
+
+		_, cvCleanup := createUploadedConfigurationVersion(t, client, wNoDestroy)
+		defer cvCleanup()
+
+		r, err := client.Runs.Create(ctx, RunCreateOptions{
+			Workspace: wNoDestroy,
+			IsDestroy: Bool(true),
+		})
+		assert.Nil(t, r)
+		assert.Error(t, err)
+	})
+
 	t.Run("with additional attributes", func(t *testing.T) {
 		options := RunCreateOptions{
 			Message:      String("yo"),
@@ -210,6 +285,159 @@ func TestRunsReadWithOptions(t *testing.T) {
 		assert.NotEmpty(t, r.CreatedBy)
 		assert.NotEmpty(t, r.CreatedBy.Username)
 	})
+
+	t.Run("when the run is confirmed", func(t *testing.T) {
+		rPlanned, rPlannedCleanup := createPlannedRun(t, client, nil)
+		defer rPlannedCleanup()
+
+		err := client.Runs.Apply(ctx, rPlanned.ID, RunApplyOptions{})
+		require.NoError(t, err)
+
+		r, err := client.Runs.ReadWithOptions(ctx, rPlanned.ID, RunReadOptions{
+			Include: "confirmed_by",
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, r.ConfirmedBy)
+		assert.NotEmpty(t, r.ConfirmedBy.Username)
+		assert.False(t, r.IsAutoApplied())
+	})
+}
+
+func TestRunsReadFull(t *testing.T) {
+	var requests []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+
+		switch r.URL.Path {
+		case "/api/v2/runs/run-full":
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-full","type":"runs","attributes":{"status":"policy_checked"},"relationships":{
+				"plan":{"data":{"id":"plan-1","type":"plans"}},
+				"apply":{"data":{"id":"apply-1","type":"applies"}},
+				"cost-estimate":{"data":null},
+				"workspace":{"data":{"id":"ws-1","type":"workspaces"}},
+				"created-by":{"data":{"id":"user-1","type":"users"}},
+				"confirmed-by":{"data":null},
+				"policy-checks":{"data":[{"id":"polchk-1","type":"policy-checks"}]}
+			}},"included":[
+				{"id":"plan-1","type":"plans","attributes":{"status":"finished"}},
+				{"id":"apply-1","type":"applies","attributes":{"status":"unreachable"}},
+				{"id":"ws-1","type":"workspaces","attributes":{"name":"app"}},
+				{"id":"user-1","type":"users","attributes":{"username":"alice"}}
+			]}`))
+		case "/api/v2/runs/run-plain":
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-plain","type":"runs","attributes":{"status":"planned_and_finished","plan-only":true},"relationships":{
+				"plan":{"data":{"id":"plan-2","type":"plans"}},
+				"policy-checks":{"data":[]}
+			}},"included":[
+				{"id":"plan-2","type":"plans","attributes":{"status":"finished"}}
+			]}`))
+		case "/api/v2/runs/run-full/policy-checks":
+			checkedWrite(t, w, []byte(`{"data":[{"id":"polchk-1","type":"policy-checks","attributes":{"status":"passed"}}]}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with policy checks", func(t *testing.T) {
+		requests = nil
+
+		r, err := client.Runs.ReadFull(ctx, "run-full")
+		require.NoError(t, err)
+		assert.Equal(t, "finished", string(r.Plan.Status))
+		assert.Equal(t, "unreachable", string(r.Apply.Status))
+		assert.Equal(t, "app", r.Workspace.Name)
+		assert.Equal(t, "alice", r.CreatedBy.Username)
+		assert.Nil(t, r.CostEstimate)
+		assert.Nil(t, r.ConfirmedBy)
+		require.Len(t, r.PolicyChecks, 1)
+		assert.Equal(t, PolicyPasses, r.PolicyChecks[0].Status)
+
+		query := url.Values{"include": []string{runFullInclude}}
+		assert.Equal(t, []string{
+			"/api/v2/runs/run-full?" + query.Encode(),
+			"/api/v2/runs/run-full/policy-checks?page%5Bnumber%5D=1&page%5Bsize%5D=100",
+		}, requests)
+	})
+
+	t.Run("without policy checks", func(t *testing.T) {
+		requests = nil
+
+		r, err := client.Runs.ReadFull(ctx, "run-plain")
+		require.NoError(t, err)
+		assert.Equal(t, "finished", string(r.Plan.Status))
+		assert.Nil(t, r.Apply)
+		assert.Empty(t, r.PolicyChecks)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("without a valid run ID", func(t *testing.T) {
+		_, err := client.Runs.ReadFull(ctx, badIdentifier)
+		assert.EqualError(t, err, ErrInvalidRunID.Error())
+	})
+}
+
+func TestRunsReadPlanAndApply(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/runs/run-123":
+			switch r.URL.Query().Get("include") {
+			case "plan":
+				checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs",`+
+					`"relationships":{"plan":{"data":{"id":"plan-123","type":"plans"}}}},`+
+					`"included":[{"id":"plan-123","type":"plans","attributes":{"status":"finished","resource-additions":2}}]}`))
+			case "apply":
+				checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs",`+
+					`"relationships":{"apply":{"data":{"id":"apply-123","type":"applies"}}}},`+
+					`"included":[{"id":"apply-123","type":"applies","attributes":{"status":"finished","resource-additions":2}}]}`))
+			default:
+				assert.Fail(t, "invalid include", r.URL.Query().Get("include"))
+			}
+		case "/api/v2/runs/nonexisting":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when the run exists", func(t *testing.T) {
+		p, err := client.Runs.ReadPlan(ctx, "run-123")
+		require.NoError(t, err)
+		assert.Equal(t, "plan-123", p.ID)
+		assert.Equal(t, PlanFinished, p.Status)
+		assert.Equal(t, 2, p.ResourceAdditions)
+
+		a, err := client.Runs.ReadApply(ctx, "run-123")
+		require.NoError(t, err)
+		assert.Equal(t, "apply-123", a.ID)
+		assert.Equal(t, ApplyFinished, a.Status)
+		assert.Equal(t, 2, a.ResourceAdditions)
+	})
+
+	t.Run("when the run does not exist", func(t *testing.T) {
+		p, err := client.Runs.ReadPlan(ctx, "nonexisting")
+		assert.Nil(t, p)
+		assert.Equal(t, ErrResourceNotFound, err)
+
+		a, err := client.Runs.ReadApply(ctx, "nonexisting")
+		assert.Nil(t, a)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		p, err := client.Runs.ReadPlan(ctx, badIdentifier)
+		assert.Nil(t, p)
+		assert.Equal(t, ErrInvalidRunID, err)
+
+		a, err := client.Runs.ReadApply(ctx, badIdentifier)
+		assert.Nil(t, a)
+		assert.Equal(t, ErrInvalidRunID, err)
+	})
 }
 
 func TestRunsApply(t *testing.T) {
@@ -240,6 +468,219 @@ func TestRunsApply(t *testing.T) {
 	})
 }
 
+func TestRunsCreateIdempotent(t *testing.T) {
+	run := func(id, message, userID, createdAt string) string {
+		return `{"id":"` + id + `","type":"runs","attributes":{"message":"` + message + `","source":"tfe-api","created-at":"` + createdAt + `"},` +
+			`"relationships":{"created-by":{"data":{"id":"` + userID + `","type":"users"}}}}`
+	}
+
+	t.Run("when the connection drops", func(t *testing.T) {
+		var keys, messages []string
+		var pages []string
+		creates := 0
+
+		client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "POST" && r.URL.Path == "/api/v2/runs":
+				var body struct {
+					Data struct {
+						Attributes struct {
+							Message string `json:"message"`
+						} `json:"attributes"`
+					} `json:"data"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				keys = append(keys, r.Header.Get("Idempotency-Key"))
+				messages = append(messages, body.Data.Attributes.Message)
+				creates++
+
+				// Drop the connection to simulate a run being created
+				// without the response reaching the client.
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if assert.NoError(t, err) {
+					conn.Close()
+				}
+			case r.Method == "GET" && r.URL.Path == "/api/v2/account/details":
+				checkedWrite(t, w, []byte(`{"data":{"id":"user-123","type":"users"}}`))
+			case r.Method == "GET" && r.URL.Path == "/api/v2/workspaces/ws-123/runs":
+				now := time.Now().UTC().Format(time.RFC3339)
+				page := r.URL.Query().Get("page[number]")
+				pages = append(pages, page)
+				if page == "2" {
+					checkedWrite(t, w, []byte(`{"data":[`+
+						run("run-new", "deploy", "user-123", now)+`,`+
+						run("run-old", "deploy", "user-123", "2020-01-01T00:00:00Z")+
+						`],"meta":{"pagination":{"current-page":2,"total-pages":2}}}`))
+					return
+				}
+				checkedWrite(t, w, []byte(`{"data":[`+
+					run("run-other", "other", "user-123", now)+`,`+
+					run("run-foreign", "deploy", "user-456", now)+
+					`],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2}}}`))
+			default:
+				assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+			}
+		})
+
+		r, err := client.Runs.Create(context.Background(), RunCreateOptions{
+			Workspace:      &Workspace{ID: "ws-123"},
+			Message:        String("deploy"),
+			IdempotencyKey: "key-123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "run-new", r.ID)
+		assert.Equal(t, 1, creates)
+		assert.Equal(t, []string{"key-123"}, keys)
+		assert.Equal(t, []string{"deploy"}, messages)
+		assert.Equal(t, []string{"1", "2"}, pages)
+	})
+
+	t.Run("when the server fails", func(t *testing.T) {
+		creates, lists := 0, 0
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/api/v2/ping":
+			case r.Method == "POST" && r.URL.Path == "/api/v2/runs":
+				creates++
+				if creates == 1 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				checkedWrite(t, w, []byte(`{"data":{"id":"run-new","type":"runs"}}`))
+			case r.Method == "GET" && r.URL.Path == "/api/v2/account/details":
+				checkedWrite(t, w, []byte(`{"data":{"id":"user-123","type":"users"}}`))
+			case r.Method == "GET" && r.URL.Path == "/api/v2/workspaces/ws-123/runs":
+				lists++
+				checkedWrite(t, w, []byte(`{"data":[`+
+					run("run-old", "deploy", "user-123", "2020-01-01T00:00:00Z")+
+					`],"meta":{"pagination":{"current-page":1,"total-pages":1}}}`))
+			default:
+				assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+			}
+		}))
+		defer ts.Close()
+
+		client, err := NewClient(&Config{
+			Address:           ts.URL,
+			Token:             "123",
+			HTTPClient:        ts.Client(),
+			RetryServerErrors: true,
+		})
+		require.NoError(t, err)
+
+		r, err := client.Runs.Create(context.Background(), RunCreateOptions{
+			Workspace:      &Workspace{ID: "ws-123"},
+			Message:        String("deploy"),
+			IdempotencyKey: "key-123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "run-new", r.ID)
+		assert.Equal(t, 2, creates)
+		assert.Equal(t, 1, lists)
+	})
+
+	t.Run("when the request is rejected", func(t *testing.T) {
+		creates := 0
+
+		client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "POST" && r.URL.Path == "/api/v2/runs":
+				creates++
+				w.WriteHeader(http.StatusUnprocessableEntity)
+			default:
+				assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+			}
+		})
+
+		_, err := client.Runs.Create(context.Background(), RunCreateOptions{
+			Workspace:      &Workspace{ID: "ws-123"},
+			IdempotencyKey: "key-123",
+		})
+		assert.EqualError(t, err, "422 Unprocessable Entity")
+		assert.Equal(t, 1, creates)
+	})
+}
+
+func TestRunsCreate_debuggingMode(t *testing.T) {
+	var attributes map[string]interface{}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v2/runs":
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			attributes = body.Data.Attributes
+
+			w.WriteHeader(http.StatusCreated)
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs","attributes":{"debugging-mode":true}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	r, err := client.Runs.Create(context.Background(), RunCreateOptions{
+		Workspace:     &Workspace{ID: "ws-123"},
+		DebuggingMode: Bool(true),
+	})
+	require.NoError(t, err)
+	assert.True(t, r.DebuggingMode)
+	assert.Equal(t, true, attributes["debugging-mode"])
+}
+
+func TestRunsCreate_checkConfigurationVersion(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"data":[` +
+			`{"id":"cv-3","type":"configuration-versions","attributes":{"speculative":true,"status":"uploaded"}},` +
+			`{"id":"cv-2","type":"configuration-versions","attributes":{"speculative":true,"status":"pending"}}` +
+			`],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2}}}`,
+		"2": `{"data":[` +
+			`{"id":"cv-1","type":"configuration-versions","attributes":{"speculative":false,"status":"%s"}},` +
+			`{"id":"cv-0","type":"configuration-versions","attributes":{"speculative":false,"status":"uploaded"}}` +
+			`],"meta":{"pagination":{"current-page":2,"total-pages":2}}}`,
+	}
+
+	tests := []struct {
+		name   string
+		status ConfigurationStatus
+		err    error
+	}{
+		{"latest is uploaded", ConfigurationUploaded, nil},
+		{"latest is pending", ConfigurationPending, ErrNoConfigurationVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/api/v2/workspaces/ws-123/configuration-versions":
+					if r.URL.Query().Get("page[number]") == "2" {
+						checkedWrite(t, w, []byte(fmt.Sprintf(pages["2"], tt.status)))
+						return
+					}
+					checkedWrite(t, w, []byte(pages["1"]))
+				case r.Method == "POST" && r.URL.Path == "/api/v2/runs":
+					w.WriteHeader(http.StatusCreated)
+					checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs"}}`))
+				default:
+					assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+				}
+			})
+
+			_, err := client.Runs.Create(context.Background(), RunCreateOptions{
+				Workspace:                 &Workspace{ID: "ws-123"},
+				CheckConfigurationVersion: true,
+			})
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
 func TestRunsCancel(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -272,6 +713,145 @@ func TestRunsCancel(t *testing.T) {
 	})
 }
 
+func TestRunsActions_comment(t *testing.T) {
+	bodies := make(map[string]string)
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies[r.URL.Path] = string(b)
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	ctx := context.Background()
+
+	require.NoError(t, client.Runs.Apply(ctx, "run-1", RunApplyOptions{Comment: String("ship it")}))
+	require.NoError(t, client.Runs.Cancel(ctx, "run-1", RunCancelOptions{Comment: String("stuck on a lock")}))
+	require.NoError(t, client.Runs.ForceCancel(ctx, "run-1", RunForceCancelOptions{Comment: String("still stuck")}))
+	require.NoError(t, client.Runs.Discard(ctx, "run-1", RunDiscardOptions{}))
+
+	// The comment is sent as plain JSON, as documented for run actions.
+	assert.Equal(t, map[string]string{
+		"/api/v2/runs/run-1/actions/apply":        `{"comment":"ship it"}`,
+		"/api/v2/runs/run-1/actions/cancel":       `{"comment":"stuck on a lock"}`,
+		"/api/v2/runs/run-1/actions/force-cancel": `{"comment":"still stuck"}`,
+		"/api/v2/runs/run-1/actions/discard":      `{}`,
+	}, bodies)
+}
+
+func TestRunsCancelAll(t *testing.T) {
+	var canceled []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123/runs":
+			checkedWrite(t, w, []byte(`{"data":[`+
+				`{"id":"run-1","type":"runs","attributes":{"actions":{"is-cancelable":true}}},`+
+				`{"id":"run-2","type":"runs","attributes":{"actions":{"is-cancelable":false}}},`+
+				`{"id":"run-3","type":"runs","attributes":{"actions":{"is-cancelable":true}}},`+
+				`{"id":"run-4","type":"runs","attributes":{"actions":{"is-cancelable":true}}}`+
+				`],"meta":{"pagination":{"current-page":1,"total-pages":1}}}`))
+		case "/api/v2/runs/run-1/actions/cancel", "/api/v2/runs/run-4/actions/cancel":
+			canceled = append(canceled, r.URL.Path)
+			w.WriteHeader(202)
+		case "/api/v2/runs/run-3/actions/cancel":
+			w.WriteHeader(404)
+		default:
+			assert.Fail(t, "invalid request URI", "URI", r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a failing run", func(t *testing.T) {
+		n, err := client.Runs.CancelAll(ctx, "ws-123", RunCancelOptions{})
+		assert.Equal(t, 2, n)
+		assert.Len(t, canceled, 2)
+
+		var cancelErr *RunActionError
+		require.True(t, errors.As(err, &cancelErr))
+		assert.Equal(t, "cancel", cancelErr.Action)
+		assert.Len(t, cancelErr.Errors, 1)
+		assert.Equal(t, ErrResourceNotFound, cancelErr.Errors["run-3"])
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		n, err := client.Runs.CancelAll(ctx, badIdentifier, RunCancelOptions{})
+		assert.Equal(t, 0, n)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestRunsDiscardOlderThan(t *testing.T) {
+	var discarded []string
+
+	now := time.Now().UTC()
+	created := func(ago time.Duration) string {
+		return now.Add(-ago).Format(time.RFC3339)
+	}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123/runs":
+			checkedWrite(t, w, []byte(`{"data":[`+
+				`{"id":"run-1","type":"runs","attributes":{"created-at":"`+created(time.Hour)+`","actions":{"is-discardable":true}}},`+
+				`{"id":"run-2","type":"runs","attributes":{"created-at":"`+created(5*time.Hour)+`","actions":{"is-discardable":false}}},`+
+				`{"id":"run-3","type":"runs","attributes":{"created-at":"`+created(6*time.Hour)+`","actions":{"is-discardable":true}}},`+
+				`{"id":"run-4","type":"runs","attributes":{"created-at":"`+created(7*time.Hour)+`","actions":{"is-discardable":true}}},`+
+				`{"id":"run-5","type":"runs","attributes":{"created-at":"`+created(8*time.Hour)+`","actions":{"is-discardable":true}}}`+
+				`],"meta":{"pagination":{"current-page":1,"total-pages":1}}}`))
+		case "/api/v2/runs/run-3/actions/discard", "/api/v2/runs/run-5/actions/discard":
+			discarded = append(discarded, r.URL.Path)
+			w.WriteHeader(202)
+		case "/api/v2/runs/run-4/actions/discard":
+			w.WriteHeader(409)
+		default:
+			assert.Fail(t, "invalid request URI", "URI", r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a failing run", func(t *testing.T) {
+		n, err := client.Runs.DiscardOlderThan(ctx, "ws-123", 4*time.Hour, RunDiscardOptions{})
+		assert.Equal(t, 2, n)
+		assert.Equal(t, []string{
+			"/api/v2/runs/run-3/actions/discard",
+			"/api/v2/runs/run-5/actions/discard",
+		}, discarded)
+
+		var discardErr *RunActionError
+		require.True(t, errors.As(err, &discardErr))
+		assert.Equal(t, "discard", discardErr.Action)
+		assert.Len(t, discardErr.Errors, 1)
+		assert.Contains(t, discardErr.Errors, "run-4")
+	})
+
+	t.Run("with a canceled context", func(t *testing.T) {
+		discarded = nil
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		n, err := client.Runs.DiscardOlderThan(ctx, "ws-123", 4*time.Hour, RunDiscardOptions{})
+		assert.Equal(t, 0, n)
+		assert.Equal(t, context.Canceled, err)
+		assert.Empty(t, discarded)
+	})
+
+	t.Run("with an invalid age", func(t *testing.T) {
+		n, err := client.Runs.DiscardOlderThan(ctx, "ws-123", 0, RunDiscardOptions{})
+		assert.Equal(t, 0, n)
+		assert.EqualError(t, err, "invalid value for age, must be positive")
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		n, err := client.Runs.DiscardOlderThan(ctx, badIdentifier, time.Hour, RunDiscardOptions{})
+		assert.Equal(t, 0, n)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
 func TestRunsForceCancel(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -493,6 +1073,73 @@ func TestRunsUploadLogs(t *testing.T) {
 	}
 }
 
+func TestRunsVariables(t *testing.T) {
+	var attributes map[string]interface{}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v2/runs":
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			attributes = body.Data.Attributes
+
+			w.WriteHeader(201)
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs","attributes":{`+
+				`"plan-only":true,"variables":[{"key":"region","value":"\"eu-west-1\""}]}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	r, err := client.Runs.Create(context.Background(), RunCreateOptions{
+		Workspace: &Workspace{ID: "ws-123"},
+		PlanOnly:  Bool(true),
+		Variables: []*RunVariable{{Key: "region", Value: `"eu-west-1"`}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, true, attributes["plan-only"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "region", "value": `"eu-west-1"`},
+	}, attributes["variables"])
+
+	assert.True(t, r.PlanOnly)
+	assert.True(t, r.IsSpeculative())
+	assert.Equal(t, []RunVariable{{Key: "region", Value: `"eu-west-1"`}}, r.Variables)
+}
+
+func TestRunsList_destroyRuns(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123/runs":
+			assert.Equal(t, "destroy", r.URL.Query().Get("filter[operation]"))
+			checkedWrite(t, w, []byte(`{"data":[
+				{"id":"run-1","type":"runs","attributes":{"is-destroy":true}},
+				{"id":"run-2","type":"runs","attributes":{"is-destroy":false}},
+				{"id":"run-3","type":"runs","attributes":{"is-destroy":true}}
+			]}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	rl, err := client.Runs.List(context.Background(), "ws-123", RunListOptions{
+		Operation: RunOperationValue(RunOperationDestroy),
+	})
+	require.NoError(t, err)
+	require.Len(t, rl.Items, 3)
+
+	var ids []string
+	for _, r := range rl.DestroyRuns() {
+		ids = append(ids, r.ID)
+	}
+	assert.Equal(t, []string{"run-1", "run-3"}, ids)
+}
+
 func TestRun_Unmarshal(t *testing.T) {
 	data := map[string]interface{}{
 		"data": map[string]interface{}{
@@ -556,3 +1203,441 @@ func TestRun_Unmarshal(t *testing.T) {
 	assert.Equal(t, run.StatusTimestamps.PlanQueuedAt, planQueuedParsedTime)
 	assert.Equal(t, run.StatusTimestamps.ErroredAt, erroredParsedTime)
 }
+
+func TestRun_ConfirmedBy(t *testing.T) {
+	unmarshal := func(t *testing.T, autoApply bool, confirmedBy interface{}) *Run {
+		data := map[string]interface{}{
+			"data": map[string]interface{}{
+				"type": "runs",
+				"id":   "run-1",
+				"attributes": map[string]interface{}{
+					"auto-apply": autoApply,
+				},
+				"relationships": map[string]interface{}{
+					"confirmed-by": map[string]interface{}{
+						"data": confirmedBy,
+					},
+				},
+			},
+		}
+		byteData, err := json.Marshal(data)
+		require.NoError(t, err)
+
+		run := &Run{}
+		err = unmarshalResponse(bytes.NewReader(byteData), run)
+		require.NoError(t, err)
+		return run
+	}
+
+	t.Run("when confirmed by a user", func(t *testing.T) {
+		run := unmarshal(t, false, map[string]interface{}{
+			"type": "users",
+			"id":   "user-1",
+		})
+		require.NotNil(t, run.ConfirmedBy)
+		assert.Equal(t, "user-1", run.ConfirmedBy.ID)
+		assert.False(t, run.IsAutoApplied())
+	})
+
+	t.Run("when auto-applied", func(t *testing.T) {
+		run := unmarshal(t, true, nil)
+		assert.Nil(t, run.ConfirmedBy)
+		assert.True(t, run.IsAutoApplied())
+	})
+}
+
+func TestRun_Warnings(t *testing.T) {
+	t.Run("without target or replace addresses", func(t *testing.T) {
+		run := &Run{}
+		assert.Empty(t, run.Warnings())
+	})
+
+	t.Run("with target and replace addresses", func(t *testing.T) {
+		run := &Run{
+			TargetAddrs:  []string{"null_resource.a", "module.b"},
+			ReplaceAddrs: []string{"null_resource.c"},
+		}
+		assert.Equal(t, []string{
+			"Resource targeting is in effect, the run only considers null_resource.a, module.b and their dependencies",
+			"The run forces the replacement of null_resource.c",
+		}, run.Warnings())
+	})
+}
+
+func TestRunCreateOptions_Marshal(t *testing.T) {
+	marshal := func(t *testing.T, opts RunCreateOptions) string {
+		reqBody, err := serializeRequestBody(&opts)
+		require.NoError(t, err)
+		req, err := retryablehttp.NewRequest("POST", "url", reqBody)
+		require.NoError(t, err)
+		bodyBytes, err := req.BodyBytes()
+		require.NoError(t, err)
+		return string(bodyBytes)
+	}
+
+	t.Run("with only a workspace", func(t *testing.T) {
+		body := marshal(t, RunCreateOptions{
+			Workspace: &Workspace{ID: "ws-123"},
+		})
+
+		expectedBody := `{"data":{"type":"runs","relationships":{"workspace":{"data":{"type":"workspaces","id":"ws-123"}}}}}
+`
+		assert.Equal(t, expectedBody, body)
+	})
+
+	t.Run("with a configuration version", func(t *testing.T) {
+		body := marshal(t, RunCreateOptions{
+			ConfigurationVersion: &ConfigurationVersion{ID: "cv-123"},
+			Workspace:            &Workspace{ID: "ws-123"},
+		})
+
+		expectedBody := `{"data":{"type":"runs","relationships":{"configuration-version":{"data":{"type":"configuration-versions","id":"cv-123"}},"workspace":{"data":{"type":"workspaces","id":"ws-123"}}}}}
+`
+		assert.Equal(t, expectedBody, body)
+	})
+}
+
+func TestRunsTail(t *testing.T) {
+	runReads := 0
+
+	logs := map[string]string{
+		"/logs/plan":  "\x02Terraform v1.0.0\nPlan: 1 to add, 0 to change, 0 to destroy.\x03",
+		"/logs/apply": "\x02Terraform v1.0.0\nApply complete! Resources: 1 added, 0 changed, 0 destroyed.\x03",
+	}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/runs/run-123":
+			runReads++
+			status := RunPlanning
+			switch {
+			case runReads == 2:
+				status = RunPlanned
+			case runReads > 2:
+				status = RunApplied
+			}
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs","attributes":{"status":"`+string(status)+`"},`+
+				`"relationships":{"plan":{"data":{"id":"plan-123","type":"plans"}},"apply":{"data":{"id":"apply-123","type":"applies"}}}}}`))
+		case "/api/v2/plans/plan-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"plan-123","type":"plans","attributes":{"status":"finished","log-read-url":"http://`+r.Host+`/logs/plan"}}}`))
+		case "/api/v2/applies/apply-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"apply-123","type":"applies","attributes":{"status":"finished","log-read-url":"http://`+r.Host+`/logs/apply"}}}`))
+		case "/logs/plan", "/logs/apply":
+			offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+			assert.NoError(t, err)
+			limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+			assert.NoError(t, err)
+
+			content := logs[r.URL.Path]
+			if offset >= len(content) {
+				return
+			}
+			end := offset + limit
+			if end > len(content) {
+				end = len(content)
+			}
+			checkedWrite(t, w, []byte(content[offset:end]))
+		default:
+			assert.Fail(t, "invalid request URI", "URI", r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a valid run ID", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := client.Runs.Tail(ctx, "run-123", buf)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Terraform v1.0.0\nPlan: 1 to add, 0 to change, 0 to destroy."+
+			"Terraform v1.0.0\nApply complete! Resources: 1 added, 0 changed, 0 destroyed.", buf.String())
+	})
+
+	t.Run("with an invalid run ID", func(t *testing.T) {
+		err := client.Runs.Tail(ctx, badIdentifier, new(bytes.Buffer))
+		assert.EqualError(t, err, ErrInvalidRunID.Error())
+	})
+}
+
+func TestRunStatus_IsTerminal(t *testing.T) {
+	terminal := []RunStatus{RunApplied, RunCanceled, RunDiscarded, RunErrored,
+		RunPlannedAndFinished, RunPolicySoftFailed}
+	nonTerminal := []RunStatus{RunApplyQueued, RunApplying, RunConfirmed, RunCostEstimated,
+		RunCostEstimating, RunPending, RunPlanQueued, RunPlanned, RunPlanning,
+		RunPolicyChecked, RunPolicyChecking, RunPolicyOverride}
+
+	for _, s := range terminal {
+		assert.True(t, s.IsTerminal(), "expected %s to be terminal", s)
+	}
+	for _, s := range nonTerminal {
+		assert.False(t, s.IsTerminal(), "expected %s not to be terminal", s)
+	}
+}
+
+func TestRun_IsConfirmable(t *testing.T) {
+	assert.False(t, (&Run{Status: RunPlanned}).IsConfirmable())
+	assert.False(t, (&Run{Status: RunPolicyOverride, Actions: &RunActions{}}).IsConfirmable())
+	assert.True(t, (&Run{Status: RunPolicyChecked, Actions: &RunActions{IsConfirmable: true}}).IsConfirmable())
+}
+
+func TestRunsWaitForStart(t *testing.T) {
+	type state struct {
+		status   RunStatus
+		position int
+	}
+	var states []state
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/runs/run-123":
+			st := states[0]
+			if len(states) > 1 {
+				states = states[1:]
+			}
+			checkedWrite(t, w, []byte(fmt.Sprintf(
+				`{"data":{"id":"run-123","type":"runs","attributes":{"status":"%s","position-in-queue":%d}}}`,
+				st.status, st.position,
+			)))
+		default:
+			assert.Fail(t, "invalid request URI", "URI", r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("reporting the queue position", func(t *testing.T) {
+		states = []state{
+			{RunPending, 2},
+			{RunPending, 2},
+			{RunPending, 1},
+			{RunPlanQueued, 0},
+			{RunPlanning, 0},
+		}
+
+		var positions []int
+		r, err := client.Runs.WaitForStart(ctx, "run-123", RunWaitForStartOptions{
+			OnQueuePositionChange: func(position int) {
+				positions = append(positions, position)
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, RunPlanning, r.Status)
+		assert.Equal(t, []int{2, 1, 0}, positions)
+	})
+
+	t.Run("when the run is canceled before it starts", func(t *testing.T) {
+		states = []state{{RunCanceled, 0}}
+
+		r, err := client.Runs.WaitForStart(ctx, "run-123", RunWaitForStartOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, RunCanceled, r.Status)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		states = []state{{RunPending, 1}}
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		_, err := client.Runs.WaitForStart(ctx, "run-123", RunWaitForStartOptions{})
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("without a valid run ID", func(t *testing.T) {
+		r, err := client.Runs.WaitForStart(ctx, badIdentifier, RunWaitForStartOptions{})
+		assert.Nil(t, r)
+		assert.EqualError(t, err, ErrInvalidRunID.Error())
+	})
+}
+
+func TestRunsWaitForConfirmable(t *testing.T) {
+	type state struct {
+		status      RunStatus
+		confirmable bool
+	}
+	var states []state
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/runs/run-123":
+			st := states[0]
+			if len(states) > 1 {
+				states = states[1:]
+			}
+			checkedWrite(t, w, []byte(fmt.Sprintf(
+				`{"data":{"id":"run-123","type":"runs","attributes":{"status":"%s","actions":{"is-confirmable":%t}}}}`,
+				st.status, st.confirmable,
+			)))
+		default:
+			assert.Fail(t, "invalid request URI", "URI", r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("through the intermediate states", func(t *testing.T) {
+		states = []state{
+			{RunPlanning, false},
+			{RunCostEstimating, false},
+			{RunPolicyChecking, false},
+			{RunPolicyChecked, true},
+		}
+
+		r, err := client.Runs.WaitForConfirmable(ctx, "run-123")
+		require.NoError(t, err)
+		assert.Equal(t, RunPolicyChecked, r.Status)
+		assert.True(t, r.Actions.IsConfirmable)
+	})
+
+	t.Run("when the policy check soft fails", func(t *testing.T) {
+		states = []state{{RunPolicyChecking, false}, {RunPolicySoftFailed, false}}
+
+		r, err := client.Runs.WaitForConfirmable(ctx, "run-123")
+		require.NoError(t, err)
+		assert.Equal(t, RunPolicySoftFailed, r.Status)
+	})
+
+	t.Run("when the run awaits a policy override", func(t *testing.T) {
+		states = []state{{RunPolicyChecking, false}, {RunPolicyOverride, false}}
+
+		r, err := client.Runs.WaitForConfirmable(ctx, "run-123")
+		require.NoError(t, err)
+		assert.Equal(t, RunPolicyOverride, r.Status)
+	})
+
+	t.Run("when the run errors", func(t *testing.T) {
+		states = []state{{RunPlanning, false}, {RunErrored, false}}
+
+		r, err := client.Runs.WaitForConfirmable(ctx, "run-123")
+		require.NotNil(t, r)
+		assert.Equal(t, RunErrored, r.Status)
+		assert.True(t, errors.Is(err, ErrRunTerminated))
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		states = []state{{RunPlanning, false}}
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		_, err := client.Runs.WaitForConfirmable(ctx, "run-123")
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("without a valid run ID", func(t *testing.T) {
+		r, err := client.Runs.WaitForConfirmable(ctx, badIdentifier)
+		assert.Nil(t, r)
+		assert.EqualError(t, err, ErrInvalidRunID.Error())
+	})
+}
+
+func TestRunsCreateAndWait(t *testing.T) {
+	type state struct {
+		status      RunStatus
+		confirmable bool
+	}
+	var states []state
+	applied := false
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/runs":
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs","attributes":{"status":"pending"}}}`))
+		case "/api/v2/runs/run-123":
+			st := states[0]
+			if len(states) > 1 {
+				states = states[1:]
+			}
+			checkedWrite(t, w, []byte(fmt.Sprintf(
+				`{"data":{"id":"run-123","type":"runs","attributes":{"status":"%s","actions":{"is-confirmable":%t}}}}`,
+				st.status, st.confirmable,
+			)))
+		case "/api/v2/runs/run-123/actions/apply":
+			applied = true
+			states = []state{{RunApplying, false}, {RunApplied, false}}
+		default:
+			assert.Fail(t, "invalid request URI", "URI", r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+	options := RunCreateOptions{Workspace: &Workspace{ID: "ws-123"}}
+
+	t.Run("waiting for the plan", func(t *testing.T) {
+		states = []state{{RunPlanning, false}, {RunPlanned, false}, {RunPolicyChecking, false}, {RunPolicyChecked, true}}
+		applied = false
+
+		r, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, RunPolicyChecked, r.Status)
+		assert.False(t, applied)
+	})
+
+	t.Run("waiting for the plan of an auto-applied run", func(t *testing.T) {
+		states = []state{{RunPlanning, false}, {RunPlanned, false}, {RunApplyQueued, false}}
+		applied = false
+
+		r, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, RunApplyQueued, r.Status)
+		assert.False(t, applied)
+	})
+
+	t.Run("waiting for the plan when the policy check soft fails", func(t *testing.T) {
+		states = []state{{RunPolicyChecking, false}, {RunPolicySoftFailed, false}}
+
+		r, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, RunPolicySoftFailed, r.Status)
+	})
+
+	t.Run("waiting for the apply", func(t *testing.T) {
+		states = []state{{RunPlanned, false}, {RunCostEstimating, false}, {RunCostEstimated, true}}
+		applied = false
+
+		r, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{Stage: RunWaitApplied})
+		require.NoError(t, err)
+		assert.Equal(t, RunApplied, r.Status)
+		assert.True(t, applied)
+	})
+
+	t.Run("waiting for the apply of an auto-applied run", func(t *testing.T) {
+		states = []state{{RunPlanned, false}, {RunApplying, false}, {RunApplied, false}}
+		applied = false
+
+		r, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{Stage: RunWaitApplied})
+		require.NoError(t, err)
+		assert.Equal(t, RunApplied, r.Status)
+		assert.False(t, applied)
+	})
+
+	t.Run("waiting for the apply when the policy check soft fails", func(t *testing.T) {
+		states = []state{{RunPolicyChecking, false}, {RunPolicySoftFailed, false}}
+		applied = false
+
+		r, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{Stage: RunWaitApplied})
+		assert.True(t, errors.Is(err, ErrRunTerminated))
+		require.NotNil(t, r)
+		assert.Equal(t, RunPolicySoftFailed, r.Status)
+		assert.False(t, applied)
+	})
+
+	t.Run("when the run errors", func(t *testing.T) {
+		states = []state{{RunErrored, false}}
+
+		r, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{Stage: RunWaitApplied})
+		assert.True(t, errors.Is(err, ErrRunTerminated))
+		require.NotNil(t, r)
+		assert.Equal(t, RunErrored, r.Status)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		states = []state{{RunPlanning, false}}
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		_, err := client.Runs.CreateAndWait(ctx, options, RunWaitOptions{})
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
@@ -0,0 +1,49 @@
+package tfe
+
+import (
+	"context"
+	"os"
+)
+
+// SpeculativePlan starts a speculative plan of the Terraform configuration
+// in configDir against a workspace, as done to preview the changes of a pull
+// request. It creates a speculative configuration version, uploads the
+// configuration to it, waits for it to be ready, and then creates a
+// plan-only run using it. The run is returned as soon as it is created; use
+// Runs.WaitForStart or Runs.Read to follow it.
+func (c *Client) SpeculativePlan(ctx context.Context, workspaceID string, configDir string) (*Run, error) {
+	if !validStringID(&workspaceID) {
+		return nil, ErrInvalidWorkspaceID
+	}
+
+	// Check the configuration before creating a configuration version
+	// which could never be uploaded.
+	if file, err := os.Stat(configDir); err != nil || !file.Mode().IsDir() {
+		return nil, ErrMissingDirectory
+	}
+
+	// The run is created explicitly below, so don't let the upload queue
+	// one as well.
+	cv, err := c.ConfigurationVersions.Create(ctx, workspaceID, ConfigurationVersionCreateOptions{
+		AutoQueueRuns: Bool(false),
+		Speculative:   Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ConfigurationVersions.Upload(ctx, cv.UploadURL, configDir); err != nil {
+		return nil, err
+	}
+
+	cv, err = c.ConfigurationVersions.WaitForReady(ctx, cv.ID, ConfigurationVersionWaitOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Runs.Create(ctx, RunCreateOptions{
+		ConfigurationVersion: cv,
+		PlanOnly:             Bool(true),
+		Workspace:            &Workspace{ID: workspaceID},
+	})
+}
@@ -0,0 +1,98 @@
+package tfe
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SpeculativePlan(t *testing.T) {
+	var requests []string
+	var cvAttributes, runAttributes map[string]interface{}
+	var runRelationships map[string]interface{}
+	var uploaded int
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		var body struct {
+			Data struct {
+				Attributes    map[string]interface{} `json:"attributes"`
+				Relationships map[string]interface{} `json:"relationships"`
+			} `json:"data"`
+		}
+		b, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v2/workspaces/ws-1/configuration-versions":
+			assert.NoError(t, json.Unmarshal(b, &body))
+			cvAttributes = body.Data.Attributes
+
+			w.WriteHeader(http.StatusCreated)
+			checkedWrite(t, w, []byte(`{"data":{"id":"cv-1","type":"configuration-versions","attributes":{"status":"pending","speculative":true,"upload-url":"http://`+r.Host+`/upload/cv-1"}}}`))
+		case "PUT /upload/cv-1":
+			uploaded = len(b)
+		case "GET /api/v2/configuration-versions/cv-1":
+			checkedWrite(t, w, []byte(`{"data":{"id":"cv-1","type":"configuration-versions","attributes":{"status":"uploaded","speculative":true}}}`))
+		case "POST /api/v2/runs":
+			assert.NoError(t, json.Unmarshal(b, &body))
+			runAttributes = body.Data.Attributes
+			runRelationships = body.Data.Relationships
+
+			w.WriteHeader(http.StatusCreated)
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-1","type":"runs","attributes":{"plan-only":true,"status":"pending"}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a valid configuration", func(t *testing.T) {
+		r, err := client.SpeculativePlan(ctx, "ws-1", "test-fixtures/config-version")
+		require.NoError(t, err)
+		assert.Equal(t, "run-1", r.ID)
+		assert.True(t, r.PlanOnly)
+
+		assert.Equal(t, []string{
+			"POST /api/v2/workspaces/ws-1/configuration-versions",
+			"PUT /upload/cv-1",
+			"GET /api/v2/configuration-versions/cv-1",
+			"POST /api/v2/runs",
+		}, requests)
+
+		assert.Equal(t, map[string]interface{}{
+			"auto-queue-runs": false,
+			"speculative":     true,
+		}, cvAttributes)
+		assert.NotZero(t, uploaded)
+		assert.Equal(t, true, runAttributes["plan-only"])
+		assert.Equal(t, map[string]interface{}{
+			"data": map[string]interface{}{"id": "cv-1", "type": "configuration-versions"},
+		}, runRelationships["configuration-version"])
+		assert.Equal(t, map[string]interface{}{
+			"data": map[string]interface{}{"id": "ws-1", "type": "workspaces"},
+		}, runRelationships["workspace"])
+	})
+
+	t.Run("without a configuration directory", func(t *testing.T) {
+		requests = nil
+
+		r, err := client.SpeculativePlan(ctx, "ws-1", "test-fixtures/nonexisting")
+		assert.Nil(t, r)
+		assert.Equal(t, ErrMissingDirectory, err)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		r, err := client.SpeculativePlan(ctx, badIdentifier, "test-fixtures/config-version")
+		assert.Nil(t, r)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
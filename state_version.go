@@ -3,9 +3,12 @@ package tfe
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"time"
 )
 
@@ -21,6 +24,17 @@ type StateVersions interface {
 	// List all the state versions for a given workspace.
 	List(ctx context.Context, options StateVersionListOptions) (*StateVersionList, error)
 
+	// Count the state versions for a given workspace.
+	Count(ctx context.Context, options StateVersionListOptions) (int, error)
+
+	// ForEach calls fn for each state version for a given workspace, one
+	// page at a time.
+	ForEach(ctx context.Context, options StateVersionListOptions, fn func(*StateVersion) error) error
+
+	// History lists the most recent state versions of the given workspace,
+	// ordered by serial.
+	History(ctx context.Context, workspaceID string, limit int) ([]*StateVersion, error)
+
 	// Create a new state version for the given workspace.
 	Create(ctx context.Context, workspaceID string, options StateVersionCreateOptions) (*StateVersion, error)
 
@@ -56,6 +70,7 @@ type StateVersion struct {
 	ID           string    `jsonapi:"primary,state-versions"`
 	CreatedAt    time.Time `jsonapi:"attr,created-at,iso8601"`
 	DownloadURL  string    `jsonapi:"attr,hosted-state-download-url"`
+	UploadURL    string    `jsonapi:"attr,hosted-state-upload-url"`
 	Serial       int64     `jsonapi:"attr,serial"`
 	VCSCommitSHA string    `jsonapi:"attr,vcs-commit-sha"`
 	VCSCommitURL string    `jsonapi:"attr,vcs-commit-url"`
@@ -102,6 +117,95 @@ func (s *stateVersions) List(ctx context.Context, options StateVersionListOption
 	return svl, nil
 }
 
+// Count the state versions for a given workspace. It makes a single request
+// for one state version, and reads the total count of the pagination. The
+// pagination of the options is ignored.
+func (s *stateVersions) Count(ctx context.Context, options StateVersionListOptions) (int, error) {
+	options.ListOptions = countOptions
+
+	svl, err := s.List(ctx, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalCount(svl.Pagination)
+}
+
+// ForEach calls fn for each state version for a given workspace, newest
+// first, holding only one page of state versions at a time. It stops at the
+// first error returned by fn, which it returns, or when the context is done.
+// The pagination of the options sets the first page and the page size, which
+// defaults to 100.
+func (s *stateVersions) ForEach(ctx context.Context, options StateVersionListOptions, fn func(*StateVersion) error) error {
+	return forEachPage(ctx, options.ListOptions, func(lo ListOptions) (*Pagination, error) {
+		options.ListOptions = lo
+
+		svl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, sv := range svl.Items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(sv); err != nil {
+				return nil, err
+			}
+		}
+
+		return svl.Pagination, nil
+	})
+}
+
+// History lists the most recent state versions of the given workspace, up to
+// limit versions, ordered by increasing serial. A limit of zero or less lists
+// the complete history.
+func (s *stateVersions) History(ctx context.Context, workspaceID string, limit int) ([]*StateVersion, error) {
+	if !validStringID(&workspaceID) {
+		return nil, ErrInvalidWorkspaceID
+	}
+
+	ws, err := s.client.Workspaces.ReadByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if ws.Organization == nil {
+		return nil, fmt.Errorf("workspace %s does not have an organization", workspaceID)
+	}
+
+	options := StateVersionListOptions{
+		ListOptions:  ListOptions{PageSize: 100},
+		Workspace:    String(ws.Name),
+		Organization: String(ws.Organization.Name),
+	}
+
+	// State versions are listed from newest to oldest.
+	var svs []*StateVersion
+	for page := 1; page != 0 && (limit <= 0 || len(svs) < limit); {
+		options.PageNumber = page
+
+		svl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		svs = append(svs, svl.Items...)
+
+		page = 0
+		if svl.Pagination != nil {
+			page = svl.NextPage
+		}
+	}
+
+	sort.Slice(svs, func(i, j int) bool {
+		return svs[i].Serial < svs[j].Serial
+	})
+	if limit > 0 && len(svs) > limit {
+		svs = svs[len(svs)-limit:]
+	}
+
+	return svs, nil
+}
+
 // StateVersionCreateOptions represents the options for creating a state version.
 type StateVersionCreateOptions struct {
 	// Type is a public field utilized by JSON:API to
@@ -113,14 +217,21 @@ type StateVersionCreateOptions struct {
 	// The lineage of the state.
 	Lineage *string `jsonapi:"attr,lineage,omitempty"`
 
-	// The MD5 hash of the state version.
+	// The MD5 hash of the state version. It is computed from the state
+	// when omitted.
 	MD5 *string `jsonapi:"attr,md5"`
 
 	// The serial of the state.
 	Serial *int64 `jsonapi:"attr,serial"`
 
-	// The base64 encoded state.
-	State *string `jsonapi:"attr,state"`
+	// The base64 encoded state. Either State or RawState is required.
+	State *string `jsonapi:"attr,state,omitempty"`
+
+	// The raw state, used when State is omitted. States up to
+	// maxInlineStateSize bytes are sent base64 encoded with the request,
+	// while larger states are uploaded separately after creating the state
+	// version. This is not sent to the API as such.
+	RawState []byte
 
 	// Force can be set to skip certain validations. Wrong use
 	// of this flag can cause data loss, so USE WITH CAUTION!
@@ -130,14 +241,15 @@ type StateVersionCreateOptions struct {
 	Run *Run `jsonapi:"relation,run,omitempty"`
 }
 
+// maxInlineStateSize is the maximum size of a raw state sent with the request
+// creating a state version.
+const maxInlineStateSize = 1 << 20
+
 func (o StateVersionCreateOptions) valid() error {
-	if !validString(o.MD5) {
-		return errors.New("MD5 is required")
-	}
 	if o.Serial == nil {
 		return errors.New("serial is required")
 	}
-	if !validString(o.State) {
+	if !validString(o.State) && len(o.RawState) == 0 {
 		return errors.New("state is required")
 	}
 	return nil
@@ -152,6 +264,30 @@ func (s *stateVersions) Create(ctx context.Context, workspaceID string, options
 		return nil, err
 	}
 
+	// Compute the MD5 hash of the state if needed.
+	if options.MD5 == nil {
+		raw := options.RawState
+		if options.State != nil {
+			var err error
+			raw, err = base64.StdEncoding.DecodeString(*options.State)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for state: %v", err)
+			}
+		}
+		options.MD5 = String(fmt.Sprintf("%x", md5.Sum(raw)))
+	}
+
+	// Small raw states are sent inline, while large ones are uploaded once
+	// the state version is created.
+	var upload []byte
+	if options.State == nil {
+		if len(options.RawState) <= maxInlineStateSize {
+			options.State = String(base64.StdEncoding.EncodeToString(options.RawState))
+		} else {
+			upload = options.RawState
+		}
+	}
+
 	u := fmt.Sprintf("workspaces/%s/state-versions", url.QueryEscape(workspaceID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
@@ -164,9 +300,29 @@ func (s *stateVersions) Create(ctx context.Context, workspaceID string, options
 		return nil, err
 	}
 
+	if upload != nil {
+		if err := s.upload(ctx, sv.UploadURL, upload); err != nil {
+			return nil, err
+		}
+	}
+
 	return sv, nil
 }
 
+// upload uploads a raw state to the upload URL of a state version.
+func (s *stateVersions) upload(ctx context.Context, uploadURL string, state []byte) error {
+	if uploadURL == "" {
+		return errors.New("the state version does not contain an upload URL")
+	}
+
+	req, err := s.client.newRequest("PUT", uploadURL, bytes.NewBuffer(state))
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
 // StateVersionReadOptions represents the options for reading state version.
 type StateVersionReadOptions struct {
 	Include string `schema:"include"`
@@ -238,7 +394,7 @@ func (s *stateVersions) Download(ctx context.Context, url string) ([]byte, error
 	req.Header.Set("Accept", "application/json")
 
 	var buf bytes.Buffer
-	err = s.client.do(ctx, req, &buf)
+	err = s.client.download(ctx, req, &buf)
 	if err != nil {
 		return nil, err
 	}
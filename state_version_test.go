@@ -1,11 +1,14 @@
 package tfe
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"testing"
 	"time"
 
@@ -101,6 +104,112 @@ func TestStateVersionsList(t *testing.T) {
 	})
 }
 
+func TestStateVersionsHistory(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, wTestCleanup := createWorkspace(t, client, orgTest)
+	defer wTestCleanup()
+
+	for i := int64(0); i < 3; i++ {
+		_, svTestCleanup := createStateVersion(t, client, i, wTest)
+		defer svTestCleanup()
+	}
+
+	t.Run("with a limit", func(t *testing.T) {
+		svs, err := client.StateVersions.History(ctx, wTest.ID, 2)
+		require.NoError(t, err)
+		require.Len(t, svs, 2)
+		assert.Equal(t, int64(1), svs[0].Serial)
+		assert.Equal(t, int64(2), svs[1].Serial)
+	})
+
+	t.Run("without a limit", func(t *testing.T) {
+		svs, err := client.StateVersions.History(ctx, wTest.ID, 0)
+		require.NoError(t, err)
+		require.Len(t, svs, 3)
+		for i, sv := range svs {
+			assert.Equal(t, int64(i), sv.Serial)
+			assert.NotEmpty(t, sv.CreatedAt)
+		}
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		svs, err := client.StateVersions.History(ctx, badIdentifier, 0)
+		assert.Nil(t, svs)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestStateVersionsHistory_paging(t *testing.T) {
+	pages := map[string]string{
+		"1": `{"data":[` +
+			`{"id":"sv-4","type":"state-versions","attributes":{"serial":4},"relationships":{"run":{"data":{"id":"run-4","type":"runs"}}}},` +
+			`{"id":"sv-3","type":"state-versions","attributes":{"serial":3}}` +
+			`],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":4}}}`,
+		"2": `{"data":[` +
+			`{"id":"sv-2","type":"state-versions","attributes":{"serial":2}},` +
+			`{"id":"sv-1","type":"state-versions","attributes":{"serial":1}}` +
+			`],"meta":{"pagination":{"current-page":2,"total-pages":2,"total-count":4}}}`,
+	}
+	var requested []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"name":"dev"},`+
+				`"relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`))
+		case "/api/v2/workspaces/ws-orphan":
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-orphan","type":"workspaces","attributes":{"name":"dev"}}}`))
+		case "/api/v2/state-versions":
+			q := r.URL.Query()
+			assert.Equal(t, "acme", q.Get("filter[organization][name]"))
+			assert.Equal(t, "dev", q.Get("filter[workspace][name]"))
+			page := q.Get("page[number]")
+			requested = append(requested, page)
+			checkedWrite(t, w, []byte(pages[page]))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	serials := func(svs []*StateVersion) []int64 {
+		var serials []int64
+		for _, sv := range svs {
+			serials = append(serials, sv.Serial)
+		}
+		return serials
+	}
+
+	t.Run("stopping at the limit", func(t *testing.T) {
+		requested = nil
+
+		svs, err := client.StateVersions.History(context.Background(), "ws-123", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 4}, serials(svs))
+		assert.Equal(t, "run-4", svs[1].Run.ID)
+		assert.Equal(t, []string{"1"}, requested)
+	})
+
+	t.Run("listing all pages", func(t *testing.T) {
+		requested = nil
+
+		svs, err := client.StateVersions.History(context.Background(), "ws-123", 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, 3, 4}, serials(svs))
+		assert.Equal(t, []string{"1", "2"}, requested)
+	})
+
+	t.Run("without the organization of the workspace", func(t *testing.T) {
+		svs, err := client.StateVersions.History(context.Background(), "ws-orphan", 0)
+		assert.Nil(t, svs)
+		assert.EqualError(t, err, "workspace ws-orphan does not have an organization")
+	})
+}
+
 func TestStateVersionsCreate(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -226,12 +335,23 @@ func TestStateVersionsCreate(t *testing.T) {
 	})
 
 	t.Run("without md5 hash", func(t *testing.T) {
+		_, err := client.Workspaces.Lock(ctx, wTest.ID, WorkspaceLockOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
 		sv, err := client.StateVersions.Create(ctx, wTest.ID, StateVersionCreateOptions{
-			Serial: Int64(0),
+			Serial: Int64(3),
 			State:  String(base64.StdEncoding.EncodeToString(state)),
+			Force:  Bool(true),
 		})
-		assert.Nil(t, sv)
-		assert.EqualError(t, err, "MD5 is required")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), sv.Serial)
+
+		_, err = client.Workspaces.Unlock(ctx, wTest.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
 	})
 
 	t.Run("withous serial", func(t *testing.T) {
@@ -259,6 +379,64 @@ func TestStateVersionsCreate(t *testing.T) {
 	})
 }
 
+func TestStateVersionsCreateRawState(t *testing.T) {
+	var attributes map[string]interface{}
+	var uploaded []byte
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v2/workspaces/ws-123/state-versions":
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			attributes = body.Data.Attributes
+
+			w.WriteHeader(201)
+			checkedWrite(t, w, []byte(`{"data":{"id":"sv-123","type":"state-versions","attributes":{`+
+				`"hosted-state-upload-url":"http://`+r.Host+`/upload"}}}`))
+		case r.Method == "PUT" && r.URL.Path == "/upload":
+			var err error
+			uploaded, err = ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a small state", func(t *testing.T) {
+		attributes, uploaded = nil, nil
+		state := []byte(`{"version":4}`)
+
+		_, err := client.StateVersions.Create(ctx, "ws-123", StateVersionCreateOptions{
+			Serial:   Int64(1),
+			RawState: state,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(state), attributes["state"])
+		assert.Equal(t, fmt.Sprintf("%x", md5.Sum(state)), attributes["md5"])
+		assert.Nil(t, uploaded)
+	})
+
+	t.Run("with a large state", func(t *testing.T) {
+		attributes, uploaded = nil, nil
+		state := bytes.Repeat([]byte("x"), maxInlineStateSize+1)
+
+		_, err := client.StateVersions.Create(ctx, "ws-123", StateVersionCreateOptions{
+			Serial:   Int64(1),
+			RawState: state,
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, attributes, "state")
+		assert.Equal(t, fmt.Sprintf("%x", md5.Sum(state)), attributes["md5"])
+		assert.Equal(t, state, uploaded)
+	})
+}
+
 func TestStateVersionsRead(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
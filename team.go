@@ -24,11 +24,18 @@ type Teams interface {
 	// Read a team by its ID.
 	Read(ctx context.Context, teamID string) (*Team, error)
 
+	// ReadWithOptions reads a team by its ID using the options supplied.
+	ReadWithOptions(ctx context.Context, teamID string, options TeamReadOptions) (*Team, error)
+
 	// Update a team by its ID.
 	Update(ctx context.Context, teamID string, options TeamUpdateOptions) (*Team, error)
 
 	// Delete a team by its ID.
 	Delete(ctx context.Context, teamID string) error
+
+	// ListWithPolicyManagement lists all the teams of the given organization
+	// allowed to manage its policies and policy sets.
+	ListWithPolicyManagement(ctx context.Context, organization string) ([]*Team, error)
 }
 
 // teams implements Teams.
@@ -47,7 +54,7 @@ type Team struct {
 	ID                 string              `jsonapi:"primary,teams"`
 	Name               string              `jsonapi:"attr,name"`
 	OrganizationAccess *OrganizationAccess `jsonapi:"attr,organization-access"`
-	Visibility         string              `jsonapi:"attr,visibility"`
+	Visibility         TeamVisibility      `jsonapi:"attr,visibility"`
 	Permissions        *TeamPermissions    `jsonapi:"attr,permissions"`
 	UserCount          int                 `jsonapi:"attr,users-count"`
 
@@ -56,18 +63,32 @@ type Team struct {
 	OrganizationMemberships []*OrganizationMembership `jsonapi:"relation,organization-memberships"`
 }
 
+// TeamVisibility represents the visibility of a team.
+type TeamVisibility string
+
+// List of available team visibilities.
+const (
+	TeamVisibilitySecret       TeamVisibility = "secret"
+	TeamVisibilityOrganization TeamVisibility = "organization"
+)
+
+var validTeamVisibility = map[TeamVisibility]struct{}{
+	TeamVisibilitySecret:       {},
+	TeamVisibilityOrganization: {},
+}
+
 // OrganizationAccess represents the team's permissions on its organization
 type OrganizationAccess struct {
-	ManagePolicies        bool `jsonapi:"attr,manage-policies"`
-	ManagePolicyOverrides bool `jsonapi:"attr,manage-policy-overrides"`
-	ManageWorkspaces      bool `jsonapi:"attr,manage-workspaces"`
-	ManageVCSSettings     bool `jsonapi:"attr,manage-vcs-settings"`
+	ManagePolicies        bool `json:"manage-policies"`
+	ManagePolicyOverrides bool `json:"manage-policy-overrides"`
+	ManageWorkspaces      bool `json:"manage-workspaces"`
+	ManageVCSSettings     bool `json:"manage-vcs-settings"`
 }
 
 // TeamPermissions represents the current user's permissions on the team.
 type TeamPermissions struct {
-	CanDestroy          bool `jsonapi:"attr,can-destroy"`
-	CanUpdateMembership bool `jsonapi:"attr,can-update-membership"`
+	CanDestroy          bool `json:"can-destroy"`
+	CanUpdateMembership bool `json:"can-update-membership"`
 }
 
 // TeamListOptions represents the options for listing teams.
@@ -113,7 +134,7 @@ type TeamCreateOptions struct {
 	OrganizationAccess *OrganizationAccessOptions `jsonapi:"attr,organization-access,omitempty"`
 
 	// The team's visibility ("secret", "organization")
-	Visibility *string `jsonapi:"attr,visibility,omitempty"`
+	Visibility *TeamVisibility `jsonapi:"attr,visibility,omitempty"`
 }
 
 // OrganizationAccessOptions represents the organization access options of a team.
@@ -128,6 +149,16 @@ func (o TeamCreateOptions) valid() error {
 	if !validString(o.Name) {
 		return ErrRequiredName
 	}
+	return validateTeamVisibility(o.Visibility)
+}
+
+func validateTeamVisibility(v *TeamVisibility) error {
+	if v == nil {
+		return nil
+	}
+	if _, ok := validTeamVisibility[*v]; !ok {
+		return ErrInvalidTeamVisibility
+	}
 	return nil
 }
 
@@ -157,12 +188,23 @@ func (s *teams) Create(ctx context.Context, organization string, options TeamCre
 
 // Read a single team by its ID.
 func (s *teams) Read(ctx context.Context, teamID string) (*Team, error) {
+	return s.ReadWithOptions(ctx, teamID, TeamReadOptions{})
+}
+
+// TeamReadOptions represents the options for reading a team. Relations which
+// can be included are "users" and "organization-memberships".
+type TeamReadOptions struct {
+	Include string `schema:"include,omitempty"`
+}
+
+// ReadWithOptions reads a single team by its ID using the options supplied.
+func (s *teams) ReadWithOptions(ctx context.Context, teamID string, options TeamReadOptions) (*Team, error) {
 	if !validStringID(&teamID) {
 		return nil, errors.New("invalid value for team ID")
 	}
 
 	u := fmt.Sprintf("teams/%s", url.QueryEscape(teamID))
-	req, err := s.client.newRequest("GET", u, nil)
+	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +233,11 @@ type TeamUpdateOptions struct {
 	OrganizationAccess *OrganizationAccessOptions `jsonapi:"attr,organization-access,omitempty"`
 
 	// The team's visibility ("secret", "organization")
-	Visibility *string `jsonapi:"attr,visibility,omitempty"`
+	Visibility *TeamVisibility `jsonapi:"attr,visibility,omitempty"`
+}
+
+func (o TeamUpdateOptions) valid() error {
+	return validateTeamVisibility(o.Visibility)
 }
 
 // Update a team by its ID.
@@ -199,6 +245,9 @@ func (s *teams) Update(ctx context.Context, teamID string, options TeamUpdateOpt
 	if !validStringID(&teamID) {
 		return nil, errors.New("invalid value for team ID")
 	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
 
 	u := fmt.Sprintf("teams/%s", url.QueryEscape(teamID))
 	req, err := s.client.newRequest("PATCH", u, &options)
@@ -229,3 +278,35 @@ func (s *teams) Delete(ctx context.Context, teamID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// ListWithPolicyManagement lists all the teams of the given organization
+// whose organization access allows them to manage its policies and policy
+// sets.
+func (s *teams) ListWithPolicyManagement(ctx context.Context, organization string) ([]*Team, error) {
+	if !validStringID(&organization) {
+		return nil, ErrInvalidOrg
+	}
+
+	var teams []*Team
+	for page := 1; page != 0; {
+		tl, err := s.List(ctx, organization, TeamListOptions{
+			ListOptions: ListOptions{PageNumber: page, PageSize: 100},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tl.Items {
+			if t.OrganizationAccess != nil && t.OrganizationAccess.ManagePolicies {
+				teams = append(teams, t)
+			}
+		}
+
+		page = 0
+		if tl.Pagination != nil {
+			page = tl.NextPage
+		}
+	}
+
+	return teams, nil
+}
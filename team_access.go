@@ -19,6 +19,9 @@ type TeamAccesses interface {
 	// List all the team accesses for a given workspace.
 	List(ctx context.Context, options TeamAccessListOptions) (*TeamAccessList, error)
 
+	// ListForTeam lists all the team accesses for a given team.
+	ListForTeam(ctx context.Context, teamID string, options TeamAccessListForTeamOptions) (*TeamAccessList, error)
+
 	// Add team access for a workspace.
 	Add(ctx context.Context, options TeamAccessAddOptions) (*TeamAccess, error)
 
@@ -137,6 +140,41 @@ func (s *teamAccesses) List(ctx context.Context, options TeamAccessListOptions)
 	return tal, nil
 }
 
+// TeamAccessListForTeamOptions represents the options for listing the team
+// accesses of a team.
+type TeamAccessListForTeamOptions struct {
+	ListOptions
+}
+
+// ListForTeam lists all the team accesses for a given team, which holds the
+// workspaces the team has access to.
+func (s *teamAccesses) ListForTeam(ctx context.Context, teamID string, options TeamAccessListForTeamOptions) (*TeamAccessList, error) {
+	if !validStringID(&teamID) {
+		return nil, errors.New("invalid value for team ID")
+	}
+
+	o := struct {
+		TeamAccessListForTeamOptions
+		TeamID string `schema:"filter[team][id]"`
+	}{
+		TeamAccessListForTeamOptions: options,
+		TeamID:                       teamID,
+	}
+
+	req, err := s.client.newRequest("GET", "team-workspaces", &o)
+	if err != nil {
+		return nil, err
+	}
+
+	tal := &TeamAccessList{}
+	err = s.client.do(ctx, req, tal)
+	if err != nil {
+		return nil, err
+	}
+
+	return tal, nil
+}
+
 // TeamAccessAddOptions represents the options for adding team access.
 type TeamAccessAddOptions struct {
 	// Type is a public field utilized by JSON:API to
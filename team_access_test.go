@@ -75,6 +75,42 @@ func TestTeamAccessesList(t *testing.T) {
 	})
 }
 
+func TestTeamAccessesListForTeam(t *testing.T) {
+	skipIfFreeOnly(t)
+
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest1, wTest1Cleanup := createWorkspace(t, client, orgTest)
+	defer wTest1Cleanup()
+	wTest2, wTest2Cleanup := createWorkspace(t, client, orgTest)
+	defer wTest2Cleanup()
+
+	tmTest, tmTestCleanup := createTeam(t, client, orgTest)
+	defer tmTestCleanup()
+
+	taTest1, taTest1Cleanup := createTeamAccess(t, client, tmTest, wTest1, orgTest)
+	defer taTest1Cleanup()
+	taTest2, taTest2Cleanup := createTeamAccess(t, client, tmTest, wTest2, orgTest)
+	defer taTest2Cleanup()
+
+	t.Run("with valid options", func(t *testing.T) {
+		tal, err := client.TeamAccess.ListForTeam(ctx, tmTest.ID, TeamAccessListForTeamOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, tal.Items, taTest1)
+		assert.Contains(t, tal.Items, taTest2)
+	})
+
+	t.Run("without a valid team ID", func(t *testing.T) {
+		tal, err := client.TeamAccess.ListForTeam(ctx, badIdentifier, TeamAccessListForTeamOptions{})
+		assert.Nil(t, tal)
+		assert.EqualError(t, err, "invalid value for team ID")
+	})
+}
+
 func TestTeamAccessesAdd(t *testing.T) {
 	skipIfFreeOnly(t)
 
@@ -33,6 +33,10 @@ type TeamMembers interface {
 
 	// Remove multiple users from a team.
 	Remove(ctx context.Context, teamID string, options TeamMemberRemoveOptions) error
+
+	// Reconcile adds and removes users so the members of a team are exactly
+	// the users with the desired usernames.
+	Reconcile(ctx context.Context, teamID string, desiredUsernames []string) (added, removed int, err error)
 }
 
 // teamMembers implements TeamMembers.
@@ -40,6 +44,10 @@ type teamMembers struct {
 	client *Client
 }
 
+// teamMemberBatchSize is the maximum number of users added to or removed from
+// a team in a single request when reconciling its members.
+const teamMemberBatchSize = 100
+
 type teamMemberUser struct {
 	Username string `jsonapi:"primary,users"`
 }
@@ -249,3 +257,76 @@ func (s *teamMembers) Remove(ctx context.Context, teamID string, options TeamMem
 
 	return s.client.do(ctx, req, nil)
 }
+
+// Reconcile adds and removes users so the members of a team are exactly the
+// users with the desired usernames, which may be empty to remove every
+// member. It reads the current members once, then adds and removes only the
+// users which differ, many at a time, so a large roster takes few requests
+// on top of the rate limiting applied by the client. Users are added before
+// any is removed, so a team never has fewer members than needed along the
+// way.
+//
+// When an error occurs, the counts of the users added and removed so far are
+// returned with it.
+func (s *teamMembers) Reconcile(ctx context.Context, teamID string, desiredUsernames []string) (added, removed int, err error) {
+	if !validStringID(&teamID) {
+		return 0, 0, errors.New("invalid value for team ID")
+	}
+
+	want := make(map[string]bool, len(desiredUsernames))
+	for _, name := range desiredUsernames {
+		if name == "" {
+			return 0, 0, errors.New("invalid value for usernames")
+		}
+		want[name] = true
+	}
+
+	users, err := s.ListUsers(ctx, teamID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	have := make(map[string]bool, len(users))
+	var toRemove []string
+	for _, u := range users {
+		have[u.Username] = true
+		if !want[u.Username] {
+			toRemove = append(toRemove, u.Username)
+		}
+	}
+
+	var toAdd []string
+	for _, name := range desiredUsernames {
+		if !have[name] {
+			toAdd = append(toAdd, name)
+			// Skip duplicates of the desired usernames.
+			have[name] = true
+		}
+	}
+
+	for len(toAdd) > 0 {
+		batch := toAdd
+		if len(batch) > teamMemberBatchSize {
+			batch = batch[:teamMemberBatchSize]
+		}
+		if err := s.Add(ctx, teamID, TeamMemberAddOptions{Usernames: batch}); err != nil {
+			return added, removed, err
+		}
+		added += len(batch)
+		toAdd = toAdd[len(batch):]
+	}
+
+	for len(toRemove) > 0 {
+		batch := toRemove
+		if len(batch) > teamMemberBatchSize {
+			batch = batch[:teamMemberBatchSize]
+		}
+		if err := s.Remove(ctx, teamID, TeamMemberRemoveOptions{Usernames: batch}); err != nil {
+			return added, removed, err
+		}
+		removed += len(batch)
+		toRemove = toRemove[len(batch):]
+	}
+
+	return added, removed, nil
+}
@@ -2,6 +2,11 @@ package tfe
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -271,3 +276,104 @@ func TestTeamMembersRemoveByOrganizationMemberships(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestTeamMembers_relationshipPayload(t *testing.T) {
+	var method, path, body string
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		method, path, body = r.Method, r.URL.Path, string(b)
+		w.WriteHeader(204)
+	})
+
+	ctx := context.Background()
+
+	t.Run("adding by usernames", func(t *testing.T) {
+		err := client.TeamMembers.Add(ctx, "team-123", TeamMemberAddOptions{
+			Usernames: []string{"alice"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "POST", method)
+		assert.Equal(t, "/api/v2/teams/team-123/relationships/users", path)
+		assert.JSONEq(t, `{"data":[{"type":"users","id":"alice"}]}`, body)
+	})
+
+	t.Run("removing by organization membership ids", func(t *testing.T) {
+		err := client.TeamMembers.Remove(ctx, "team-123", TeamMemberRemoveOptions{
+			OrganizationMembershipIDs: []string{"ou-123"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "DELETE", method)
+		assert.Equal(t, "/api/v2/teams/team-123/relationships/organization-memberships", path)
+		assert.JSONEq(t, `{"data":[{"type":"organization-memberships","id":"ou-123"}]}`, body)
+	})
+}
+
+func TestTeamMembersReconcile(t *testing.T) {
+	var members []string
+	var requests []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/teams/team-123":
+			assert.Equal(t, "users", r.URL.Query().Get("include"))
+			var data, included []string
+			for _, name := range members {
+				data = append(data, fmt.Sprintf(`{"type":"users","id":%q}`, name))
+				included = append(included, fmt.Sprintf(`{"type":"users","id":%q,"attributes":{"username":%q}}`, name, name))
+			}
+			fmt.Fprintf(w, `{"data":{"id":"team-123","type":"teams","relationships":{"users":{"data":[%s]}}},"included":[%s]}`,
+				strings.Join(data, ","), strings.Join(included, ","))
+		case r.URL.Path == "/api/v2/teams/team-123/relationships/users":
+			var doc struct {
+				Data []struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(b, &doc))
+			requests = append(requests, fmt.Sprintf("%s %d", r.Method, len(doc.Data)))
+			w.WriteHeader(204)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with differing members", func(t *testing.T) {
+		members = []string{"alice", "bob"}
+		requests = nil
+
+		desired := []string{"alice", "carol", "carol"}
+		for i := 0; i < 150; i++ {
+			desired = append(desired, fmt.Sprintf("user%d", i))
+		}
+
+		added, removed, err := client.TeamMembers.Reconcile(ctx, "team-123", desired)
+		require.NoError(t, err)
+		assert.Equal(t, 151, added)
+		assert.Equal(t, 1, removed)
+		assert.Equal(t, []string{"POST 100", "POST 51", "DELETE 1"}, requests)
+	})
+
+	t.Run("with matching members", func(t *testing.T) {
+		members = []string{"alice", "bob"}
+		requests = nil
+
+		added, removed, err := client.TeamMembers.Reconcile(ctx, "team-123", []string{"bob", "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, added)
+		assert.Equal(t, 0, removed)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("with invalid options", func(t *testing.T) {
+		_, _, err := client.TeamMembers.Reconcile(ctx, badIdentifier, nil)
+		assert.EqualError(t, err, "invalid value for team ID")
+
+		_, _, err = client.TeamMembers.Reconcile(ctx, "team-123", []string{""})
+		assert.EqualError(t, err, "invalid value for usernames")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
@@ -96,6 +97,15 @@ func TestTeamsCreate(t *testing.T) {
 		assert.EqualError(t, err, ErrRequiredName.Error())
 	})
 
+	t.Run("when options has an invalid visibility", func(t *testing.T) {
+		tm, err := client.Teams.Create(ctx, "foo", TeamCreateOptions{
+			Name:       String("foo"),
+			Visibility: TeamVisibilityValue("public"),
+		})
+		assert.Nil(t, tm)
+		assert.EqualError(t, err, ErrInvalidTeamVisibility.Error())
+	})
+
 	t.Run("when options has an invalid organization", func(t *testing.T) {
 		tm, err := client.Teams.Create(ctx, badIdentifier, TeamCreateOptions{
 			Name: String("foo"),
@@ -136,7 +146,7 @@ func TestTeamsRead(t *testing.T) {
 		assert.Equal(t, tmTest, tm)
 
 		t.Run("visibility is returned", func(t *testing.T) {
-			assert.Equal(t, "secret", tm.Visibility)
+			assert.Equal(t, TeamVisibilitySecret, tm.Visibility)
 		})
 
 		t.Run("permissions are properly decoded", func(t *testing.T) {
@@ -161,6 +171,42 @@ func TestTeamsRead(t *testing.T) {
 	})
 }
 
+func TestTeamsReadWithOptions(t *testing.T) {
+	skipIfFreeOnly(t)
+
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	tmTest, tmTestCleanup := createTeam(t, client, orgTest)
+	defer tmTestCleanup()
+
+	memTest, memTestCleanup := createOrganizationMembership(t, client, orgTest)
+	defer memTestCleanup()
+
+	err := client.TeamMembers.Add(ctx, tmTest.ID, TeamMemberAddOptions{
+		OrganizationMembershipIDs: []string{memTest.ID},
+	})
+	require.NoError(t, err)
+
+	t.Run("with organization memberships included", func(t *testing.T) {
+		tm, err := client.Teams.ReadWithOptions(ctx, tmTest.ID, TeamReadOptions{
+			Include: "organization-memberships",
+		})
+		require.NoError(t, err)
+		require.Len(t, tm.OrganizationMemberships, 1)
+		assert.Equal(t, memTest.ID, tm.OrganizationMemberships[0].ID)
+	})
+
+	t.Run("without a valid team ID", func(t *testing.T) {
+		tm, err := client.Teams.ReadWithOptions(ctx, badIdentifier, TeamReadOptions{})
+		assert.Nil(t, tm)
+		assert.EqualError(t, err, "invalid value for team ID")
+	})
+}
+
 func TestTeamsUpdate(t *testing.T) {
 	skipIfFreeOnly(t)
 
@@ -181,7 +227,7 @@ func TestTeamsUpdate(t *testing.T) {
 				ManageVCSSettings:     Bool(true),
 				ManagePolicyOverrides: Bool(true),
 			},
-			Visibility: String("organization"),
+			Visibility: TeamVisibilityValue(TeamVisibilityOrganization),
 		}
 
 		tm, err := client.Teams.Update(ctx, tmTest.ID, options)
@@ -227,6 +273,14 @@ func TestTeamsUpdate(t *testing.T) {
 		assert.Nil(t, tm)
 		assert.EqualError(t, err, "invalid value for team ID")
 	})
+
+	t.Run("with an invalid visibility", func(t *testing.T) {
+		tm, err := client.Teams.Update(ctx, tmTest.ID, TeamUpdateOptions{
+			Visibility: TeamVisibilityValue("public"),
+		})
+		assert.Nil(t, tm)
+		assert.EqualError(t, err, ErrInvalidTeamVisibility.Error())
+	})
 }
 
 func TestTeamsDelete(t *testing.T) {
@@ -295,7 +349,7 @@ func TestTeam_Unmarshal(t *testing.T) {
 func TestTeamCreateOptions_Marshal(t *testing.T) {
 	opts := TeamCreateOptions{
 		Name:       String("team name"),
-		Visibility: String("organization"),
+		Visibility: TeamVisibilityValue(TeamVisibilityOrganization),
 		OrganizationAccess: &OrganizationAccessOptions{
 			ManagePolicies: Bool(true),
 		},
@@ -312,3 +366,45 @@ func TestTeamCreateOptions_Marshal(t *testing.T) {
 `
 	assert.Equal(t, expectedBody, string(bodyBytes))
 }
+
+func TestTeamsListWithPolicyManagement(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/organizations/acme/teams" {
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+			return
+		}
+
+		switch r.URL.Query().Get("page[number]") {
+		case "1":
+			checkedWrite(t, w, []byte(`{"data":[
+				{"id":"team-owners","type":"teams","attributes":{"name":"owners","organization-access":{"manage-policies":true}}},
+				{"id":"team-devs","type":"teams","attributes":{"name":"devs","organization-access":{"manage-workspaces":true}}}
+			],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":3}}}`))
+		case "2":
+			checkedWrite(t, w, []byte(`{"data":[
+				{"id":"team-security","type":"teams","attributes":{"name":"security","organization-access":{"manage-policies":true,"manage-policy-overrides":true}}}
+			],"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":3}}}`))
+		default:
+			assert.Fail(t, "invalid page", r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with teams on several pages", func(t *testing.T) {
+		teams, err := client.Teams.ListWithPolicyManagement(ctx, "acme")
+		require.NoError(t, err)
+
+		var names []string
+		for _, tm := range teams {
+			names = append(names, tm.Name)
+		}
+		assert.Equal(t, []string{"owners", "security"}, names)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		teams, err := client.Teams.ListWithPolicyManagement(ctx, badIdentifier)
+		assert.Nil(t, teams)
+		assert.EqualError(t, err, ErrInvalidOrg.Error())
+	})
+}
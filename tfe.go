@@ -1,19 +1,25 @@
 package tfe
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/schema"
@@ -37,14 +43,119 @@ const (
 	DefaultBasePath = "/api/v2/"
 	// PingEndpoint is a no-op API endpoint used to configure the rate limiter
 	PingEndpoint = "ping"
+
+	// maxDecodeErrorBodySize is the maximum number of response body bytes
+	// added to a decode error.
+	maxDecodeErrorBodySize = 512
 )
 
 // Query schema encoder, caches structs, and safe for sharing
 var encoder = schema.NewEncoder()
 
+// contextKey is the type of the keys of values stored in a context.
+type contextKey int
+
+// Keys of the values stored in a context.
+const (
+	headersContextKey contextKey = iota
+	progressContextKey
+	noRetryContextKey
+)
+
+// ContextWithHeaders returns a copy of ctx holding additional headers which
+// are added to every request made with the returned context, on top of the
+// headers configured for the client. The Authorization, Accept and
+// Content-Type headers are set by the client and cannot be overridden.
+func ContextWithHeaders(ctx context.Context, headers http.Header) context.Context {
+	return context.WithValue(ctx, headersContextKey, headers)
+}
+
+// setContextHeaders adds the headers stored in ctx to the given headers,
+// leaving the headers set by the client untouched.
+func setContextHeaders(ctx context.Context, headers http.Header) {
+	ctxHeaders, ok := ctx.Value(headersContextKey).(http.Header)
+	if !ok {
+		return
+	}
+
+	for k, v := range ctxHeaders {
+		switch http.CanonicalHeaderKey(k) {
+		case "Authorization", "Accept", "Content-Type":
+			continue
+		}
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+}
+
+// withoutRetries returns a copy of ctx with which requests are not retried,
+// except when rate limited, as the server did not process them then. This is
+// used for requests which are not safe to repeat.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryContextKey, true)
+}
+
+// retryable returns true if a request made with ctx may be retried after
+// the given response, which is nil when the request failed without one.
+func retryable(ctx context.Context, resp *http.Response) bool {
+	noRetry, _ := ctx.Value(noRetryContextKey).(bool)
+	return !noRetry || (resp != nil && resp.StatusCode == 429)
+}
+
+// ProgressFunc reports the progress of a download with the number of bytes
+// read so far and the total number of bytes, which is -1 when unknown.
+type ProgressFunc func(bytesRead, totalBytes int64)
+
+// ContextWithProgress returns a copy of ctx holding a function which is
+// called to report the progress of the downloads made with the returned
+// context, such as state versions, plan files and policies.
+func ContextWithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressContextKey, fn)
+}
+
+// progressWriter reports the number of bytes written through it to a
+// ProgressFunc, and stops writing as soon as its context is done.
+type progressWriter struct {
+	ctx   context.Context
+	w     io.Writer
+	fn    ProgressFunc
+	read  int64
+	total int64
+}
+
+// withProgress wraps w in a progressWriter when ctx holds a ProgressFunc.
+func withProgress(ctx context.Context, w io.Writer, total int64) io.Writer {
+	fn, ok := ctx.Value(progressContextKey).(ProgressFunc)
+	if !ok || fn == nil {
+		return w
+	}
+	return &progressWriter{ctx: ctx, w: w, fn: fn, total: total}
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	if err := p.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := p.w.Write(b)
+	p.read += int64(n)
+	p.fn(p.read, p.total)
+
+	return n, err
+}
+
 // RetryLogHook allows a function to run before each retry.
 type RetryLogHook func(attemptNum int, resp *http.Response)
 
+// RetryPolicy decides whether a request should be retried after the given
+// attempt (starting at 1), and how long to wait before doing so. Either resp
+// or err is set, depending on whether a response was received.
+type RetryPolicy func(req *http.Request, resp *http.Response, err error, attempt int) (retry bool, delay time.Duration)
+
+// DryRunFunc is invoked in dry-run mode with each request that is not sent to
+// the server, along with the request body. It may return the response to use
+// in place of the one from the server, or nil to use the default response.
+type DryRunFunc func(req *http.Request, body []byte) *http.Response
+
 // Config provides configuration details to the API client.
 type Config struct {
 	// The address of the Terraform Enterprise API.
@@ -56,14 +167,72 @@ type Config struct {
 	// API token used to access the Terraform Enterprise API.
 	Token string
 
-	// Headers that will be added to every request.
+	// Headers that will be added to every request. The Authorization, Accept
+	// and Content-Type headers are set by the client and take precedence.
+	// Use ContextWithHeaders to add headers to individual requests.
 	Headers http.Header
 
 	// A custom HTTP client to use.
 	HTTPClient *http.Client
 
+	// InsecureSkipVerify disables verification of the server's TLS
+	// certificate chain and host name by the default HTTP client. It is
+	// ignored when a custom HTTPClient is given.
+	//
+	// WARNING: this makes the client vulnerable to man-in-the-middle attacks
+	// and must never be used against a production instance. It is only
+	// intended for development instances using a self-signed certificate.
+	InsecureSkipVerify bool
+
 	// RetryLogHook is invoked each time a request is retried.
 	RetryLogHook RetryLogHook
+
+	// RetryPolicy replaces the default retry behavior, which retries rate
+	// limited requests (and server errors when enabled) with a backoff. The
+	// RetryLogHook is not invoked when a RetryPolicy is set.
+	RetryPolicy RetryPolicy
+
+	// RetryMax is the maximum number of times a request is retried. Zero
+	// keeps the default of 30 retries.
+	RetryMax int
+
+	// RetryServerErrors also retries unexpected errors and requests that
+	// failed with a server error, like the RetryServerErrors method.
+	RetryServerErrors bool
+
+	// RateLimit caps the number of requests sent per second. Zero only
+	// applies the rate limit reported by the server.
+	RateLimit float64
+
+	// SourceName and SourceURL are recorded on the workspaces created by the
+	// client, unless set in the create options, so the UI shows the tool that
+	// created them. SourceName defaults to the User-Agent header when it is
+	// set to something else than the default of the package.
+	SourceName string
+	SourceURL  string
+
+	// IncludeBodyOnDecodeError adds the first bytes of the response body to
+	// the errors returned when a response cannot be decoded. As the body may
+	// hold sensitive data, only enable this when debugging.
+	IncludeBodyOnDecodeError bool
+
+	// CacheWorkspaceIDs makes WorkspaceID cache the workspace IDs it
+	// resolves in memory, for the lifetime of the client.
+	CacheWorkspaceIDs bool
+
+	// DryRun makes the client only send GET requests, so automation can be
+	// tested without changing anything. Any other request is answered with a
+	// successful response echoing the request body back, or with no content
+	// when the request has no body.
+	DryRun bool
+
+	// DryRunFunc is invoked with each request that is not sent in dry-run
+	// mode, to log it or to provide its response.
+	DryRunFunc DryRunFunc
+
+	// Logger reports the method, path and body of each request that is not
+	// sent in dry-run mode.
+	Logger Logger
 }
 
 // DefaultConfig returns a default config structure.
@@ -96,8 +265,19 @@ type Client struct {
 	http              *retryablehttp.Client
 	limiter           *rate.Limiter
 	retryLogHook      RetryLogHook
+	retryPolicy       RetryPolicy
+	includeBody       bool
 	retryServerErrors bool
-	remoteAPIVersion  string
+	maxRateLimit      float64
+	sourceName        string
+	sourceURL         string
+	dryRun            bool
+	dryRunFunc        DryRunFunc
+	logger            Logger
+	workspaceIDs      *workspaceIDCache
+
+	remoteAPIVersionMu sync.RWMutex
+	remoteAPIVersion   string
 
 	Admin                      Admin
 	AgentPools                 AgentPools
@@ -105,12 +285,15 @@ type Client struct {
 	Applies                    Applies
 	ConfigurationVersions      ConfigurationVersions
 	CostEstimates              CostEstimates
+	DataRetentionPolicies      DataRetentionPolicies
 	Events                     Events
+	GPGKeys                    GPGKeys
 	NotificationConfigurations NotificationConfigurations
 	OAuthClients               OAuthClients
 	OAuthTokens                OAuthTokens
 	Organizations              Organizations
 	OrganizationMemberships    OrganizationMemberships
+	OrganizationTags           OrganizationTags
 	OrganizationTokens         OrganizationTokens
 	Plans                      Plans
 	PlanExports                PlanExports
@@ -120,6 +303,7 @@ type Client struct {
 	PolicySetVersions          PolicySetVersions
 	PolicySets                 PolicySets
 	RegistryModules            RegistryModules
+	RegistryProviders          RegistryProviders
 	Runs                       Runs
 	RunTriggers                RunTriggers
 	SSHKeys                    SSHKeys
@@ -174,16 +358,51 @@ func NewClient(cfg *Config) (*Client, error) {
 		}
 		if cfg.HTTPClient != nil {
 			config.HTTPClient = cfg.HTTPClient
+		} else if cfg.InsecureSkipVerify {
+			config.InsecureSkipVerify = true
 		}
 		if cfg.RetryLogHook != nil {
 			config.RetryLogHook = cfg.RetryLogHook
 		}
+		if cfg.RetryPolicy != nil {
+			config.RetryPolicy = cfg.RetryPolicy
+		}
+		if cfg.RetryMax > 0 {
+			config.RetryMax = cfg.RetryMax
+		}
+		if cfg.RetryServerErrors {
+			config.RetryServerErrors = true
+		}
+		if cfg.RateLimit > 0 {
+			config.RateLimit = cfg.RateLimit
+		}
+		if cfg.SourceName != "" {
+			config.SourceName = cfg.SourceName
+		}
+		if cfg.SourceURL != "" {
+			config.SourceURL = cfg.SourceURL
+		}
+		if cfg.IncludeBodyOnDecodeError {
+			config.IncludeBodyOnDecodeError = true
+		}
+		if cfg.CacheWorkspaceIDs {
+			config.CacheWorkspaceIDs = true
+		}
+		if cfg.DryRun {
+			config.DryRun = true
+		}
+		if cfg.DryRunFunc != nil {
+			config.DryRunFunc = cfg.DryRunFunc
+		}
+		if cfg.Logger != nil {
+			config.Logger = cfg.Logger
+		}
 	}
 
 	// Parse the address to make sure its a valid URL.
-	baseURL, err := url.Parse(config.Address)
+	baseURL, err := parseAddress(config.Address)
 	if err != nil {
-		return nil, fmt.Errorf("invalid address: %v", err)
+		return nil, err
 	}
 
 	baseURL.Path = config.BasePath
@@ -193,7 +412,21 @@ func NewClient(cfg *Config) (*Client, error) {
 
 	// This value must be provided by the user.
 	if config.Token == "" {
-		return nil, fmt.Errorf("missing API token")
+		return nil, ErrMissingToken
+	}
+
+	// Name the workspaces created by the client after its custom user agent.
+	if config.SourceName == "" {
+		if ua := config.Headers.Get("User-Agent"); ua != userAgent {
+			config.SourceName = ua
+		}
+	}
+
+	// Disable TLS verification of the default HTTP client when asked to.
+	if config.InsecureSkipVerify {
+		if transport, ok := config.HTTPClient.Transport.(*http.Transport); ok {
+			transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		}
 	}
 
 	// Create the client.
@@ -202,6 +435,20 @@ func NewClient(cfg *Config) (*Client, error) {
 		token:        config.Token,
 		headers:      config.Headers,
 		retryLogHook: config.RetryLogHook,
+		retryPolicy:  config.RetryPolicy,
+		includeBody:  config.IncludeBodyOnDecodeError,
+		dryRun:       config.DryRun,
+		dryRunFunc:   config.DryRunFunc,
+		logger:       config.Logger,
+
+		retryServerErrors: config.RetryServerErrors,
+		maxRateLimit:      config.RateLimit,
+		sourceName:        config.SourceName,
+		sourceURL:         config.SourceURL,
+	}
+
+	if config.CacheWorkspaceIDs {
+		client.workspaceIDs = &workspaceIDCache{ids: make(map[string]string)}
 	}
 
 	client.http = &retryablehttp.Client{
@@ -213,8 +460,11 @@ func NewClient(cfg *Config) (*Client, error) {
 		RetryWaitMax: 400 * time.Millisecond,
 		RetryMax:     30,
 	}
+	if config.RetryMax > 0 {
+		client.http.RetryMax = config.RetryMax
+	}
 
-	meta, err := client.getRawAPIMetadata()
+	meta, err := client.getRawAPIMetadata(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -242,12 +492,15 @@ func NewClient(cfg *Config) (*Client, error) {
 	client.Applies = &applies{client: client}
 	client.ConfigurationVersions = &configurationVersions{client: client}
 	client.CostEstimates = &costEstimates{client: client}
+	client.DataRetentionPolicies = &dataRetentionPolicies{client: client}
 	client.Events = &events{client: client}
+	client.GPGKeys = &gpgKeys{client: client}
 	client.NotificationConfigurations = &notificationConfigurations{client: client}
 	client.OAuthClients = &oAuthClients{client: client}
 	client.OAuthTokens = &oAuthTokens{client: client}
 	client.Organizations = &organizations{client: client}
 	client.OrganizationMemberships = &organizationMemberships{client: client}
+	client.OrganizationTags = &organizationTags{client: client}
 	client.OrganizationTokens = &organizationTokens{client: client}
 	client.Plans = &plans{client: client}
 	client.PlanExports = &planExports{client: client}
@@ -257,6 +510,7 @@ func NewClient(cfg *Config) (*Client, error) {
 	client.PolicySetVersions = &policySetVersions{client: client}
 	client.PolicySets = &policySets{client: client}
 	client.RegistryModules = &registryModules{client: client}
+	client.RegistryProviders = &registryProviders{client: client}
 	client.Runs = &runs{client: client}
 	client.RunTriggers = &runTriggers{client: client}
 	client.SSHKeys = &sshKeys{client: client}
@@ -283,7 +537,9 @@ func NewClient(cfg *Config) (*Client, error) {
 // A Terraform Cloud or Enterprise API server returns its API version in an
 // HTTP header field in all responses. The NewClient function saves the
 // version number returned in its initial setup request and RemoteAPIVersion
-// returns that cached value.
+// returns that cached value. The cached value doesn't expire, use
+// RefreshRemoteAPIVersion to pick up a new version after the server has been
+// upgraded.
 //
 // The API protocol calls for this string to be a dotted-decimal version number
 // like 2.3.0, where the first number indicates the API major version while the
@@ -295,18 +551,106 @@ func NewClient(cfg *Config) (*Client, error) {
 // information. In that case, this function returns an empty string as the
 // version.
 func (c *Client) RemoteAPIVersion() string {
+	c.remoteAPIVersionMu.RLock()
+	defer c.remoteAPIVersionMu.RUnlock()
 	return c.remoteAPIVersion
 }
 
+// RefreshRemoteAPIVersion retrieves the server's declared API version again,
+// replacing the cached value returned by RemoteAPIVersion, and returns it.
+// Long-lived clients can use it to detect the features of a server that has
+// been upgraded since the client was created.
+func (c *Client) RefreshRemoteAPIVersion(ctx context.Context) (string, error) {
+	meta, err := c.getRawAPIMetadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.remoteAPIVersionMu.Lock()
+	defer c.remoteAPIVersionMu.Unlock()
+	c.remoteAPIVersion = meta.APIVersion
+
+	return meta.APIVersion, nil
+}
+
 // SetFakeRemoteAPIVersion allows setting a given string as the client's remoteAPIVersion,
 // overriding the value pulled from the API header during client initialization.
 //
 // This is intended for use in tests, when you may want to configure your TFE client to
 // return something different than the actual API version in order to test error handling.
 func (c *Client) SetFakeRemoteAPIVersion(fakeAPIVersion string) {
+	c.remoteAPIVersionMu.Lock()
+	defer c.remoteAPIVersionMu.Unlock()
 	c.remoteAPIVersion = fakeAPIVersion
 }
 
+// WorkspaceID resolves the ID of a workspace by its organization and name.
+// When the client is configured with CacheWorkspaceIDs, resolved IDs are
+// cached and forgotten again once the workspace is not found, or is deleted
+// or renamed through this client.
+func (c *Client) WorkspaceID(ctx context.Context, organization, name string) (string, error) {
+	if id, ok := c.workspaceIDs.get(organization, name); ok {
+		return id, nil
+	}
+
+	w, err := c.Workspaces.Read(ctx, organization, name)
+	if err != nil {
+		return "", err
+	}
+
+	c.workspaceIDs.set(organization, name, w.ID)
+
+	return w.ID, nil
+}
+
+// workspaceIDCache caches workspace IDs by organization and workspace name.
+// A nil cache caches nothing.
+type workspaceIDCache struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+func (c *workspaceIDCache) get(organization, name string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	id, ok := c.ids[organization+"/"+name]
+	return id, ok
+}
+
+func (c *workspaceIDCache) set(organization, name, id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids[organization+"/"+name] = id
+}
+
+func (c *workspaceIDCache) forget(organization, name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.ids, organization+"/"+name)
+}
+
+func (c *workspaceIDCache) forgetID(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, cached := range c.ids {
+		if cached == id {
+			delete(c.ids, key)
+		}
+	}
+}
+
 // RetryServerErrors configures the retry HTTP check to also retry
 // unexpected errors or requests that failed with a server error.
 func (c *Client) RetryServerErrors(retry bool) {
@@ -319,6 +663,10 @@ func (c *Client) retryHTTPCheck(ctx context.Context, resp *http.Response, err er
 	if ctx.Err() != nil {
 		return false, ctx.Err()
 	}
+	// Requests are retried by send when a custom retry policy is set.
+	if c.retryPolicy != nil || !retryable(ctx, resp) {
+		return false, err
+	}
 	if err != nil {
 		return c.retryServerErrors, err
 	}
@@ -337,14 +685,37 @@ func (c *Client) retryHTTPBackoff(min, max time.Duration, attemptNum int, resp *
 
 	// Use the rate limit backoff function when we are rate limited.
 	if resp != nil && resp.StatusCode == 429 {
-		return rateLimitBackoff(min, max, attemptNum, resp)
+		return capToDeadline(resp, rateLimitBackoff(min, max, attemptNum, resp))
 	}
 
 	// Set custom duration's when we experience a service interruption.
 	min = 700 * time.Millisecond
 	max = 900 * time.Millisecond
 
-	return retryablehttp.LinearJitterBackoff(min, max, attemptNum, resp)
+	return capToDeadline(resp, retryablehttp.LinearJitterBackoff(min, max, attemptNum, resp))
+}
+
+// capToDeadline caps the time to wait before retrying the request of resp to
+// the time left before the deadline of its context. The retryable HTTP client
+// doesn't watch the context while waiting, so without it a long rate limit
+// reset would keep the request going past its deadline.
+func capToDeadline(resp *http.Response, wait time.Duration) time.Duration {
+	if resp == nil || resp.Request == nil {
+		return wait
+	}
+
+	deadline, ok := resp.Request.Context().Deadline()
+	if !ok {
+		return wait
+	}
+
+	if left := time.Until(deadline); left < wait {
+		if left < 0 {
+			return 0
+		}
+		return left
+	}
+	return wait
 }
 
 // rateLimitBackoff provides a callback for Client.Backoff which will use the
@@ -387,7 +758,7 @@ type rawAPIMetadata struct {
 	RateLimit string
 }
 
-func (c *Client) getRawAPIMetadata() (rawAPIMetadata, error) {
+func (c *Client) getRawAPIMetadata(ctx context.Context) (rawAPIMetadata, error) {
 	var meta rawAPIMetadata
 
 	// Create a new request.
@@ -395,7 +766,7 @@ func (c *Client) getRawAPIMetadata() (rawAPIMetadata, error) {
 	if err != nil {
 		return meta, err
 	}
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return meta, err
 	}
@@ -439,10 +810,37 @@ func (c *Client) configureLimiter(rawLimit string) {
 		}
 	}
 
+	// Never exceed the rate limit configured for the client.
+	if c.maxRateLimit > 0 && rate.Limit(c.maxRateLimit) < limit {
+		limit = rate.Limit(c.maxRateLimit)
+		if burst < 1 {
+			burst = 1
+		}
+	}
+
 	// Create a new limiter using the calculated values.
 	c.limiter = rate.NewLimiter(limit, burst)
 }
 
+// Do sends a request to an API endpoint that isn't wrapped by this package
+// yet. The path is resolved relative to the base API path, and body and out
+// are handled as for the request and response of any wrapped endpoint: body
+// is JSONAPI encoded (or added as query parameters for a GET request), and
+// the response is JSONAPI decoded into out unless out is nil or implements
+// io.Writer, in which case the raw response body is written to it.
+//
+// Do is an escape hatch and is not covered by any compatibility guarantees:
+// its signature and behavior may change in any release. Prefer the wrapped
+// endpoints whenever they are available.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	req, err := c.newRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, out)
+}
+
 // newRequest creates an API request with proper headers and serialization.
 //
 // A relative URL path can be provided, in which case it is resolved relative to the baseURL
@@ -576,46 +974,262 @@ func serializeRequestBody(v interface{}) (interface{}, error) {
 // The provided ctx must be non-nil. If it is canceled or times out, ctx.Err()
 // will be returned.
 func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface{}) error {
-	// Wait will block until the limiter can obtain a new token
-	// or returns an error if the given context is canceled.
-	if err := c.limiter.Wait(ctx); err != nil {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Return here if decoding the response isn't needed.
+	if v == nil {
+		return nil
+	}
+
+	// If v implements io.Writer, write the raw response body.
+	if w, ok := v.(io.Writer); ok {
+		_, err = io.Copy(w, resp.Body)
+		return err
+	}
+
+	// Read the whole body first if it needs to be added to decode errors.
+	var body []byte
+	reader := io.Reader(resp.Body)
+	if c.includeBody {
+		if body, err = ioutil.ReadAll(resp.Body); err != nil {
+			return err
+		}
+		reader = bytes.NewReader(body)
+	}
+
+	if err := unmarshalResponse(reader, v); err != nil {
+		if len(body) > maxDecodeErrorBodySize {
+			body = body[:maxDecodeErrorBodySize]
+		}
+		return &DecodeError{StatusCode: resp.StatusCode, Body: string(body), Err: err}
+	}
+
+	return nil
+}
+
+// download sends an API request and writes the raw response body to w.
+//
+// Unlike do, it makes sure the server didn't respond with an error document
+// in place of the expected content: an HTML page or a JSON:API error document
+// is returned as an *APIError and nothing is written to w.
+//
+// The progress of the download is reported to the ProgressFunc stored in ctx,
+// if any.
+func (c *Client) download(ctx context.Context, req *retryablehttp.Request, w io.Writer) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	// Add the context to the request.
+	w = withProgress(ctx, w, resp.ContentLength)
+
+	contentType := resp.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "text/html"):
+		return &APIError{StatusCode: resp.StatusCode, ContentType: contentType}
+	case strings.HasPrefix(contentType, "application/vnd.api+json"):
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		errPayload := &errorsPayload{}
+		if err := json.Unmarshal(body, errPayload); err == nil && len(errPayload.Errors) > 0 {
+			return &APIError{
+				StatusCode:  resp.StatusCode,
+				ContentType: contentType,
+				Errors:      errPayload.Errors,
+			}
+		}
+
+		_, err = w.Write(body)
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// send sends an API request and returns the API response, after checking its
+// status code. The caller is responsible for closing the response body.
+func (c *Client) send(ctx context.Context, req *retryablehttp.Request) (*http.Response, error) {
+	// Add the context to the request, along with its headers.
 	req = req.WithContext(ctx)
+	setContextHeaders(ctx, req.Header)
+
+	// Skip anything but reads in dry-run mode.
+	if c.dryRun && req.Method != "GET" {
+		return c.dryRunResponse(req)
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		// Wait will block until the limiter can obtain a new token
+		// or returns an error if the given context is canceled.
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		// Execute the request.
+		resp, err = c.http.Do(req)
+		if c.retryPolicy == nil || !retryable(ctx, resp) {
+			break
+		}
+
+		retry, delay := c.retryPolicy(req.Request, resp, err, attempt)
+		if !retry {
+			break
+		}
+
+		// We're going to retry, so consume any response to reuse the
+		// connection.
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
 
-	// Execute the request and check the response.
-	resp, err := c.http.Do(req)
+	// Check the response.
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, ctx.Err()
 		default:
-			return err
+			return nil, err
 		}
 	}
-	defer resp.Body.Close()
 
 	// Basic response checking.
 	if err := checkResponseCode(resp); err != nil {
-		return err
+		resp.Body.Close()
+		return nil, err
 	}
 
-	// Return here if decoding the response isn't needed.
-	if v == nil {
+	return resp, nil
+}
+
+// dryRunResponse returns the response to a request that is not sent in
+// dry-run mode.
+func (c *Client) dryRunResponse(req *retryablehttp.Request) (*http.Response, error) {
+	body, err := req.BodyBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.logger != nil {
+		c.logger.Printf("[DEBUG] dry run: skipping %s %s %s", req.Method, req.URL.Path, body)
+	}
+
+	if c.dryRunFunc != nil {
+		if resp := c.dryRunFunc(req.Request, body); resp != nil {
+			if resp.Request == nil {
+				resp.Request = req.Request
+			}
+			if err := checkResponseCode(resp); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		}
+	}
+
+	resp := &http.Response{
+		Status:        "204 No Content",
+		StatusCode:    http.StatusNoContent,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          ioutil.NopCloser(bytes.NewReader(nil)),
+		ContentLength: 0,
+		Request:       req.Request,
+	}
+	if len(body) > 0 && req.Header.Get("Content-Type") == "application/vnd.api+json" {
+		resp.Status = "200 OK"
+		resp.StatusCode = http.StatusOK
+		resp.Header.Set("Content-Type", "application/vnd.api+json")
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+	}
+
+	return resp, nil
+}
+
+// rawResource holds the parts of a JSON:API resource object the jsonapi
+// package is unable to decode, such as lists of objects as attributes,
+// relations to resources of different types and resource links.
+type rawResource struct {
+	Attributes    map[string]json.RawMessage `json:"attributes"`
+	Relationships map[string]json.RawMessage `json:"relationships"`
+	Links         map[string]interface{}     `json:"links"`
+}
+
+// rawResourceUnmarshaler is implemented by resources needing parts of their
+// resource object which the jsonapi package doesn't decode.
+type rawResourceUnmarshaler interface {
+	unmarshalRawResource(r *rawResource) error
+}
+
+// unmarshalRawResources passes the raw resource objects of the primary data
+// of a JSON:API document to the models, when they implement
+// rawResourceUnmarshaler. For a document holding a list of resources the
+// models are expected in the same order as the resources. Included resources
+// are not passed on.
+func unmarshalRawResources(body []byte, many bool, models ...interface{}) error {
+	if len(models) == 0 {
+		return nil
+	}
+	if _, ok := models[0].(rawResourceUnmarshaler); !ok {
 		return nil
 	}
 
-	// If v implements io.Writer, write the raw response body.
-	if w, ok := v.(io.Writer); ok {
-		_, err = io.Copy(w, resp.Body)
-		return err
+	var resources []*rawResource
+	if many {
+		var doc struct {
+			Data []*rawResource `json:"data"`
+		}
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return err
+		}
+		resources = doc.Data
+	} else {
+		var doc struct {
+			Data *rawResource `json:"data"`
+		}
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return err
+		}
+		resources = []*rawResource{doc.Data}
+	}
+
+	if len(resources) != len(models) {
+		return fmt.Errorf("expected %d resources, got %d", len(models), len(resources))
+	}
+	for i, model := range models {
+		if resources[i] == nil {
+			continue
+		}
+		u := model.(rawResourceUnmarshaler)
+		if err := u.unmarshalRawResource(resources[i]); err != nil {
+			return err
+		}
 	}
 
-	return unmarshalResponse(resp.Body, v)
+	return nil
 }
 
 func unmarshalResponse(responseBody io.Reader, model interface{}) error {
@@ -631,10 +1245,17 @@ func unmarshalResponse(responseBody io.Reader, model interface{}) error {
 	items := dst.FieldByName("Items")
 	pagination := dst.FieldByName("Pagination")
 
+	// Create a temporary buffer and copy all the read data into it.
+	body := bytes.NewBuffer(nil)
+	reader := io.TeeReader(responseBody, body)
+
 	// Unmarshal a single value if v does not contain the
 	// Items and Pagination struct fields.
 	if !items.IsValid() || !pagination.IsValid() {
-		return jsonapi.UnmarshalPayload(responseBody, model)
+		if err := jsonapi.UnmarshalPayload(reader, model); err != nil {
+			return err
+		}
+		return unmarshalRawResources(body.Bytes(), false, model)
 	}
 
 	// Return an error if v.Items is not a slice.
@@ -642,10 +1263,6 @@ func unmarshalResponse(responseBody io.Reader, model interface{}) error {
 		return fmt.Errorf("v.Items must be a slice")
 	}
 
-	// Create a temporary buffer and copy all the read data into it.
-	body := bytes.NewBuffer(nil)
-	reader := io.TeeReader(responseBody, body)
-
 	// Unmarshal as a list of values as v.Items is a slice.
 	raw, err := jsonapi.UnmarshalManyPayload(reader, items.Type().Elem())
 	if err != nil {
@@ -664,6 +1281,10 @@ func unmarshalResponse(responseBody io.Reader, model interface{}) error {
 	// Pointer-swap the result.
 	items.Set(result)
 
+	if err := unmarshalRawResources(body.Bytes(), true, raw...); err != nil {
+		return err
+	}
+
 	// As we are getting a list of values, we need to decode
 	// the pagination details out of the response body.
 	p, err := parsePagination(body)
@@ -696,6 +1317,50 @@ type Pagination struct {
 	TotalCount   int `json:"total-count"`
 }
 
+// countOptions are the list options used to count the items of a list, with
+// a single request fetching as few items as possible.
+var countOptions = ListOptions{PageNumber: 1, PageSize: 1}
+
+// totalCount returns the total number of items of a list, as reported by the
+// pagination of one of its pages. Responses without pagination metadata
+// decode to a zero current page and cannot be counted.
+func totalCount(p *Pagination) (int, error) {
+	if p == nil || p.CurrentPage == 0 {
+		return 0, errors.New("list is not paginated, unable to count its items")
+	}
+	return p.TotalCount, nil
+}
+
+// forEachPage calls list with the options of each page of a list, from the
+// page of the options to the last one, stopping at the first error or when
+// the context is done. Pages hold 100 items unless the options set a size.
+func forEachPage(ctx context.Context, options ListOptions, list func(ListOptions) (*Pagination, error)) error {
+	if options.PageNumber == 0 {
+		options.PageNumber = 1
+	}
+	if options.PageSize == 0 {
+		options.PageSize = 100
+	}
+
+	for options.PageNumber != 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		p, err := list(options)
+		if err != nil {
+			return err
+		}
+
+		options.PageNumber = 0
+		if p != nil {
+			options.PageNumber = p.NextPage
+		}
+	}
+
+	return nil
+}
+
 func parsePagination(body io.Reader) (*Pagination, error) {
 	var raw struct {
 		Meta struct {
@@ -734,40 +1399,118 @@ func checkResponseCode(r *http.Response) error {
 	}
 
 	// Decode the error payload.
-	errPayload := &jsonapi.ErrorsPayload{}
+	errPayload := &errorsPayload{}
 	err := json.NewDecoder(r.Body).Decode(errPayload)
 	if err != nil || len(errPayload.Errors) == 0 {
-		return fmt.Errorf(r.Status)
+		return &statusError{StatusCode: r.StatusCode, Status: r.Status}
 	}
 
-	// Parse and format the errors.
-	var errs []string
-	for _, e := range errPayload.Errors {
-		if e.Detail == "" {
-			errs = append(errs, e.Title)
-		} else {
-			errs = append(errs, fmt.Sprintf("%s\n\n%s", e.Title, e.Detail))
-		}
+	return &APIError{
+		StatusCode:  r.StatusCode,
+		ContentType: r.Header.Get("Content-Type"),
+		Errors:      errPayload.Errors,
 	}
+}
 
-	return fmt.Errorf(strings.Join(errs, "\n"))
+// statusError is returned for an error response without an error payload.
+type statusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *statusError) Error() string {
+	return e.Status
+}
+
+// errorsPayload represents a JSON:API error document.
+type errorsPayload struct {
+	Errors []*ErrorObject `json:"errors"`
 }
 
 func packContents(path string) (*bytes.Buffer, error) {
-	body := bytes.NewBuffer(nil)
+	body, err := Pack(path, PackOptions{FollowSymlinks: true})
+	if err != nil {
+		return bytes.NewBuffer(nil), err
+	}
+	return bytes.NewBuffer(body), nil
+}
 
-	file, err := os.Stat(path)
+// PackOptions represents the options for packing a directory.
+type PackOptions struct {
+	// Whether to include the targets of symlinks pointing outside of the
+	// directory. When false, such symlinks are omitted.
+	FollowSymlinks bool
+
+	// A path prepended to the name of every file in the tarball.
+	Prefix string
+}
+
+// Pack creates the gzip compressed tarball of a directory expected by the
+// configuration version and policy set version upload endpoints. Files
+// matching the rules of a .terraformignore file in the directory are left
+// out.
+func Pack(dir string, options PackOptions) ([]byte, error) {
+	file, err := os.Stat(dir)
 	if err != nil {
-		return body, err
+		return nil, err
 	}
 	if !file.Mode().IsDir() {
-		return body, ErrMissingDirectory
+		return nil, ErrMissingDirectory
+	}
+
+	body := bytes.NewBuffer(nil)
+	if _, err := slug.Pack(dir, body, options.FollowSymlinks); err != nil {
+		return nil, err
 	}
 
-	_, err = slug.Pack(path, body, true)
+	if options.Prefix == "" {
+		return body.Bytes(), nil
+	}
+
+	return prefixTarball(body, options.Prefix)
+}
+
+// prefixTarball rewrites a gzip compressed tarball, prepending the prefix
+// to the name of every file.
+func prefixTarball(r io.Reader, prefix string) ([]byte, error) {
+	zr, err := gzip.NewReader(r)
 	if err != nil {
-		return body, err
+		return nil, err
+	}
+	tr := tar.NewReader(zr)
+
+	body := bytes.NewBuffer(nil)
+	zw := gzip.NewWriter(body)
+	tw := tar.NewWriter(zw)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		header.Name = path.Join(prefix, header.Name)
+		if header.Typeflag == tar.TypeDir {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
 	}
 
-	return body, nil
+	return body.Bytes(), nil
 }
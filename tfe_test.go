@@ -1,13 +1,21 @@
 package tfe
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -88,6 +96,62 @@ func TestClient_newClient(t *testing.T) {
 	})
 }
 
+func TestClient_RefreshRemoteAPIVersion(t *testing.T) {
+	apiVersion := "2.4"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("TFP-API-Version", apiVersion)
+		w.WriteHeader(204)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "abcd1234", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+	assert.Equal(t, "2.4", client.RemoteAPIVersion())
+
+	// Upgrade the server.
+	apiVersion = "2.5"
+	assert.Equal(t, "2.4", client.RemoteAPIVersion())
+
+	version, err := client.RefreshRemoteAPIVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", version)
+	assert.Equal(t, "2.5", client.RemoteAPIVersion())
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.RefreshRemoteAPIVersion(ctx)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, "2.5", client.RemoteAPIVersion())
+	})
+}
+
+func TestClient_insecureSkipVerify(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204) // We query the configured ping URL which should return a 204.
+	}))
+	defer ts.Close()
+
+	t.Run("fails to verify a self-signed certificate by default", func(t *testing.T) {
+		_, err := NewClient(&Config{
+			Address: ts.URL,
+			Token:   "abcd1234",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("skips verification when enabled", func(t *testing.T) {
+		_, err := NewClient(&Config{
+			Address:            ts.URL,
+			Token:              "abcd1234",
+			InsecureSkipVerify: true,
+		})
+		assert.NoError(t, err)
+	})
+}
+
 func TestClient_defaultConfig(t *testing.T) {
 	t.Run("with no environment variables", func(t *testing.T) {
 		defer setupEnvVars("", "")()
@@ -173,6 +237,46 @@ func TestClient_headers(t *testing.T) {
 	}
 }
 
+func TestClient_contextHeaders(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ping" {
+			w.WriteHeader(204)
+			return
+		}
+
+		assert.Equal(t, "application/vnd.api+json", r.Header.Get("Accept"))
+		assert.Equal(t, "Bearer dummy-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "foobar", r.Header.Get("My-Custom-Header"))
+		assert.Equal(t, "abc123", r.Header.Get("X-Api-Gateway-Key"))
+		assert.Equal(t, "trace-1", r.Header.Get("X-Trace-Id"))
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		Headers:    make(http.Header),
+		HTTPClient: ts.Client(),
+	}
+	cfg.Headers.Set("My-Custom-Header", "foobar")
+	cfg.Headers.Set("X-Api-Gateway-Key", "abc123")
+
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+
+	headers := make(http.Header)
+	headers.Set("X-Trace-Id", "trace-1")
+
+	// These should not override the headers set by the client.
+	headers.Set("Authorization", "bad-token")
+	headers.Set("Accept", "text/html")
+
+	ctx := ContextWithHeaders(context.Background(), headers)
+
+	err = client.Runs.Apply(ctx, "run-123456789", RunApplyOptions{})
+	assert.NoError(t, err)
+}
+
 func TestClient_userAgent(t *testing.T) {
 	testedCalls := 0
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -404,6 +508,404 @@ type tfeAPITimestamps struct {
 	QueuedAt time.Time `jsonapi:"attr,queued-at,rfc3339"`
 }
 
+func TestClient_download(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/binary":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			checkedWrite(t, w, []byte("plan bytes"))
+		case "/api/v2/html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			checkedWrite(t, w, []byte("<html>Oops</html>"))
+		case "/api/v2/jsonapi-error":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			checkedWrite(t, w, []byte(`{"errors":[{"status":"200","title":"plan not finished","detail":"try again later"}]}`))
+		case "/api/v2/jsonapi-document":
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			checkedWrite(t, w, []byte(`{"data":{"id":"1","type":"things"}}`))
+		}
+	})
+
+	download := func(path string) ([]byte, error) {
+		req, err := client.newRequest("GET", path, nil)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		err = client.download(context.Background(), req, &buf)
+		return buf.Bytes(), err
+	}
+
+	t.Run("with binary content", func(t *testing.T) {
+		body, err := download("binary")
+		require.NoError(t, err)
+		assert.Equal(t, "plan bytes", string(body))
+	})
+
+	t.Run("with an HTML error page", func(t *testing.T) {
+		body, err := download("html")
+		assert.Empty(t, body)
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, 200, apiErr.StatusCode)
+		assert.Equal(t, "text/html; charset=utf-8", apiErr.ContentType)
+		assert.Empty(t, apiErr.Errors)
+	})
+
+	t.Run("with a JSON:API error document", func(t *testing.T) {
+		body, err := download("jsonapi-error")
+		assert.Empty(t, body)
+
+		var apiErr *APIError
+		require.True(t, errors.As(err, &apiErr))
+		require.Len(t, apiErr.Errors, 1)
+		assert.Equal(t, "plan not finished", apiErr.Errors[0].Title)
+		assert.Equal(t, "try again later", apiErr.Errors[0].Detail)
+		assert.EqualError(t, err, "plan not finished\n\ntry again later")
+	})
+
+	t.Run("with a JSON:API document", func(t *testing.T) {
+		body, err := download("jsonapi-document")
+		require.NoError(t, err)
+		assert.Equal(t, `{"data":{"id":"1","type":"things"}}`, string(body))
+	})
+}
+
+func TestClient_downloadProgress(t *testing.T) {
+	content := bytes.Repeat([]byte("state"), 20000)
+	block := make(chan struct{})
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/state":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			checkedWrite(t, w, content)
+		case "/api/v2/stalled":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			checkedWrite(t, w, content[:1000])
+			w.(http.Flusher).Flush()
+			<-block
+		}
+	})
+	defer close(block)
+
+	t.Run("reporting the progress", func(t *testing.T) {
+		var read, total int64
+		ctx := ContextWithProgress(context.Background(), func(bytesRead, totalBytes int64) {
+			assert.True(t, bytesRead > read)
+			read, total = bytesRead, totalBytes
+		})
+
+		req, err := client.newRequest("GET", "state", nil)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, client.download(ctx, req, &buf))
+		assert.Equal(t, content, buf.Bytes())
+		assert.Equal(t, int64(len(content)), read)
+		assert.Equal(t, int64(len(content)), total)
+	})
+
+	t.Run("when the context is canceled mid-stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var read int64
+		ctx = ContextWithProgress(ctx, func(bytesRead, totalBytes int64) {
+			read = bytesRead
+			cancel()
+		})
+
+		req, err := client.newRequest("GET", "stalled", nil)
+		require.NoError(t, err)
+
+		err = client.download(ctx, req, ioutil.Discard)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.True(t, read > 0 && read < int64(len(content)))
+	})
+}
+
+func TestClient_apiError(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(422)
+		checkedWrite(t, w, []byte(`{"errors":[
+			{"status":"422","title":"invalid attribute","detail":"Name is required","source":{"pointer":"/data/attributes/name"}},
+			{"status":"422","title":"invalid attribute","detail":"Name is too short","source":{"pointer":"/data/attributes/name"}},
+			{"status":"422","title":"invalid relationship","detail":"Workspace not found","code":"not-found","source":{"pointer":"/data/relationships/workspace"}},
+			{"status":"422","title":"invalid request"}
+		]}`))
+	})
+
+	_, err := client.Teams.Create(context.Background(), "foo", TeamCreateOptions{Name: String("bar")})
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 422, apiErr.StatusCode)
+	require.Len(t, apiErr.Errors, 4)
+	assert.Equal(t, "422", apiErr.Errors[2].Status)
+	assert.Equal(t, "not-found", apiErr.Errors[2].Code)
+	assert.Equal(t, "/data/relationships/workspace", apiErr.Errors[2].Source.Pointer)
+	assert.Nil(t, apiErr.Errors[3].Source)
+
+	assert.Equal(t, map[string]string{
+		"name":      "Name is required; Name is too short",
+		"workspace": "Workspace not found",
+	}, apiErr.FieldErrors())
+
+	assert.EqualError(t, err, "invalid attribute\n\nName is required\n"+
+		"invalid attribute\n\nName is too short\n"+
+		"invalid relationship\n\nWorkspace not found\n"+
+		"invalid request")
+}
+
+func TestClient_decodeError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ping" {
+			w.WriteHeader(204)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs","attributes":{"has-changes":"yes"}}}`))
+	}))
+	defer ts.Close()
+
+	decode := func(cfg *Config) error {
+		client, err := NewClient(cfg)
+		require.NoError(t, err)
+
+		req, err := client.newRequest("GET", "runs/run-123", nil)
+		require.NoError(t, err)
+
+		return client.do(context.Background(), req, &Run{})
+	}
+
+	t.Run("without the response body", func(t *testing.T) {
+		err := decode(&Config{
+			Address:    ts.URL,
+			Token:      "dummy-token",
+			HTTPClient: ts.Client(),
+		})
+
+		var decodeErr *DecodeError
+		require.True(t, errors.As(err, &decodeErr))
+		assert.Equal(t, 200, decodeErr.StatusCode)
+		assert.Empty(t, decodeErr.Body)
+		assert.Error(t, decodeErr.Err)
+	})
+
+	t.Run("with the response body", func(t *testing.T) {
+		err := decode(&Config{
+			Address:                  ts.URL,
+			Token:                    "dummy-token",
+			HTTPClient:               ts.Client(),
+			IncludeBodyOnDecodeError: true,
+		})
+
+		var decodeErr *DecodeError
+		require.True(t, errors.As(err, &decodeErr))
+		assert.Equal(t, 200, decodeErr.StatusCode)
+		assert.Contains(t, decodeErr.Body, `"has-changes":"yes"`)
+		assert.Contains(t, err.Error(), `"has-changes":"yes"`)
+	})
+}
+
+func TestClient_retryPolicy(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ping" {
+			w.WriteHeader(204)
+			return
+		}
+		requests++
+		if requests < 3 {
+			w.WriteHeader(503)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs"}}`))
+	}))
+	defer ts.Close()
+
+	var attempts []int
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+		RetryPolicy: func(req *http.Request, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+			attempts = append(attempts, attempt)
+			return err == nil && resp.StatusCode == 503, time.Millisecond
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("retries until the policy gives up", func(t *testing.T) {
+		r, err := client.Runs.Read(context.Background(), "run-123")
+		require.NoError(t, err)
+		assert.Equal(t, "run-123", r.ID)
+		assert.Equal(t, 3, requests)
+		assert.Equal(t, []int{1, 2, 3}, attempts)
+	})
+
+	t.Run("returns the last response when not retrying", func(t *testing.T) {
+		requests, attempts = 0, nil
+		client.retryPolicy = func(req *http.Request, resp *http.Response, err error, attempt int) (bool, time.Duration) {
+			attempts = append(attempts, attempt)
+			return false, 0
+		}
+		_, err := client.Runs.Read(context.Background(), "run-123")
+		assert.Error(t, err)
+		assert.Equal(t, 1, requests)
+		assert.Equal(t, []int{1}, attempts)
+	})
+}
+
+func TestClient_pollingWhileRateLimited(t *testing.T) {
+	var reads int
+	var reset string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			reads++
+			if reads <= 3 || reset == "30" {
+				w.Header().Set("X-RateLimit-Reset", reset)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			locked := reads <= 5
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"locked":`+strconv.FormatBool(locked)+`}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	t.Run("when rate limited a few times", func(t *testing.T) {
+		reads, reset = 0, "0.05"
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := client.Workspaces.WaitForUnlock(ctx, "ws-123", 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, 6, reads)
+	})
+
+	t.Run("when the rate limit outlasts the deadline", func(t *testing.T) {
+		reads, reset = 0, "30"
+
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := client.Workspaces.WaitForUnlock(ctx, "ws-123", 10*time.Millisecond)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.True(t, time.Since(start) < 2*time.Second, "polling took %s", time.Since(start))
+	})
+}
+
+func TestClient_WorkspaceID(t *testing.T) {
+	reads := 0
+	exists := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/ping":
+			w.WriteHeader(204)
+		case "/api/v2/organizations/foo/workspaces/bar":
+			reads++
+			if !exists {
+				w.WriteHeader(404)
+				return
+			}
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"name":"bar"}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	t.Run("without caching", func(t *testing.T) {
+		reads, exists = 0, true
+		client, err := NewClient(&Config{
+			Address:    ts.URL,
+			Token:      "dummy-token",
+			HTTPClient: ts.Client(),
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			id, err := client.WorkspaceID(ctx, "foo", "bar")
+			require.NoError(t, err)
+			assert.Equal(t, "ws-123", id)
+		}
+		assert.Equal(t, 2, reads)
+	})
+
+	t.Run("with caching", func(t *testing.T) {
+		reads, exists = 0, true
+		client, err := NewClient(&Config{
+			Address:           ts.URL,
+			Token:             "dummy-token",
+			HTTPClient:        ts.Client(),
+			CacheWorkspaceIDs: true,
+		})
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			id, err := client.WorkspaceID(ctx, "foo", "bar")
+			require.NoError(t, err)
+			assert.Equal(t, "ws-123", id)
+		}
+		assert.Equal(t, 1, reads)
+
+		// Reading the workspace once it's gone invalidates the cache.
+		exists = false
+		_, err = client.Workspaces.Read(ctx, "foo", "bar")
+		assert.Equal(t, ErrResourceNotFound, err)
+
+		_, err = client.WorkspaceID(ctx, "foo", "bar")
+		assert.Equal(t, ErrResourceNotFound, err)
+		assert.Equal(t, 3, reads)
+	})
+}
+
+func TestClient_Do(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/organizations/foo/things":
+			assert.Equal(t, "2", r.URL.Query().Get("page[number]"))
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs","attributes":{"message":"hello"}}}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v2/runs/run-123/actions/frobnicate":
+			assert.Equal(t, "application/vnd.api+json", r.Header.Get("Content-Type"))
+			w.WriteHeader(202)
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("decoding the response", func(t *testing.T) {
+		r := &Run{}
+		err := client.Do(ctx, "GET", "organizations/foo/things", &ListOptions{PageNumber: 2}, r)
+		require.NoError(t, err)
+		assert.Equal(t, "run-123", r.ID)
+		assert.Equal(t, "hello", r.Message)
+	})
+
+	t.Run("without a response", func(t *testing.T) {
+		err := client.Do(ctx, "POST", "runs/run-123/actions/frobnicate", nil, nil)
+		assert.NoError(t, err)
+	})
+}
+
 func Test_unmarshalResponse(t *testing.T) {
 	t.Run("unmarshal properly formatted json", func(t *testing.T) {
 		// This structure is intended to include multiple possible fields and
@@ -480,6 +982,153 @@ func Test_unmarshalResponse(t *testing.T) {
 		assert.EqualError(t, err, "v must be a struct or an io.Writer")
 	})
 
+	t.Run("hydrates relations of included resources", func(t *testing.T) {
+		responseBody, err := os.Open("test-fixtures/nested-includes/run.json")
+		require.NoError(t, err)
+		defer responseBody.Close()
+
+		r := &Run{}
+		err = unmarshalResponse(responseBody, r)
+		require.NoError(t, err)
+
+		assertHydrated(t, r.Plan)
+		assert.Equal(t, 1, r.Plan.ResourceAdditions)
+		require.Len(t, r.Plan.Exports, 1)
+		assertHydrated(t, r.Plan.Exports[0])
+		assert.Equal(t, PlanExportSentinelMockBundleV0, r.Plan.Exports[0].DataType)
+	})
+
+	t.Run("decodes included resources carrying links", func(t *testing.T) {
+		responseBody, err := os.Open("test-fixtures/nested-includes/policy-set.json")
+		require.NoError(t, err)
+		defer responseBody.Close()
+
+		ps := &PolicySet{}
+		err = unmarshalResponse(responseBody, ps)
+		require.NoError(t, err)
+
+		assertHydrated(t, ps.CurrentVersion)
+		assert.Equal(t, PolicySetVersionReady, ps.CurrentVersion.Status)
+		assert.Equal(t, ps.ID, ps.CurrentVersion.PolicySet.ID)
+	})
+
+	t.Run("decodes links of the primary data", func(t *testing.T) {
+		data := map[string]interface{}{
+			"data": []interface{}{
+				map[string]interface{}{
+					"type": "policy-set-versions",
+					"id":   "polsetver-1",
+					"links": map[string]interface{}{
+						"upload": "https://archivist.terraform.io/1",
+					},
+				},
+				map[string]interface{}{
+					"type": "policy-set-versions",
+					"id":   "polsetver-2",
+					"links": map[string]interface{}{
+						"upload": "https://archivist.terraform.io/2",
+					},
+				},
+			},
+			"meta": map[string]interface{}{
+				"pagination": map[string]interface{}{
+					"current-page": 1,
+					"total-count":  2,
+				},
+			},
+		}
+		byteData, _ := json.Marshal(data)
+
+		list := struct {
+			*Pagination
+			Items []*PolicySetVersion
+		}{}
+		err := unmarshalResponse(bytes.NewReader(byteData), &list)
+		require.NoError(t, err)
+		require.Len(t, list.Items, 2)
+
+		for i, psv := range list.Items {
+			uploadURL, err := psv.uploadURL()
+			require.NoError(t, err)
+			assert.Equal(t, "https://archivist.terraform.io/"+strconv.Itoa(i+1), uploadURL)
+		}
+	})
+}
+
+func TestClient_dryRun(t *testing.T) {
+	var requests []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/ping" {
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"name":"existing"}}}`))
+	}))
+	defer ts.Close()
+
+	var skipped []string
+	var override *http.Response
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "abcd1234",
+		HTTPClient: ts.Client(),
+		DryRun:     true,
+		DryRunFunc: func(req *http.Request, body []byte) *http.Response {
+			skipped = append(skipped, req.Method+" "+req.URL.Path+" "+string(body))
+			return override
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("sends GET requests", func(t *testing.T) {
+		requests, skipped = nil, nil
+
+		w, err := client.Workspaces.ReadByID(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.Equal(t, "existing", w.Name)
+		assert.Equal(t, []string{"GET /api/v2/workspaces/ws-123"}, requests)
+		assert.Empty(t, skipped)
+	})
+
+	t.Run("echoes the request body of skipped requests", func(t *testing.T) {
+		requests, skipped = nil, nil
+
+		w, err := client.Workspaces.Create(ctx, "acme", WorkspaceCreateOptions{
+			Name: String("new"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "new", w.Name)
+		assert.Empty(t, requests)
+		require.Len(t, skipped, 1)
+		assert.Contains(t, skipped[0], "POST /api/v2/organizations/acme/workspaces ")
+		assert.Contains(t, skipped[0], `"name":"new"`)
+	})
+
+	t.Run("skips requests without a body", func(t *testing.T) {
+		requests, skipped = nil, nil
+
+		err := client.Workspaces.DeleteByID(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+		assert.Equal(t, []string{"DELETE /api/v2/workspaces/ws-123 "}, skipped)
+	})
+
+	t.Run("uses the response of the DryRunFunc", func(t *testing.T) {
+		requests, skipped = nil, nil
+		override = &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}
+		defer func() { override = nil }()
+
+		err := client.Workspaces.DeleteByID(ctx, "ws-123")
+		assert.Equal(t, ErrResourceNotFound, err)
+		assert.Empty(t, requests)
+	})
 }
 
 func TestClient_configureLimiter(t *testing.T) {
@@ -674,3 +1323,205 @@ func setupEnvVars(token, address string) func() {
 		os.Setenv("TFE_ADDRESS", origAddress)
 	}
 }
+
+func TestPack(t *testing.T) {
+	names := func(t *testing.T, body []byte) []string {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		tr := tar.NewReader(zr)
+
+		var names []string
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			names = append(names, header.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t.Run("with a prefix", func(t *testing.T) {
+		body, err := Pack("test-fixtures/archive-dir", PackOptions{Prefix: "config"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"config/bar.txt",
+			"config/exe",
+			"config/foo.txt",
+			"config/sub/",
+			"config/sub/foo.txt",
+			"config/sub/zip.txt",
+		}, names(t, body))
+	})
+
+	t.Run("with a .terraformignore file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "tfe-pack")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".terraformignore"), []byte("*.tfvars\n"), 0644))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), nil, 0644))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "secret.tfvars"), nil, 0644))
+
+		body, err := Pack(dir, PackOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{".terraformignore", "main.tf"}, names(t, body))
+	})
+
+	t.Run("when the path is not a directory", func(t *testing.T) {
+		_, err := Pack("test-fixtures/archive-dir/foo.txt", PackOptions{})
+		assert.Equal(t, ErrMissingDirectory, err)
+	})
+}
+
+func TestClient_count(t *testing.T) {
+	queries := make(map[string]url.Values)
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries[r.URL.Path] = r.URL.Query()
+
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123/runs":
+			checkedWrite(t, w, []byte(`{"data":[{"id":"run-1","type":"runs"}],"meta":{"pagination":{"current-page":1,"total-pages":12000,"total-count":12000}}}`))
+		case "/api/v2/organizations/acme/workspaces":
+			checkedWrite(t, w, []byte(`{"data":[{"id":"ws-1","type":"workspaces"}],"meta":{"pagination":{"current-page":1,"total-pages":42,"total-count":42}}}`))
+		case "/api/v2/state-versions":
+			checkedWrite(t, w, []byte(`{"data":[{"id":"sv-1","type":"state-versions"}],"meta":{"pagination":{"current-page":1,"total-pages":7,"total-count":7}}}`))
+		case "/api/v2/organizations/unpaginated/workspaces":
+			checkedWrite(t, w, []byte(`{"data":[{"id":"ws-1","type":"workspaces"}]}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with runs", func(t *testing.T) {
+		n, err := client.Runs.Count(ctx, "ws-123", RunListOptions{
+			ListOptions: ListOptions{PageNumber: 3, PageSize: 100},
+			Search:      String("deploy"),
+			Include:     String("plan"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 12000, n)
+		assert.Equal(t, url.Values{
+			"page[number]":  []string{"1"},
+			"page[size]":    []string{"1"},
+			"search[basic]": []string{"deploy"},
+		}, queries["/api/v2/workspaces/ws-123/runs"])
+	})
+
+	t.Run("with workspaces", func(t *testing.T) {
+		n, err := client.Workspaces.Count(ctx, "acme", WorkspaceListOptions{Search: String("app")})
+		require.NoError(t, err)
+		assert.Equal(t, 42, n)
+		assert.Equal(t, url.Values{
+			"page[number]": []string{"1"},
+			"page[size]":   []string{"1"},
+			"search[name]": []string{"app"},
+		}, queries["/api/v2/organizations/acme/workspaces"])
+	})
+
+	t.Run("with state versions", func(t *testing.T) {
+		n, err := client.StateVersions.Count(ctx, StateVersionListOptions{
+			Organization: String("acme"),
+			Workspace:    String("app"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 7, n)
+	})
+
+	t.Run("when the list is not paginated", func(t *testing.T) {
+		_, err := client.Workspaces.Count(ctx, "unpaginated", WorkspaceListOptions{})
+		assert.EqualError(t, err, "list is not paginated, unable to count its items")
+	})
+}
+
+func TestClient_forEach(t *testing.T) {
+	var pages []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		assert.NoError(t, err)
+		pages = append(pages, r.URL.Path+" "+r.URL.Query().Get("page[number]")+"/"+r.URL.Query().Get("page[size]"))
+
+		// Three pages of two items each.
+		next := "null"
+		if page < 3 {
+			next = strconv.Itoa(page + 1)
+		}
+		typ := "runs"
+		if r.URL.Path == "/api/v2/organizations/acme/workspaces" {
+			typ = "workspaces"
+		}
+		checkedWrite(t, w, []byte(`{"data":[{"id":"`+strconv.Itoa(2*page-1)+`","type":"`+typ+`"},{"id":"`+strconv.Itoa(2*page)+`","type":"`+typ+`"}],`+
+			`"meta":{"pagination":{"current-page":`+strconv.Itoa(page)+`,"next-page":`+next+`,"total-pages":3,"total-count":6}}}`))
+	})
+
+	ctx := context.Background()
+
+	t.Run("with all items", func(t *testing.T) {
+		pages = nil
+
+		var ids []string
+		err := client.Runs.ForEach(ctx, "ws-123", RunListOptions{}, func(r *Run) error {
+			ids = append(ids, r.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3", "4", "5", "6"}, ids)
+		assert.Equal(t, []string{
+			"/api/v2/workspaces/ws-123/runs 1/100",
+			"/api/v2/workspaces/ws-123/runs 2/100",
+			"/api/v2/workspaces/ws-123/runs 3/100",
+		}, pages)
+	})
+
+	t.Run("from a given page", func(t *testing.T) {
+		pages = nil
+
+		var ids []string
+		err := client.Workspaces.ForEach(ctx, "acme", WorkspaceListOptions{
+			ListOptions: ListOptions{PageNumber: 2, PageSize: 2},
+		}, func(w *Workspace) error {
+			ids = append(ids, w.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3", "4", "5", "6"}, ids)
+		assert.Len(t, pages, 2)
+	})
+
+	t.Run("when fn fails", func(t *testing.T) {
+		pages = nil
+		errStop := errors.New("stop")
+
+		var ids []string
+		err := client.Runs.ForEach(ctx, "ws-123", RunListOptions{}, func(r *Run) error {
+			ids = append(ids, r.ID)
+			if r.ID == "3" {
+				return errStop
+			}
+			return nil
+		})
+		assert.Equal(t, errStop, err)
+		assert.Equal(t, []string{"1", "2", "3"}, ids)
+		assert.Len(t, pages, 2)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var ids []string
+		err := client.Runs.ForEach(ctx, "ws-123", RunListOptions{}, func(r *Run) error {
+			ids = append(ids, r.ID)
+			cancel()
+			return nil
+		})
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, []string{"1"}, ids)
+	})
+}
@@ -65,6 +65,11 @@ func PlanExportType(v PlanExportDataType) *PlanExportDataType {
 	return &v
 }
 
+// RunOperationValue returns a pointer to the given run operation.
+func RunOperationValue(v RunOperation) *RunOperation {
+	return &v
+}
+
 // ServiceProvider returns a pointer to the given service provider type.
 func ServiceProvider(v ServiceProviderType) *ServiceProviderType {
 	return &v
@@ -75,6 +80,11 @@ func SMTPAuthValue(v SMTPAuthType) *SMTPAuthType {
 	return &v
 }
 
+// TeamVisibilityValue returns a pointer to the given team visibility.
+func TeamVisibilityValue(v TeamVisibility) *TeamVisibility {
+	return &v
+}
+
 // String returns a pointer to the given string.
 func String(v string) *string {
 	return &v
@@ -2,6 +2,8 @@ package tfe
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 )
 
 // Compile-time proof of interface implementation.
@@ -17,6 +19,16 @@ type Users interface {
 
 	// Update attributes of the currently authenticated user.
 	Update(ctx context.Context, options UserUpdateOptions) (*User, error)
+
+	// ListOrganizations lists the organizations the given user is a member
+	// of, as far as they are visible to the authenticated user. This takes
+	// at least one request per visible organization.
+	ListOrganizations(ctx context.Context, userID string) ([]*Organization, error)
+
+	// ListTeams lists the teams the given user is a member of, as far as
+	// they are visible to the authenticated user. This takes at least one
+	// request per visible organization.
+	ListTeams(ctx context.Context, userID string) ([]*Team, error)
 }
 
 // users implements Users.
@@ -91,3 +103,137 @@ func (s *users) Update(ctx context.Context, options UserUpdateOptions) (*User, e
 
 	return u, nil
 }
+
+// ListOrganizations lists the organizations the given user is a member of.
+//
+// The API doesn't expose the memberships of a user directly, so this lists
+// every organization visible to the authenticated user and looks up the
+// user's membership in each of them. That costs at least one request per
+// organization, on top of reading the user. When the email address of the
+// user isn't visible, all the memberships of every organization are paged
+// through instead, which can take thousands of requests for a token seeing
+// many large organizations, such as an admin token.
+func (s *users) ListOrganizations(ctx context.Context, userID string) ([]*Organization, error) {
+	if !validStringID(&userID) {
+		return nil, ErrInvalidUserValue
+	}
+
+	var orgs []*Organization
+	err := s.walkMemberships(ctx, userID, func(org *Organization, _ *OrganizationMembership) {
+		orgs = append(orgs, org)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orgs, nil
+}
+
+// ListTeams lists the teams the given user is a member of.
+//
+// The API doesn't expose the memberships of a user directly, so this costs as
+// many requests as ListOrganizations does: see its documentation.
+func (s *users) ListTeams(ctx context.Context, userID string) ([]*Team, error) {
+	if !validStringID(&userID) {
+		return nil, ErrInvalidUserValue
+	}
+
+	var teams []*Team
+	err := s.walkMemberships(ctx, userID, func(_ *Organization, om *OrganizationMembership) {
+		teams = append(teams, om.Teams...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// walkMemberships calls fn for every organization the user is a member of,
+// with the user's organization membership including its teams.
+func (s *users) walkMemberships(ctx context.Context, userID string, fn func(*Organization, *OrganizationMembership)) error {
+	email, err := s.email(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return forEachPage(ctx, ListOptions{}, func(lo ListOptions) (*Pagination, error) {
+		ol, err := s.client.Organizations.List(ctx, OrganizationListOptions{ListOptions: lo})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, org := range ol.Items {
+			om, err := s.findMembership(ctx, org.Name, userID, email)
+			if err != nil {
+				return nil, err
+			}
+			if om != nil {
+				fn(org, om)
+			}
+		}
+
+		return ol.Pagination, nil
+	})
+}
+
+// email returns the email address of the user, or an empty string if it
+// isn't visible to the authenticated user. The address of other users may
+// not be visible, while the authenticated user always sees its own.
+func (s *users) email(ctx context.Context, userID string) (string, error) {
+	current, err := s.ReadCurrent(ctx)
+	if err != nil {
+		return "", err
+	}
+	if current.ID == userID {
+		return current.Email, nil
+	}
+
+	u := fmt.Sprintf("users/%s", url.QueryEscape(userID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return "", err
+	}
+
+	user := &User{}
+	err = s.client.do(ctx, req, user)
+	if err != nil {
+		return "", err
+	}
+
+	return user.Email, nil
+}
+
+// findMembership finds the membership of the user within the organization,
+// returning nil if the user isn't a member. When the email address of the
+// user is given, only the memberships of that address are listed.
+func (s *users) findMembership(ctx context.Context, organization, userID, email string) (*OrganizationMembership, error) {
+	options := OrganizationMembershipListOptions{Include: "teams"}
+	if email != "" {
+		options.Email = String(email)
+	}
+
+	var found *OrganizationMembership
+	err := forEachPage(ctx, ListOptions{}, func(lo ListOptions) (*Pagination, error) {
+		options.ListOptions = lo
+
+		oml, err := s.client.OrganizationMemberships.List(ctx, organization, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, om := range oml.Items {
+			if om.User != nil && om.User.ID == userID {
+				found = om
+				return nil, nil
+			}
+		}
+
+		return oml.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
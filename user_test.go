@@ -2,6 +2,7 @@ package tfe
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -90,3 +91,106 @@ func TestUsersUpdate(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestUsersListOrganizations(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	uTest, err := client.Users.ReadCurrent(ctx)
+	require.NoError(t, err)
+
+	t.Run("with a valid user ID", func(t *testing.T) {
+		orgs, err := client.Users.ListOrganizations(ctx, uTest.ID)
+		require.NoError(t, err)
+
+		found := []string{}
+		for _, org := range orgs {
+			found = append(found, org.Name)
+		}
+		assert.Contains(t, found, orgTest.Name)
+	})
+
+	t.Run("with an invalid user ID", func(t *testing.T) {
+		orgs, err := client.Users.ListOrganizations(ctx, badIdentifier)
+		assert.Nil(t, orgs)
+		assert.EqualError(t, err, ErrInvalidUserValue.Error())
+	})
+}
+
+func TestUsersListOrganizations_emailFilter(t *testing.T) {
+	var filters []string
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/account/details":
+			checkedWrite(t, w, []byte(`{"data":{"id":"user-1","type":"users","attributes":{"email":"one@example.com"}}}`))
+		case "/api/v2/users/user-2":
+			checkedWrite(t, w, []byte(`{"data":{"id":"user-2","type":"users","attributes":{"username":"two"}}}`))
+		case "/api/v2/organizations":
+			checkedWrite(t, w, []byte(`{"data":[{"id":"acme","type":"organizations","attributes":{"name":"acme"}}]}`))
+		case "/api/v2/organizations/acme/organization-memberships":
+			filters = append(filters, r.URL.Query().Get("filter[email]"))
+			checkedWrite(t, w, []byte(`{"data":[`+
+				`{"id":"ou-1","type":"organization-memberships","relationships":{"user":{"data":{"id":"user-1","type":"users"}}}},`+
+				`{"id":"ou-2","type":"organization-memberships","relationships":{"user":{"data":{"id":"user-2","type":"users"}}}}`+
+				`]}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with the authenticated user", func(t *testing.T) {
+		filters = nil
+
+		orgs, err := client.Users.ListOrganizations(ctx, "user-1")
+		require.NoError(t, err)
+		require.Len(t, orgs, 1)
+		assert.Equal(t, "acme", orgs[0].Name)
+		assert.Equal(t, []string{"one@example.com"}, filters)
+	})
+
+	t.Run("with a user whose email address isn't visible", func(t *testing.T) {
+		filters = nil
+
+		orgs, err := client.Users.ListOrganizations(ctx, "user-2")
+		require.NoError(t, err)
+		require.Len(t, orgs, 1)
+		assert.Equal(t, []string{""}, filters)
+	})
+}
+
+func TestUsersListTeams(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	uTest, err := client.Users.ReadCurrent(ctx)
+	require.NoError(t, err)
+
+	t.Run("with a valid user ID", func(t *testing.T) {
+		teams, err := client.Users.ListTeams(ctx, uTest.ID)
+		require.NoError(t, err)
+
+		// The creator of an organization is a member of its owners team.
+		found := false
+		for _, team := range teams {
+			if team.Name == "owners" {
+				found = true
+			}
+		}
+		assert.True(t, found, "expected to be a member of the owners team of %s", orgTest.Name)
+	})
+
+	t.Run("with an invalid user ID", func(t *testing.T) {
+		teams, err := client.Users.ListTeams(ctx, badIdentifier)
+		assert.Nil(t, teams)
+		assert.EqualError(t, err, ErrInvalidUserValue.Error())
+	})
+}
@@ -10,6 +10,10 @@ var reStringID = regexp.MustCompile(`^[a-zA-Z0-9\-\._]+$`)
 // A regular expression used to validate semantic versions (major.minor.patch).
 var reSemanticVersion = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
 
+// A regular expression used to validate inactivity durations, a number of
+// days or hours such as "14d" or "12h".
+var reActivityDuration = regexp.MustCompile(`^[1-9][0-9]*[dh]$`)
+
 // validString checks if the given input is present and non-empty.
 func validString(v *string) bool {
 	return v != nil && *v != ""
@@ -26,3 +30,9 @@ func validStringID(v *string) bool {
 func validSemanticVersion(v string) bool {
 	return reSemanticVersion.MatchString(v)
 }
+
+// validActivityDuration checks if the given string is a valid inactivity
+// duration, such as "14d" or "12h".
+func validActivityDuration(v string) bool {
+	return reActivityDuration.MatchString(v)
+}
@@ -29,6 +29,10 @@ type Variables interface {
 
 	// Delete a variable by its ID.
 	Delete(ctx context.Context, workspaceID string, variableID string) error
+
+	// Reconcile creates, updates and deletes the variables of a workspace to
+	// match the desired variables, and returns how many of each it did.
+	Reconcile(ctx context.Context, workspaceID string, desired []VariableSpec) (created, updated, deleted int, err error)
 }
 
 // variables implements Variables.
@@ -242,3 +246,193 @@ func (s *variables) Delete(ctx context.Context, workspaceID string, variableID s
 
 	return s.client.do(ctx, req, nil)
 }
+
+// VariableSpec represents the desired state of a variable, identified by its
+// key and category.
+type VariableSpec struct {
+	// The name of the variable.
+	Key string
+
+	// The value of the variable. When nil, the value of an existing variable
+	// is left untouched, which allows keeping a sensitive value that can't be
+	// read back.
+	Value *string
+
+	// The description of the variable.
+	Description string
+
+	// Whether this is a Terraform or environment variable.
+	Category CategoryType
+
+	// Whether to evaluate the value of the variable as a string of HCL code.
+	HCL bool
+
+	// Whether the value is sensitive.
+	Sensitive bool
+
+	// Whether to update the value of an existing sensitive variable even
+	// when its other attributes are unchanged, such as to rotate a secret.
+	// A sensitive value can't be read back to be compared, so by default it
+	// is only sent along with changes to the other attributes, which keeps
+	// the counts of Reconcile at zero when nothing else changed.
+	UpdateSensitiveValue bool
+}
+
+// variableKey identifies a variable within a workspace.
+type variableKey struct {
+	key      string
+	category CategoryType
+}
+
+// Reconcile creates, updates and deletes the variables of a workspace so they
+// match the desired variables, matching them by key and category.
+//
+// The value of a sensitive variable can't be read back, so it can't be
+// compared either: a sensitive variable is only updated when its other
+// attributes differ, unless UpdateSensitiveValue is set for it. A sensitive
+// variable can't be made non-sensitive again, so it is deleted and created
+// anew instead. Variables are created and updated before any is deleted, so
+// a workspace never lacks a variable it keeps along the way.
+//
+// When an error occurs, the counts of the changes made so far are returned
+// with it.
+func (s *variables) Reconcile(ctx context.Context, workspaceID string, desired []VariableSpec) (created, updated, deleted int, err error) {
+	if !validStringID(&workspaceID) {
+		return 0, 0, 0, ErrInvalidWorkspaceID
+	}
+
+	want := make(map[variableKey]VariableSpec, len(desired))
+	for _, spec := range desired {
+		if spec.Key == "" {
+			return 0, 0, 0, errors.New("key is required")
+		}
+		if spec.Category == "" {
+			return 0, 0, 0, errors.New("category is required")
+		}
+
+		k := variableKey{key: spec.Key, category: spec.Category}
+		if _, ok := want[k]; ok {
+			return 0, 0, 0, fmt.Errorf("duplicate %s variable %s", spec.Category, spec.Key)
+		}
+		want[k] = spec
+	}
+
+	var existing []*Variable
+	for page := 1; page != 0; {
+		vl, err := s.List(ctx, workspaceID, VariableListOptions{
+			ListOptions: ListOptions{PageNumber: page, PageSize: 100},
+		})
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		existing = append(existing, vl.Items...)
+
+		page = 0
+		if vl.Pagination != nil {
+			page = vl.NextPage
+		}
+	}
+
+	// Work out the changes first, so no change is made when a variable can't
+	// be reconciled.
+	have := make(map[variableKey]bool, len(existing))
+	var toDelete []*Variable
+	var toUpdate []*Variable
+	var toReplace []*Variable
+	for _, v := range existing {
+		k := variableKey{key: v.Key, category: v.Category}
+		have[k] = true
+
+		spec, ok := want[k]
+		switch {
+		case !ok:
+			toDelete = append(toDelete, v)
+		case v.Sensitive && !spec.Sensitive:
+			if spec.Value == nil {
+				return 0, 0, 0, fmt.Errorf("a value is required to make sensitive %s variable %s non-sensitive", v.Category, v.Key)
+			}
+			toReplace = append(toReplace, v)
+		case variableNeedsUpdate(v, spec):
+			toUpdate = append(toUpdate, v)
+		}
+	}
+
+	// Create the missing variables in the order they were given.
+	for _, spec := range desired {
+		if have[variableKey{key: spec.Key, category: spec.Category}] {
+			continue
+		}
+		if err := s.createSpec(ctx, workspaceID, spec); err != nil {
+			return created, updated, deleted, err
+		}
+		created++
+	}
+
+	for _, v := range toUpdate {
+		spec := want[variableKey{key: v.Key, category: v.Category}]
+		_, err := s.Update(ctx, workspaceID, v.ID, VariableUpdateOptions{
+			Value:       spec.Value,
+			Description: String(spec.Description),
+			HCL:         Bool(spec.HCL),
+			Sensitive:   Bool(spec.Sensitive),
+		})
+		if err != nil {
+			return created, updated, deleted, err
+		}
+		updated++
+	}
+
+	// A variable replacing another one has the same key and category, so
+	// the old one has to be deleted first.
+	for _, v := range toReplace {
+		if err := s.Delete(ctx, workspaceID, v.ID); err != nil {
+			return created, updated, deleted, err
+		}
+		deleted++
+
+		if err := s.createSpec(ctx, workspaceID, want[variableKey{key: v.Key, category: v.Category}]); err != nil {
+			return created, updated, deleted, err
+		}
+		created++
+	}
+
+	for _, v := range toDelete {
+		if err := s.Delete(ctx, workspaceID, v.ID); err != nil {
+			return created, updated, deleted, err
+		}
+		deleted++
+	}
+
+	return created, updated, deleted, nil
+}
+
+// createSpec creates a variable in the desired state.
+func (s *variables) createSpec(ctx context.Context, workspaceID string, spec VariableSpec) error {
+	category := spec.Category
+	_, err := s.Create(ctx, workspaceID, VariableCreateOptions{
+		Key:         String(spec.Key),
+		Value:       spec.Value,
+		Description: String(spec.Description),
+		Category:    &category,
+		HCL:         Bool(spec.HCL),
+		Sensitive:   Bool(spec.Sensitive),
+	})
+	return err
+}
+
+// variableNeedsUpdate returns true if an existing variable differs from its
+// desired state. The value of a sensitive variable can't be compared, so it
+// only differs when asked to update it.
+func variableNeedsUpdate(v *Variable, spec VariableSpec) bool {
+	if v.Description != spec.Description || v.HCL != spec.HCL || v.Sensitive != spec.Sensitive {
+		return true
+	}
+	switch {
+	case spec.Value == nil:
+		return false
+	case v.Sensitive:
+		return spec.UpdateSensitiveValue
+	default:
+		return v.Value != *spec.Value
+	}
+}
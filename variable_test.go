@@ -2,6 +2,11 @@ package tfe
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -325,3 +330,171 @@ func TestVariablesDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for variable ID")
 	})
 }
+
+func TestVariablesReconcile(t *testing.T) {
+	// The variables of the workspace, keyed by ID, as JSON:API attributes.
+	vars := map[string]map[string]interface{}{
+		"var-keep":   {"key": "region", "value": "eu-west-1", "category": "env", "hcl": false, "sensitive": false, "description": ""},
+		"var-change": {"key": "instances", "value": "2", "category": "terraform", "hcl": true, "sensitive": false, "description": ""},
+		"var-secret": {"key": "token", "value": "", "category": "env", "hcl": false, "sensitive": true, "description": "API token"},
+		"var-stale":  {"key": "legacy", "value": "yes", "category": "terraform", "hcl": false, "sensitive": false, "description": ""},
+		"var-same":   {"key": "region", "value": "eu-west-1", "category": "terraform", "hcl": false, "sensitive": false, "description": ""},
+	}
+	var requests []string
+	var patched map[string]interface{}
+	nextID := 0
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		var body struct {
+			Data struct {
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"data"`
+		}
+		if r.Method == "POST" || r.Method == "PATCH" {
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(b, &body))
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/workspaces/ws-1/vars/")
+		switch r.Method {
+		case "GET":
+			var data []map[string]interface{}
+			for id, attrs := range vars {
+				data = append(data, map[string]interface{}{"id": id, "type": "vars", "attributes": attrs})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": data,
+				"meta": map[string]interface{}{"pagination": map[string]interface{}{"current-page": 1, "total-pages": 1}},
+			})
+			return
+		case "POST":
+			nextID++
+			id = fmt.Sprintf("var-new-%d", nextID)
+			vars[id] = body.Data.Attributes
+			w.WriteHeader(http.StatusCreated)
+		case "PATCH":
+			patched = body.Data.Attributes
+			for k, v := range body.Data.Attributes {
+				vars[id][k] = v
+			}
+		case "DELETE":
+			delete(vars, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": id, "type": "vars", "attributes": vars[id]},
+		})
+	})
+
+	ctx := context.Background()
+
+	t.Run("with changes", func(t *testing.T) {
+		created, updated, deleted, err := client.Variables.Reconcile(ctx, "ws-1", []VariableSpec{
+			{Key: "region", Value: String("eu-west-1"), Category: CategoryEnv},
+			{Key: "region", Value: String("eu-west-1"), Category: CategoryTerraform},
+			{Key: "instances", Value: String("3"), Category: CategoryTerraform, HCL: true},
+			{Key: "token", Category: CategoryEnv, Sensitive: true, Description: "API token"},
+			{Key: "owner", Value: String("platform"), Category: CategoryTerraform},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 1, updated)
+		assert.Equal(t, 1, deleted)
+
+		assert.Equal(t, []string{
+			"GET /api/v2/workspaces/ws-1/vars",
+			"POST /api/v2/workspaces/ws-1/vars",
+			"PATCH /api/v2/workspaces/ws-1/vars/var-change",
+			"DELETE /api/v2/workspaces/ws-1/vars/var-stale",
+		}, requests)
+		assert.Equal(t, "3", vars["var-change"]["value"])
+		assert.Equal(t, "owner", vars["var-new-1"]["key"])
+	})
+
+	t.Run("when keeping a sensitive value", func(t *testing.T) {
+		requests = nil
+
+		_, updated, _, err := client.Variables.Reconcile(ctx, "ws-1", []VariableSpec{
+			{Key: "region", Value: String("eu-west-1"), Category: CategoryEnv},
+			{Key: "region", Value: String("eu-west-1"), Category: CategoryTerraform},
+			{Key: "instances", Value: String("3"), Category: CategoryTerraform, HCL: true},
+			{Key: "token", Category: CategoryEnv, Sensitive: true, Description: "Rotated API token"},
+			{Key: "owner", Value: String("platform"), Category: CategoryTerraform},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated)
+		assert.Equal(t, []string{
+			"GET /api/v2/workspaces/ws-1/vars",
+			"PATCH /api/v2/workspaces/ws-1/vars/var-secret",
+		}, requests)
+
+		// The value isn't sent, so the stored value is kept.
+		assert.NotContains(t, patched, "value")
+		assert.Equal(t, "Rotated API token", patched["description"])
+	})
+
+	t.Run("when giving an unchanged sensitive variable a value", func(t *testing.T) {
+		desired := []VariableSpec{
+			{Key: "region", Value: String("eu-west-1"), Category: CategoryEnv},
+			{Key: "region", Value: String("eu-west-1"), Category: CategoryTerraform},
+			{Key: "instances", Value: String("3"), Category: CategoryTerraform, HCL: true},
+			{Key: "token", Value: String("secret"), Category: CategoryEnv, Sensitive: true, Description: "Rotated API token"},
+			{Key: "owner", Value: String("platform"), Category: CategoryTerraform},
+		}
+
+		requests = nil
+		created, updated, deleted, err := client.Variables.Reconcile(ctx, "ws-1", desired)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 0, 0}, []int{created, updated, deleted})
+		assert.Equal(t, []string{"GET /api/v2/workspaces/ws-1/vars"}, requests)
+
+		requests = nil
+		desired[3].UpdateSensitiveValue = true
+		_, updated, _, err = client.Variables.Reconcile(ctx, "ws-1", desired)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated)
+		assert.Equal(t, []string{
+			"GET /api/v2/workspaces/ws-1/vars",
+			"PATCH /api/v2/workspaces/ws-1/vars/var-secret",
+		}, requests)
+		assert.Equal(t, "secret", patched["value"])
+	})
+
+	t.Run("when making a sensitive variable non-sensitive", func(t *testing.T) {
+		requests = nil
+
+		created, _, deleted, err := client.Variables.Reconcile(ctx, "ws-1", []VariableSpec{
+			{Key: "token", Value: String("public"), Category: CategoryEnv},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 5, deleted)
+		assert.Len(t, vars, 1)
+		for _, attrs := range vars {
+			assert.Equal(t, "public", attrs["value"])
+			assert.Equal(t, false, attrs["sensitive"])
+		}
+	})
+
+	t.Run("with duplicate variables", func(t *testing.T) {
+		_, _, _, err := client.Variables.Reconcile(ctx, "ws-1", []VariableSpec{
+			{Key: "region", Category: CategoryEnv},
+			{Key: "region", Category: CategoryEnv},
+		})
+		assert.EqualError(t, err, "duplicate env variable region")
+	})
+
+	t.Run("without a category", func(t *testing.T) {
+		_, _, _, err := client.Variables.Reconcile(ctx, "ws-1", []VariableSpec{{Key: "region"}})
+		assert.EqualError(t, err, "category is required")
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		_, _, _, err := client.Variables.Reconcile(ctx, badIdentifier, nil)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
@@ -2,11 +2,14 @@ package tfe
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"path"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,9 +24,20 @@ type Workspaces interface {
 	// List all the workspaces within an organization.
 	List(ctx context.Context, organization string, options WorkspaceListOptions) (*WorkspaceList, error)
 
+	// Count the workspaces within an organization matching the list options.
+	Count(ctx context.Context, organization string, options WorkspaceListOptions) (int, error)
+
+	// ForEach calls fn for each workspace within an organization matching
+	// the list options, one page at a time.
+	ForEach(ctx context.Context, organization string, options WorkspaceListOptions, fn func(*Workspace) error) error
+
 	// Create is used to create a new workspace.
 	Create(ctx context.Context, organization string, options WorkspaceCreateOptions) (*Workspace, error)
 
+	// CreateMany creates workspaces concurrently, with at most concurrency
+	// workspaces being created at the same time.
+	CreateMany(ctx context.Context, organization string, inputs []WorkspaceCreateOptions, concurrency int) ([]*Workspace, []error)
+
 	// Read a workspace by its name.
 	Read(ctx context.Context, organization string, workspace string) (*Workspace, error)
 
@@ -36,6 +50,10 @@ type Workspaces interface {
 	// ReadByIDWithOptions reads a workspace by its ID with the given options.
 	ReadByIDWithOptions(ctx context.Context, workspaceID string, options WorkspaceReadOptions) (*Workspace, error)
 
+	// LatestRunLogs streams the plan and apply logs of the latest run of a
+	// workspace.
+	LatestRunLogs(ctx context.Context, workspaceID string, w io.Writer) error
+
 	// Update settings of an existing workspace.
 	Update(ctx context.Context, organization string, workspace string, options WorkspaceUpdateOptions) (*Workspace, error)
 
@@ -54,6 +72,18 @@ type Workspaces interface {
 	// RemoveVCSConnectionByID removes a VCS connection from a workspace.
 	RemoveVCSConnectionByID(ctx context.Context, workspaceID string) (*Workspace, error)
 
+	// EnableAutoApply enables auto apply on a workspace.
+	EnableAutoApply(ctx context.Context, workspaceID string) (*Workspace, error)
+
+	// DisableAutoApply disables auto apply on a workspace.
+	DisableAutoApply(ctx context.Context, workspaceID string) (*Workspace, error)
+
+	// RemoveAutoDestroy clears the auto destroy settings of a workspace.
+	RemoveAutoDestroy(ctx context.Context, workspaceID string) (*Workspace, error)
+
+	// WaitForUnlock waits for a workspace to be unlocked.
+	WaitForUnlock(ctx context.Context, workspaceID string, interval time.Duration) error
+
 	// Lock a workspace by its ID.
 	Lock(ctx context.Context, workspaceID string, options WorkspaceLockOptions) (*Workspace, error)
 
@@ -81,6 +111,14 @@ type Workspaces interface {
 	// UpdateRemoteStateConsumers updates all the remote state consumers for a workspace
 	// to match the workspaces in the update options.
 	UpdateRemoteStateConsumers(ctx context.Context, workspaceID string, options WorkspaceUpdateRemoteStateConsumersOptions) error
+
+	// ListPolicySets lists the policy sets which apply to a workspace,
+	// including the global policy sets of its organization.
+	ListPolicySets(ctx context.Context, workspaceID string, options ListOptions) (*PolicySetList, error)
+
+	// EffectiveTags lists the tags which apply to a workspace, both those
+	// assigned to it directly and those inherited from its project.
+	EffectiveTags(ctx context.Context, workspaceID string) ([]*EffectiveTagBinding, error)
 }
 
 // workspaces implements Workspaces.
@@ -96,45 +134,121 @@ type WorkspaceList struct {
 
 // Workspace represents a Terraform Enterprise workspace.
 type Workspace struct {
-	ID                         string                `jsonapi:"primary,workspaces"`
-	Actions                    *WorkspaceActions     `jsonapi:"attr,actions"`
-	AgentPoolID                string                `jsonapi:"attr,agent-pool-id"`
-	AllowDestroyPlan           bool                  `jsonapi:"attr,allow-destroy-plan"`
-	AutoApply                  bool                  `jsonapi:"attr,auto-apply"`
-	CanQueueDestroyPlan        bool                  `jsonapi:"attr,can-queue-destroy-plan"`
-	CreatedAt                  time.Time             `jsonapi:"attr,created-at,iso8601"`
-	Description                string                `jsonapi:"attr,description"`
-	Environment                string                `jsonapi:"attr,environment"`
-	ExecutionMode              string                `jsonapi:"attr,execution-mode"`
-	FileTriggersEnabled        bool                  `jsonapi:"attr,file-triggers-enabled"`
-	GlobalRemoteState          bool                  `jsonapi:"attr,global-remote-state"`
-	Locked                     bool                  `jsonapi:"attr,locked"`
-	MigrationEnvironment       string                `jsonapi:"attr,migration-environment"`
-	Name                       string                `jsonapi:"attr,name"`
-	Operations                 bool                  `jsonapi:"attr,operations"`
-	Permissions                *WorkspacePermissions `jsonapi:"attr,permissions"`
-	QueueAllRuns               bool                  `jsonapi:"attr,queue-all-runs"`
-	SpeculativeEnabled         bool                  `jsonapi:"attr,speculative-enabled"`
-	SourceName                 string                `jsonapi:"attr,source-name"`
-	SourceURL                  string                `jsonapi:"attr,source-url"`
-	StructuredRunOutputEnabled bool                  `jsonapi:"attr,structured-run-output-enabled"`
-	TerraformVersion           string                `jsonapi:"attr,terraform-version"`
-	TriggerPrefixes            []string              `jsonapi:"attr,trigger-prefixes"`
-	VCSRepo                    *VCSRepo              `jsonapi:"attr,vcs-repo"`
-	WorkingDirectory           string                `jsonapi:"attr,working-directory"`
-	UpdatedAt                  time.Time             `jsonapi:"attr,updated-at,iso8601"`
-	ResourceCount              int                   `jsonapi:"attr,resource-count"`
-	ApplyDurationAverage       time.Duration         `jsonapi:"attr,apply-duration-average"`
-	PlanDurationAverage        time.Duration         `jsonapi:"attr,plan-duration-average"`
-	PolicyCheckFailures        int                   `jsonapi:"attr,policy-check-failures"`
-	RunFailures                int                   `jsonapi:"attr,run-failures"`
-	RunsCount                  int                   `jsonapi:"attr,workspace-kpis-runs-count"`
+	ID                          string                      `jsonapi:"primary,workspaces"`
+	Actions                     *WorkspaceActions           `jsonapi:"attr,actions"`
+	AgentPoolID                 string                      `jsonapi:"attr,agent-pool-id"`
+	AllowDestroyPlan            bool                        `jsonapi:"attr,allow-destroy-plan"`
+	AssessmentsEnabled          bool                        `jsonapi:"attr,assessments-enabled"`
+	AutoApply                   bool                        `jsonapi:"attr,auto-apply"`
+	AutoApplyRunTrigger         bool                        `jsonapi:"attr,auto-apply-run-trigger"`
+	AutoDestroyAt               *time.Time                  `jsonapi:"attr,auto-destroy-at,iso8601"`
+	AutoDestroyActivityDuration string                      `jsonapi:"attr,auto-destroy-activity-duration"`
+	CanQueueDestroyPlan         bool                        `jsonapi:"attr,can-queue-destroy-plan"`
+	CreatedAt                   time.Time                   `jsonapi:"attr,created-at,iso8601"`
+	Description                 string                      `jsonapi:"attr,description"`
+	Environment                 string                      `jsonapi:"attr,environment"`
+	ExecutionMode               string                      `jsonapi:"attr,execution-mode"`
+	FileTriggersEnabled         bool                        `jsonapi:"attr,file-triggers-enabled"`
+	GlobalRemoteState           bool                        `jsonapi:"attr,global-remote-state"`
+	Locked                      bool                        `jsonapi:"attr,locked"`
+	MigrationEnvironment        string                      `jsonapi:"attr,migration-environment"`
+	Name                        string                      `jsonapi:"attr,name"`
+	Operations                  bool                        `jsonapi:"attr,operations"`
+	Permissions                 *WorkspacePermissions       `jsonapi:"attr,permissions"`
+	QueueAllRuns                bool                        `jsonapi:"attr,queue-all-runs"`
+	SettingOverwrites           *WorkspaceSettingOverwrites `jsonapi:"attr,setting-overwrites"`
+	SpeculativeEnabled          bool                        `jsonapi:"attr,speculative-enabled"`
+	SourceName                  string                      `jsonapi:"attr,source-name"`
+	SourceURL                   string                      `jsonapi:"attr,source-url"`
+	StructuredRunOutputEnabled  bool                        `jsonapi:"attr,structured-run-output-enabled"`
+	TerraformVersion            string                      `jsonapi:"attr,terraform-version"`
+	TriggerPrefixes             []string                    `jsonapi:"attr,trigger-prefixes"`
+	TriggerPatterns             []string                    `jsonapi:"attr,trigger-patterns"`
+	VCSRepo                     *VCSRepo                    `jsonapi:"attr,vcs-repo"`
+	WorkingDirectory            string                      `jsonapi:"attr,working-directory"`
+	UpdatedAt                   time.Time                   `jsonapi:"attr,updated-at,iso8601"`
+	ResourceCount               int                         `jsonapi:"attr,resource-count"`
+	ApplyDurationAverage        time.Duration               `jsonapi:"attr,apply-duration-average"`
+	PlanDurationAverage         time.Duration               `jsonapi:"attr,plan-duration-average"`
+	PolicyCheckFailures         int                         `jsonapi:"attr,policy-check-failures"`
+	RunFailures                 int                         `jsonapi:"attr,run-failures"`
+	RunsCount                   int                         `jsonapi:"attr,workspace-kpis-runs-count"`
 
 	// Relations
-	AgentPool    *AgentPool    `jsonapi:"relation,agent-pool"`
-	CurrentRun   *Run          `jsonapi:"relation,current-run"`
-	Organization *Organization `jsonapi:"relation,organization"`
-	SSHKey       *SSHKey       `jsonapi:"relation,ssh-key"`
+	AgentPool               *AgentPool        `jsonapi:"relation,agent-pool"`
+	CurrentAssessmentResult *AssessmentResult `jsonapi:"relation,current-assessment-result"`
+	CurrentRun              *Run              `jsonapi:"relation,current-run"`
+	Organization            *Organization     `jsonapi:"relation,organization"`
+	SSHKey                  *SSHKey           `jsonapi:"relation,ssh-key"`
+
+	// LockedBy holds the user, run or team holding the lock of a locked
+	// workspace. As the jsonapi package doesn't support relations to
+	// resources of different types, it is decoded separately.
+	LockedBy *LockedByChoice
+}
+
+// LockedByChoice is a choice type struct that represents the holder of a
+// workspace lock. Only one of its fields is set.
+type LockedByChoice struct {
+	Run  *Run
+	User *User
+	Team *Team
+}
+
+// AssessmentResult represents the result of the latest health assessment of
+// a workspace, which checks whether its infrastructure drifted from its
+// state. Include "current_assessment_result" when reading or listing
+// workspaces to populate it.
+type AssessmentResult struct {
+	ID        string    `jsonapi:"primary,assessment-results"`
+	Drifted   bool      `jsonapi:"attr,drifted"`
+	Succeeded bool      `jsonapi:"attr,succeeded"`
+	ErrorMsg  string    `jsonapi:"attr,error-msg"`
+	CreatedAt time.Time `jsonapi:"attr,created-at,iso8601"`
+}
+
+// Drifted reports whether the latest health assessment of the workspace
+// found its infrastructure drifted. It returns nil when that is unknown: the
+// workspace was never assessed, its latest assessment failed, or its
+// assessment result was not included.
+func (w *Workspace) Drifted() *bool {
+	r := w.CurrentAssessmentResult
+	if r == nil || r.CreatedAt.IsZero() || !r.Succeeded {
+		return nil
+	}
+	return Bool(r.Drifted)
+}
+
+// unmarshalRawResource implements rawResourceUnmarshaler.
+func (w *Workspace) unmarshalRawResource(r *rawResource) error {
+	raw, ok := r.Relationships["locked-by"]
+	if !ok {
+		return nil
+	}
+
+	var rel struct {
+		Data *struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &rel); err != nil {
+		return err
+	}
+	if rel.Data == nil {
+		return nil
+	}
+
+	switch rel.Data.Type {
+	case "runs":
+		w.LockedBy = &LockedByChoice{Run: &Run{ID: rel.Data.ID}}
+	case "users":
+		w.LockedBy = &LockedByChoice{User: &User{ID: rel.Data.ID}}
+	case "teams":
+		w.LockedBy = &LockedByChoice{Team: &Team{ID: rel.Data.ID}}
+	}
+
+	return nil
 }
 
 // workspaceWithReadme is the same as a workspace but it has a readme.
@@ -158,6 +272,7 @@ type VCSRepo struct {
 	OAuthTokenID      string `json:"oauth-token-id"`
 	RepositoryHTTPURL string `json:"repository-http-url"`
 	ServiceProvider   string `json:"service-provider"`
+	TagsRegex         string `json:"tags-regex"`
 }
 
 // WorkspaceActions represents the workspace actions.
@@ -179,6 +294,22 @@ type WorkspacePermissions struct {
 	CanUpdateVariable bool `json:"can-update-variable"`
 }
 
+// WorkspaceSettingOverwrites represents which settings of a workspace in a
+// project overwrite the defaults of the project. A setting that is not
+// overwritten is inherited from the project.
+type WorkspaceSettingOverwrites struct {
+	ExecutionMode *bool `json:"execution-mode"`
+	AgentPool     *bool `json:"agent-pool"`
+}
+
+// WorkspaceSettingOverwritesOptions represents the options for choosing which
+// settings of a workspace overwrite the defaults of its project. Set a field
+// to false to inherit the setting from the project.
+type WorkspaceSettingOverwritesOptions struct {
+	ExecutionMode *bool `json:"execution-mode,omitempty"`
+	AgentPool     *bool `json:"agent-pool,omitempty"`
+}
+
 // WorkspaceReadOptions represents the options for reading a workspace.
 type WorkspaceReadOptions struct {
 	Include string `schema:"include"`
@@ -191,8 +322,23 @@ type WorkspaceListOptions struct {
 	// A search string (partial workspace name) used to filter the results.
 	Search *string `schema:"search[name],omitempty"`
 
+	// A comma separated list of tags. Only workspaces with all of the tags
+	// are returned.
+	Tags *string `schema:"search[tags],omitempty"`
+
+	// A comma separated list of tags. Workspaces with any of the tags are
+	// left out.
+	ExcludeTags *string `schema:"search[exclude-tags],omitempty"`
+
+	// A workspace name with wildcards, such as "*-prod" or "app-*", used to
+	// filter the results.
+	WildcardName *string `schema:"search[wildcard-name],omitempty"`
+
+	// The ID of the project the workspaces must be in.
+	ProjectID *string `schema:"filter[project][id],omitempty"`
+
 	// A list of relations to include. See available resources https://www.terraform.io/docs/cloud/api/workspaces.html#available-related-resources
-	Include *string `schema:"include"`
+	Include *string `schema:"include,omitempty"`
 }
 
 // List all the workspaces within an organization.
@@ -216,6 +362,48 @@ func (s *workspaces) List(ctx context.Context, organization string, options Work
 	return wl, nil
 }
 
+// Count the workspaces within an organization matching the list options. It
+// makes a single request for one workspace, and reads the total count of the
+// pagination. The pagination and included relations of the options are
+// ignored.
+func (s *workspaces) Count(ctx context.Context, organization string, options WorkspaceListOptions) (int, error) {
+	options.ListOptions = countOptions
+	options.Include = nil
+
+	wl, err := s.List(ctx, organization, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalCount(wl.Pagination)
+}
+
+// ForEach calls fn for each workspace within an organization matching the
+// list options, holding only one page of workspaces at a time. It stops at
+// the first error returned by fn, which it returns, or when the context is
+// done. The pagination of the options sets the first page and the page size,
+// which defaults to 100.
+func (s *workspaces) ForEach(ctx context.Context, organization string, options WorkspaceListOptions, fn func(*Workspace) error) error {
+	return forEachPage(ctx, options.ListOptions, func(lo ListOptions) (*Pagination, error) {
+		options.ListOptions = lo
+
+		wl, err := s.List(ctx, organization, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range wl.Items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := fn(w); err != nil {
+				return nil, err
+			}
+		}
+
+		return wl.Pagination, nil
+	})
+}
+
 // WorkspaceCreateOptions represents the options for creating a new workspace.
 type WorkspaceCreateOptions struct {
 	// Type is a public field utilized by JSON:API to
@@ -232,15 +420,33 @@ type WorkspaceCreateOptions struct {
 	// Whether destroy plans can be queued on the workspace.
 	AllowDestroyPlan *bool `jsonapi:"attr,allow-destroy-plan,omitempty"`
 
+	// Whether health assessments, such as drift detection, run for the
+	// workspace. It has no effect when the organization enforces them.
+	AssessmentsEnabled *bool `jsonapi:"attr,assessments-enabled,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attr,auto-apply,omitempty"`
 
+	// Whether to automatically apply changes for runs created by run
+	// triggers from another workspace.
+	AutoApplyRunTrigger *bool `jsonapi:"attr,auto-apply-run-trigger,omitempty"`
+
+	// The time at which all the resources of the workspace are destroyed.
+	// Use RemoveAutoDestroy to clear it.
+	AutoDestroyAt *time.Time `jsonapi:"attr,auto-destroy-at,iso8601,omitempty"`
+
+	// The duration of inactivity after which all the resources of the
+	// workspace are destroyed, as a number of days or hours such as "14d" or
+	// "12h". Use RemoveAutoDestroy to clear it.
+	AutoDestroyActivityDuration *string `jsonapi:"attr,auto-destroy-activity-duration,omitempty"`
+
 	// A description for the workspace.
 	Description *string `jsonapi:"attr,description,omitempty"`
 
 	// Which execution mode to use. Valid values are remote, local, and agent.
 	// When set to local, the workspace will be used for state storage only.
-	// This value must not be specified if operations is specified.
+	// This value must not be specified if operations is specified; servers
+	// not supporting execution modes must be sent operations instead.
 	// 'agent' execution mode is not available in Terraform Enterprise.
 	ExecutionMode *string `jsonapi:"attr,execution-mode,omitempty"`
 
@@ -263,13 +469,17 @@ type WorkspaceCreateOptions struct {
 	Name *string `jsonapi:"attr,name"`
 
 	// DEPRECATED. Whether the workspace will use remote or local execution mode.
-	// Use ExecutionMode instead.
+	// Use ExecutionMode instead, unless the server doesn't support it.
 	Operations *bool `jsonapi:"attr,operations,omitempty"`
 
 	// Whether to queue all runs. Unless this is set to true, runs triggered by
 	// a webhook will not be queued until at least one run is manually queued.
 	QueueAllRuns *bool `jsonapi:"attr,queue-all-runs,omitempty"`
 
+	// Which settings overwrite the defaults of the project the workspace is
+	// in, rather than being inherited from it.
+	SettingOverwrites *WorkspaceSettingOverwritesOptions `jsonapi:"attr,setting-overwrites,omitempty"`
+
 	// Whether this workspace allows speculative plans. Setting this to false
 	// prevents Terraform Cloud or the Terraform Enterprise instance from
 	// running plans on pull requests, which can improve security if the VCS
@@ -300,6 +510,11 @@ type WorkspaceCreateOptions struct {
 	// tracked for changes. See FileTriggersEnabled above for more details.
 	TriggerPrefixes []string `jsonapi:"attr,trigger-prefixes,omitempty"`
 
+	// List of glob patterns describing the files which must contain changes
+	// for a VCS push to trigger a run. Can't be used together with
+	// TriggerPrefixes. See FileTriggersEnabled above for more details.
+	TriggerPatterns []string `jsonapi:"attr,trigger-patterns,omitempty"`
+
 	// Settings for the workspace's VCS repository. If omitted, the workspace is
 	// created without a VCS repo. If included, you must specify at least the
 	// oauth-token-id and identifier keys below.
@@ -318,6 +533,7 @@ type VCSRepoOptions struct {
 	Identifier        *string `json:"identifier,omitempty"`
 	IngressSubmodules *bool   `json:"ingress-submodules,omitempty"`
 	OAuthTokenID      *string `json:"oauth-token-id,omitempty"`
+	TagsRegex         *string `json:"tags-regex,omitempty"`
 }
 
 func (o WorkspaceCreateOptions) Valid() error {
@@ -339,6 +555,12 @@ func (o WorkspaceCreateOptions) Valid() error {
 	if o.AgentPoolID == nil && (o.ExecutionMode != nil && *o.ExecutionMode == "agent") {
 		return errors.New("'agent' execution mode requires an agent pool ID to be specified")
 	}
+	if len(o.TriggerPrefixes) > 0 && len(o.TriggerPatterns) > 0 {
+		return ErrUnsupportedBothTriggerPatternsAndPrefixes
+	}
+	if o.AutoDestroyActivityDuration != nil && !validActivityDuration(*o.AutoDestroyActivityDuration) {
+		return ErrInvalidAutoDestroyActivityDuration
+	}
 
 	return nil
 }
@@ -352,6 +574,14 @@ func (s *workspaces) Create(ctx context.Context, organization string, options Wo
 		return nil, err
 	}
 
+	// Record the source configured for the client, unless overridden.
+	if options.SourceName == nil && s.client.sourceName != "" {
+		options.SourceName = String(s.client.sourceName)
+	}
+	if options.SourceURL == nil && s.client.sourceURL != "" {
+		options.SourceURL = String(s.client.sourceURL)
+	}
+
 	u := fmt.Sprintf("organizations/%s/workspaces", url.QueryEscape(organization))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
@@ -367,6 +597,48 @@ func (s *workspaces) Create(ctx context.Context, organization string, options Wo
 	return w, nil
 }
 
+// CreateMany creates a workspace for each of the given options, creating at
+// most concurrency workspaces at the same time (one when concurrency is zero
+// or less). The created workspaces and the errors are returned in the order of
+// the inputs: for each input either the workspace or the error is set.
+// Workspaces which weren't created yet when ctx is done fail with ctx.Err().
+func (s *workspaces) CreateMany(ctx context.Context, organization string, inputs []WorkspaceCreateOptions, concurrency int) ([]*Workspace, []error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ws := make([]*Workspace, len(inputs))
+	errs := make([]error, len(inputs))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range inputs {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			ws[i], errs[i] = s.Create(ctx, organization, inputs[i])
+		}(i)
+	}
+	wg.Wait()
+
+	return ws, errs
+}
+
 // Read a workspace by its name.
 func (s *workspaces) Read(ctx context.Context, organization, workspace string) (*Workspace, error) {
 	if !validStringID(&organization) {
@@ -389,6 +661,9 @@ func (s *workspaces) Read(ctx context.Context, organization, workspace string) (
 	w := &Workspace{}
 	err = s.client.do(ctx, req, w)
 	if err != nil {
+		if err == ErrResourceNotFound {
+			s.client.workspaceIDs.forget(organization, workspace)
+		}
 		return nil, err
 	}
 
@@ -453,6 +728,34 @@ func (s *workspaces) Readme(ctx context.Context, workspaceID string) (io.Reader,
 	return strings.NewReader(r.Readme.RawMarkdown), nil
 }
 
+// LatestRunLogs streams the plan and apply logs of the current run of a
+// workspace to w until the run is finished, or of its latest run if there is
+// no current run. It returns ErrNoRuns if the workspace has never run.
+func (s *workspaces) LatestRunLogs(ctx context.Context, workspaceID string, w io.Writer) error {
+	ws, err := s.ReadByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	var runID string
+	if ws.CurrentRun != nil {
+		runID = ws.CurrentRun.ID
+	} else {
+		rl, err := s.client.Runs.List(ctx, workspaceID, RunListOptions{
+			ListOptions: ListOptions{PageSize: 1},
+		})
+		if err != nil {
+			return err
+		}
+		if len(rl.Items) == 0 {
+			return ErrNoRuns
+		}
+		runID = rl.Items[0].ID
+	}
+
+	return s.client.Runs.Tail(ctx, runID, w)
+}
+
 // WorkspaceUpdateOptions represents the options for updating a workspace.
 type WorkspaceUpdateOptions struct {
 	// Type is a public field utilized by JSON:API to
@@ -469,9 +772,26 @@ type WorkspaceUpdateOptions struct {
 	// Whether destroy plans can be queued on the workspace.
 	AllowDestroyPlan *bool `jsonapi:"attr,allow-destroy-plan,omitempty"`
 
+	// Whether health assessments, such as drift detection, run for the
+	// workspace. It has no effect when the organization enforces them.
+	AssessmentsEnabled *bool `jsonapi:"attr,assessments-enabled,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attr,auto-apply,omitempty"`
 
+	// Whether to automatically apply changes for runs created by run
+	// triggers from another workspace.
+	AutoApplyRunTrigger *bool `jsonapi:"attr,auto-apply-run-trigger,omitempty"`
+
+	// The time at which all the resources of the workspace are destroyed.
+	// Use RemoveAutoDestroy to clear it.
+	AutoDestroyAt *time.Time `jsonapi:"attr,auto-destroy-at,iso8601,omitempty"`
+
+	// The duration of inactivity after which all the resources of the
+	// workspace are destroyed, as a number of days or hours such as "14d" or
+	// "12h". Use RemoveAutoDestroy to clear it.
+	AutoDestroyActivityDuration *string `jsonapi:"attr,auto-destroy-activity-duration,omitempty"`
+
 	// A new name for the workspace, which can only include letters, numbers, -,
 	// and _. This will be used as an identifier and must be unique in the
 	// organization. Warning: Changing a workspace's name changes its URL in the
@@ -483,7 +803,8 @@ type WorkspaceUpdateOptions struct {
 
 	// Which execution mode to use. Valid values are remote, local, and agent.
 	// When set to local, the workspace will be used for state storage only.
-	// This value must not be specified if operations is specified.
+	// This value must not be specified if operations is specified; servers
+	// not supporting execution modes must be sent operations instead.
 	// 'agent' execution mode is not available in Terraform Enterprise.
 	ExecutionMode *string `jsonapi:"attr,execution-mode,omitempty"`
 
@@ -496,13 +817,17 @@ type WorkspaceUpdateOptions struct {
 	GlobalRemoteState *bool `jsonapi:"attr,global-remote-state,omitempty"`
 
 	// DEPRECATED. Whether the workspace will use remote or local execution mode.
-	// Use ExecutionMode instead.
+	// Use ExecutionMode instead, unless the server doesn't support it.
 	Operations *bool `jsonapi:"attr,operations,omitempty"`
 
 	// Whether to queue all runs. Unless this is set to true, runs triggered by
 	// a webhook will not be queued until at least one run is manually queued.
 	QueueAllRuns *bool `jsonapi:"attr,queue-all-runs,omitempty"`
 
+	// Which settings overwrite the defaults of the project the workspace is
+	// in, rather than being inherited from it.
+	SettingOverwrites *WorkspaceSettingOverwritesOptions `jsonapi:"attr,setting-overwrites,omitempty"`
+
 	// Whether this workspace allows speculative plans. Setting this to false
 	// prevents Terraform Cloud or the Terraform Enterprise instance from
 	// running plans on pull requests, which can improve security if the VCS
@@ -522,6 +847,11 @@ type WorkspaceUpdateOptions struct {
 	// tracked for changes. See FileTriggersEnabled above for more details.
 	TriggerPrefixes []string `jsonapi:"attr,trigger-prefixes,omitempty"`
 
+	// List of glob patterns describing the files which must contain changes
+	// for a VCS push to trigger a run. Can't be used together with
+	// TriggerPrefixes. See FileTriggersEnabled above for more details.
+	TriggerPatterns []string `jsonapi:"attr,trigger-patterns,omitempty"`
+
 	// To delete a workspace's existing VCS repo, specify null instead of an
 	// object. To modify a workspace's existing VCS repo, include whichever of
 	// the keys below you wish to modify. To add a new VCS repo to a workspace
@@ -549,6 +879,12 @@ func (o WorkspaceUpdateOptions) Valid() error {
 	if o.AgentPoolID == nil && (o.ExecutionMode != nil && *o.ExecutionMode == "agent") {
 		return errors.New("'agent' execution mode requires an agent pool ID to be specified")
 	}
+	if len(o.TriggerPrefixes) > 0 && len(o.TriggerPatterns) > 0 {
+		return ErrUnsupportedBothTriggerPatternsAndPrefixes
+	}
+	if o.AutoDestroyActivityDuration != nil && !validActivityDuration(*o.AutoDestroyActivityDuration) {
+		return ErrInvalidAutoDestroyActivityDuration
+	}
 
 	return nil
 }
@@ -581,6 +917,10 @@ func (s *workspaces) Update(ctx context.Context, organization, workspace string,
 		return nil, err
 	}
 
+	if w.Name != workspace {
+		s.client.workspaceIDs.forget(organization, workspace)
+	}
+
 	return w, nil
 }
 
@@ -589,6 +929,9 @@ func (s *workspaces) UpdateByID(ctx context.Context, workspaceID string, options
 	if !validStringID(&workspaceID) {
 		return nil, ErrInvalidWorkspaceID
 	}
+	if err := options.Valid(); err != nil {
+		return nil, err
+	}
 
 	u := fmt.Sprintf("workspaces/%s", url.QueryEscape(workspaceID))
 	req, err := s.client.newRequest("PATCH", u, &options)
@@ -602,6 +945,9 @@ func (s *workspaces) UpdateByID(ctx context.Context, workspaceID string, options
 		return nil, err
 	}
 
+	// The workspace might have been renamed.
+	s.client.workspaceIDs.forgetID(workspaceID)
+
 	return w, nil
 }
 
@@ -624,7 +970,14 @@ func (s *workspaces) Delete(ctx context.Context, organization, workspace string)
 		return err
 	}
 
-	return s.client.do(ctx, req, nil)
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	s.client.workspaceIDs.forget(organization, workspace)
+
+	return nil
 }
 
 // DeleteByID deletes a workspace by its ID.
@@ -639,7 +992,14 @@ func (s *workspaces) DeleteByID(ctx context.Context, workspaceID string) error {
 		return err
 	}
 
-	return s.client.do(ctx, req, nil)
+	err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	s.client.workspaceIDs.forgetID(workspaceID)
+
+	return nil
 }
 
 // workspaceRemoveVCSConnectionOptions
@@ -699,6 +1059,77 @@ func (s *workspaces) RemoveVCSConnectionByID(ctx context.Context, workspaceID st
 	return w, nil
 }
 
+// EnableAutoApply enables auto apply on a workspace, leaving its other
+// settings untouched.
+func (s *workspaces) EnableAutoApply(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return s.UpdateByID(ctx, workspaceID, WorkspaceUpdateOptions{AutoApply: Bool(true)})
+}
+
+// DisableAutoApply disables auto apply on a workspace, leaving its other
+// settings untouched.
+func (s *workspaces) DisableAutoApply(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return s.UpdateByID(ctx, workspaceID, WorkspaceUpdateOptions{AutoApply: Bool(false)})
+}
+
+// workspaceRemoveAutoDestroyOptions sends null auto destroy settings.
+type workspaceRemoveAutoDestroyOptions struct {
+	ID                          string     `jsonapi:"primary,workspaces"`
+	AutoDestroyAt               *time.Time `jsonapi:"attr,auto-destroy-at,iso8601"`
+	AutoDestroyActivityDuration *string    `jsonapi:"attr,auto-destroy-activity-duration"`
+}
+
+// RemoveAutoDestroy clears the auto destroy time and the auto destroy
+// activity duration of a workspace.
+func (s *workspaces) RemoveAutoDestroy(ctx context.Context, workspaceID string) (*Workspace, error) {
+	if !validStringID(&workspaceID) {
+		return nil, ErrInvalidWorkspaceID
+	}
+
+	u := fmt.Sprintf("workspaces/%s", url.QueryEscape(workspaceID))
+
+	req, err := s.client.newRequest("PATCH", u, &workspaceRemoveAutoDestroyOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{}
+	err = s.client.do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// WaitForUnlock polls the workspace every interval until it is unlocked or
+// ctx is done. When interval is zero or less, it backs off between polls.
+func (s *workspaces) WaitForUnlock(ctx context.Context, workspaceID string, interval time.Duration) error {
+	if !validStringID(&workspaceID) {
+		return ErrInvalidWorkspaceID
+	}
+
+	for i := 0; ; i++ {
+		start := time.Now()
+
+		w, err := s.ReadByID(ctx, workspaceID)
+		if err != nil {
+			return err
+		}
+		if !w.Locked {
+			return nil
+		}
+
+		delay := interval
+		if delay <= 0 {
+			delay = backoff(500, 2000, i)
+		}
+
+		if err := pollWait(ctx, start, delay); err != nil {
+			return err
+		}
+	}
+}
+
 // WorkspaceLockOptions represents the options for locking a workspace.
 type WorkspaceLockOptions struct {
 	// Specifies the reason for locking the workspace.
@@ -975,3 +1406,155 @@ func (s *workspaces) UpdateRemoteStateConsumers(ctx context.Context, workspaceID
 
 	return s.client.do(ctx, req, nil)
 }
+
+// ListPolicySets lists the policy sets which apply to a workspace, including
+// the global policy sets of its organization.
+//
+// The API doesn't relate workspaces to their policy sets, so the policy sets
+// of the organization are read page by page and filtered instead, which costs
+// a request per 100 policy sets. The filtered policy sets are then paginated
+// according to options.
+func (s *workspaces) ListPolicySets(ctx context.Context, workspaceID string, options ListOptions) (*PolicySetList, error) {
+	if !validStringID(&workspaceID) {
+		return nil, ErrInvalidWorkspaceID
+	}
+
+	w, err := s.ReadByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if w.Organization == nil {
+		return nil, fmt.Errorf("workspace %s does not have an organization", workspaceID)
+	}
+
+	var policySets []*PolicySet
+	for page := 1; page != 0; {
+		psl, err := s.client.PolicySets.List(ctx, w.Organization.Name, PolicySetListOptions{
+			ListOptions: ListOptions{PageNumber: page, PageSize: 100},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ps := range psl.Items {
+			if ps.Global || policySetAppliesTo(ps, workspaceID) {
+				policySets = append(policySets, ps)
+			}
+		}
+
+		page = 0
+		if psl.Pagination != nil {
+			page = psl.NextPage
+		}
+	}
+
+	// Use the same defaults as the API.
+	pageNumber := options.PageNumber
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	pagination := &Pagination{
+		CurrentPage: pageNumber,
+		TotalCount:  len(policySets),
+		TotalPages:  (len(policySets) + pageSize - 1) / pageSize,
+	}
+	if pageNumber > 1 {
+		pagination.PreviousPage = pageNumber - 1
+	}
+	if pageNumber < pagination.TotalPages {
+		pagination.NextPage = pageNumber + 1
+	}
+
+	psl := &PolicySetList{Pagination: pagination}
+	if start := (pageNumber - 1) * pageSize; start < len(policySets) {
+		end := start + pageSize
+		if end > len(policySets) {
+			end = len(policySets)
+		}
+		psl.Items = policySets[start:end]
+	}
+
+	return psl, nil
+}
+
+// policySetAppliesTo returns true if the policy set is attached to the
+// workspace with the given ID.
+func policySetAppliesTo(ps *PolicySet, workspaceID string) bool {
+	for _, w := range ps.Workspaces {
+		if w.ID == workspaceID {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveTagBinding represents a key/value tag bound to a workspace, either
+// directly or through its project.
+type EffectiveTagBinding struct {
+	ID    string `jsonapi:"primary,effective-tag-bindings"`
+	Key   string `jsonapi:"attr,key"`
+	Value string `jsonapi:"attr,value"`
+
+	// The ID of the project the tag is inherited from, or empty when the tag
+	// is assigned to the workspace directly.
+	InheritedFrom string
+}
+
+// Inherited returns true if the tag is inherited from the project of the
+// workspace rather than assigned to it directly.
+func (t *EffectiveTagBinding) Inherited() bool {
+	return t.InheritedFrom != ""
+}
+
+// unmarshalRawResource implements rawResourceUnmarshaler.
+func (t *EffectiveTagBinding) unmarshalRawResource(r *rawResource) error {
+	// The link to the project is the only way to tell an inherited tag.
+	if link, ok := r.Links["inherited-from"].(string); ok && link != "" {
+		t.InheritedFrom = path.Base(link)
+	}
+	return nil
+}
+
+// effectiveTagBindingList represents a page of the effective tags of a
+// workspace.
+type effectiveTagBindingList struct {
+	*Pagination
+	Items []*EffectiveTagBinding
+}
+
+// EffectiveTags lists the tags which apply to a workspace, both those
+// assigned to it directly and those inherited from its project.
+func (s *workspaces) EffectiveTags(ctx context.Context, workspaceID string) ([]*EffectiveTagBinding, error) {
+	if !validStringID(&workspaceID) {
+		return nil, ErrInvalidWorkspaceID
+	}
+
+	u := fmt.Sprintf("workspaces/%s/effective-tag-bindings", url.QueryEscape(workspaceID))
+
+	var tags []*EffectiveTagBinding
+	for page := 1; page != 0; {
+		req, err := s.client.newRequest("GET", u, &ListOptions{PageNumber: page, PageSize: 100})
+		if err != nil {
+			return nil, err
+		}
+
+		tl := &effectiveTagBindingList{}
+		err = s.client.do(ctx, req, tl)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tl.Items...)
+
+		page = 0
+		if tl.Pagination != nil {
+			page = tl.NextPage
+		}
+	}
+
+	return tags, nil
+}
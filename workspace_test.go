@@ -5,7 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -108,6 +113,7 @@ func TestWorkspacesCreate(t *testing.T) {
 			Name:                       String("foo"),
 			AllowDestroyPlan:           Bool(false),
 			AutoApply:                  Bool(true),
+			AutoApplyRunTrigger:        Bool(true),
 			Description:                String("qux"),
 			FileTriggersEnabled:        Bool(true),
 			Operations:                 Bool(true),
@@ -137,6 +143,7 @@ func TestWorkspacesCreate(t *testing.T) {
 			assert.Equal(t, *options.Description, item.Description)
 			assert.Equal(t, *options.AllowDestroyPlan, item.AllowDestroyPlan)
 			assert.Equal(t, *options.AutoApply, item.AutoApply)
+			assert.Equal(t, *options.AutoApplyRunTrigger, item.AutoApplyRunTrigger)
 			assert.Equal(t, *options.FileTriggersEnabled, item.FileTriggersEnabled)
 			assert.Equal(t, *options.Operations, item.Operations)
 			assert.Equal(t, *options.QueueAllRuns, item.QueueAllRuns)
@@ -206,6 +213,31 @@ func TestWorkspacesCreate(t *testing.T) {
 		assert.EqualError(t, err, "'agent' execution mode requires an agent pool ID to be specified")
 	})
 
+	t.Run("with trigger patterns", func(t *testing.T) {
+		options := WorkspaceCreateOptions{
+			Name:                String(randomString(t)),
+			FileTriggersEnabled: Bool(true),
+			TriggerPatterns:     []string{"/modules/**/*", "*.tf"},
+		}
+
+		w, err := client.Workspaces.Create(ctx, orgTest.Name, options)
+		require.NoError(t, err)
+		assert.Equal(t, options.TriggerPatterns, w.TriggerPatterns)
+		assert.Empty(t, w.TriggerPrefixes)
+	})
+
+	t.Run("when options includes both trigger patterns and trigger prefixes", func(t *testing.T) {
+		options := WorkspaceCreateOptions{
+			Name:            String("foo"),
+			TriggerPatterns: []string{"/modules/**/*"},
+			TriggerPrefixes: []string{"/modules"},
+		}
+
+		w, err := client.Workspaces.Create(ctx, orgTest.Name, options)
+		assert.Nil(t, w)
+		assert.Equal(t, err, ErrUnsupportedBothTriggerPatternsAndPrefixes)
+	})
+
 	t.Run("when an error is returned from the API", func(t *testing.T) {
 		w, err := client.Workspaces.Create(ctx, "bar", WorkspaceCreateOptions{
 			Name:             String("bar"),
@@ -390,6 +422,7 @@ func TestWorkspacesUpdate(t *testing.T) {
 			Name:                       String(randomString(t)),
 			AllowDestroyPlan:           Bool(true),
 			AutoApply:                  Bool(false),
+			AutoApplyRunTrigger:        Bool(true),
 			FileTriggersEnabled:        Bool(true),
 			Operations:                 Bool(false),
 			QueueAllRuns:               Bool(false),
@@ -415,6 +448,7 @@ func TestWorkspacesUpdate(t *testing.T) {
 			assert.Equal(t, *options.Name, item.Name)
 			assert.Equal(t, *options.AllowDestroyPlan, item.AllowDestroyPlan)
 			assert.Equal(t, *options.AutoApply, item.AutoApply)
+			assert.Equal(t, *options.AutoApplyRunTrigger, item.AutoApplyRunTrigger)
 			assert.Equal(t, *options.FileTriggersEnabled, item.FileTriggersEnabled)
 			assert.Equal(t, *options.Description, item.Description)
 			assert.Equal(t, *options.Operations, item.Operations)
@@ -427,6 +461,17 @@ func TestWorkspacesUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("when options includes both trigger patterns and trigger prefixes", func(t *testing.T) {
+		options := WorkspaceUpdateOptions{
+			TriggerPatterns: []string{"/modules/**/*"},
+			TriggerPrefixes: []string{"/modules"},
+		}
+
+		wAfter, err := client.Workspaces.Update(ctx, orgTest.Name, wTest.Name, options)
+		assert.Nil(t, wAfter)
+		assert.Equal(t, err, ErrUnsupportedBothTriggerPatternsAndPrefixes)
+	})
+
 	t.Run("when options includes both an operations value and an enforcement mode value", func(t *testing.T) {
 		options := WorkspaceUpdateOptions{
 			ExecutionMode: String("remote"),
@@ -1043,7 +1088,13 @@ func TestWorkspace_Unmarshal(t *testing.T) {
 				"actions": map[string]interface{}{
 					"is-destroyable": true,
 				},
-				"trigger-prefixes": []string{"prefix-"},
+				"trigger-prefixes":               []string{"prefix-"},
+				"auto-destroy-at":                "2020-08-15T12:00:00.000Z",
+				"auto-destroy-activity-duration": "14d",
+				"setting-overwrites": map[string]interface{}{
+					"execution-mode": true,
+					"agent-pool":     false,
+				},
 			},
 		},
 	}
@@ -1075,13 +1126,20 @@ func TestWorkspace_Unmarshal(t *testing.T) {
 	assert.Equal(t, ws.VCSRepo.ServiceProvider, "github")
 	assert.Equal(t, ws.Actions.IsDestroyable, true)
 	assert.Equal(t, ws.TriggerPrefixes, []string{"prefix-"})
+	require.NotNil(t, ws.AutoDestroyAt)
+	assert.Equal(t, time.Date(2020, 8, 15, 12, 0, 0, 0, time.UTC), ws.AutoDestroyAt.UTC())
+	assert.Equal(t, "14d", ws.AutoDestroyActivityDuration)
+	require.NotNil(t, ws.SettingOverwrites)
+	assert.Equal(t, Bool(true), ws.SettingOverwrites.ExecutionMode)
+	assert.Equal(t, Bool(false), ws.SettingOverwrites.AgentPool)
 }
 
 func TestWorkspaceCreateOptions_Marshal(t *testing.T) {
 	opts := WorkspaceCreateOptions{
-		AllowDestroyPlan: Bool(true),
-		Name:             String("my-workspace"),
-		TriggerPrefixes:  []string{"prefix-"},
+		AllowDestroyPlan:   Bool(true),
+		AssessmentsEnabled: Bool(true),
+		Name:               String("my-workspace"),
+		TriggerPrefixes:    []string{"prefix-"},
 		VCSRepo: &VCSRepoOptions{
 			Identifier:   String("id"),
 			OAuthTokenID: String("token"),
@@ -1095,7 +1153,721 @@ func TestWorkspaceCreateOptions_Marshal(t *testing.T) {
 	bodyBytes, err := req.BodyBytes()
 	require.NoError(t, err)
 
-	expectedBody := `{"data":{"type":"workspaces","attributes":{"allow-destroy-plan":true,"name":"my-workspace","trigger-prefixes":["prefix-"],"vcs-repo":{"identifier":"id","oauth-token-id":"token"}}}}
+	expectedBody := `{"data":{"type":"workspaces","attributes":{"allow-destroy-plan":true,"assessments-enabled":true,"name":"my-workspace","trigger-prefixes":["prefix-"],"vcs-repo":{"identifier":"id","oauth-token-id":"token"}}}}
 `
 	assert.Equal(t, expectedBody, string(bodyBytes))
 }
+
+func TestWorkspacesExecutionMode(t *testing.T) {
+	var attributes map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Declare an old API version, which must not change what is sent.
+		w.Header().Set("TFP-API-Version", "2.2")
+		if r.URL.Path == "/api/v2/ping" {
+			w.WriteHeader(204)
+			return
+		}
+
+		var body struct {
+			Data struct {
+				Attributes map[string]interface{} `json:"attributes"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		attributes = body.Data.Attributes
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces"}}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{Address: ts.URL, Token: "dummy-token", HTTPClient: ts.Client()})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("with an execution mode", func(t *testing.T) {
+		_, err := client.Workspaces.Create(ctx, "foo", WorkspaceCreateOptions{
+			Name:          String("bar"),
+			ExecutionMode: String("local"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "local", attributes["execution-mode"])
+		assert.NotContains(t, attributes, "operations")
+	})
+
+	t.Run("with operations", func(t *testing.T) {
+		_, err := client.Workspaces.UpdateByID(ctx, "ws-123", WorkspaceUpdateOptions{
+			Operations: Bool(false),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, false, attributes["operations"])
+		assert.NotContains(t, attributes, "execution-mode")
+	})
+}
+
+func TestWorkspacesLatestRunLogs(t *testing.T) {
+	planLog := "\x02Terraform v1.0.0\nNo changes. Infrastructure is up-to-date.\x03"
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123", "/api/v2/workspaces/ws-empty":
+			checkedWrite(t, w, []byte(`{"data":{"id":"`+strings.TrimPrefix(r.URL.Path, "/api/v2/workspaces/")+`","type":"workspaces"}}`))
+		case "/api/v2/workspaces/ws-123/runs":
+			checkedWrite(t, w, []byte(`{"data":[{"id":"run-123","type":"runs"}]}`))
+		case "/api/v2/workspaces/ws-empty/runs":
+			checkedWrite(t, w, []byte(`{"data":[]}`))
+		case "/api/v2/runs/run-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"run-123","type":"runs","attributes":{"status":"planned_and_finished"},`+
+				`"relationships":{"plan":{"data":{"id":"plan-123","type":"plans"}}}}}`))
+		case "/api/v2/plans/plan-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"plan-123","type":"plans","attributes":{"status":"finished","log-read-url":"http://`+r.Host+`/logs/plan"}}}`))
+		case "/logs/plan":
+			if r.URL.Query().Get("offset") == "0" {
+				checkedWrite(t, w, []byte(planLog))
+			}
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with a workspace which has run", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		err := client.Workspaces.LatestRunLogs(ctx, "ws-123", buf)
+		require.NoError(t, err)
+		assert.Equal(t, "Terraform v1.0.0\nNo changes. Infrastructure is up-to-date.", buf.String())
+	})
+
+	t.Run("with a workspace which has never run", func(t *testing.T) {
+		err := client.Workspaces.LatestRunLogs(ctx, "ws-empty", new(bytes.Buffer))
+		assert.Equal(t, ErrNoRuns, err)
+	})
+
+	t.Run("with invalid workspace ID", func(t *testing.T) {
+		err := client.Workspaces.LatestRunLogs(ctx, badIdentifier, new(bytes.Buffer))
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestWorkspacesLockedBy(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"locked":true},`+
+				`"relationships":{"locked-by":{"data":{"id":"user-123","type":"users"}}}}}`))
+		case "/api/v2/organizations/acme/workspaces":
+			checkedWrite(t, w, []byte(`{"data":[`+
+				`{"id":"ws-1","type":"workspaces","attributes":{"locked":true},"relationships":{"locked-by":{"data":{"id":"run-123","type":"runs"}}}},`+
+				`{"id":"ws-2","type":"workspaces","attributes":{"locked":false},"relationships":{"locked-by":{"data":null}}},`+
+				`{"id":"ws-3","type":"workspaces","attributes":{"locked":true},"relationships":{"locked-by":{"data":{"id":"team-123","type":"teams"}}}}`+
+				`],"meta":{"pagination":{"current-page":1,"total-pages":1,"total-count":3}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when reading a workspace", func(t *testing.T) {
+		w, err := client.Workspaces.ReadByID(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.True(t, w.Locked)
+		require.NotNil(t, w.LockedBy)
+		require.NotNil(t, w.LockedBy.User)
+		assert.Equal(t, "user-123", w.LockedBy.User.ID)
+		assert.Nil(t, w.LockedBy.Run)
+		assert.Nil(t, w.LockedBy.Team)
+	})
+
+	t.Run("when listing workspaces", func(t *testing.T) {
+		wl, err := client.Workspaces.List(ctx, "acme", WorkspaceListOptions{})
+		require.NoError(t, err)
+		require.Len(t, wl.Items, 3)
+
+		require.NotNil(t, wl.Items[0].LockedBy)
+		require.NotNil(t, wl.Items[0].LockedBy.Run)
+		assert.Equal(t, "run-123", wl.Items[0].LockedBy.Run.ID)
+
+		assert.Nil(t, wl.Items[1].LockedBy)
+
+		require.NotNil(t, wl.Items[2].LockedBy)
+		require.NotNil(t, wl.Items[2].LockedBy.Team)
+		assert.Equal(t, "team-123", wl.Items[2].LockedBy.Team.ID)
+	})
+}
+
+func TestWorkspacesWaitForUnlock(t *testing.T) {
+	var reads, unlockAfter int
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			reads++
+			locked := unlockAfter == 0 || reads <= unlockAfter
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"locked":`+strconv.FormatBool(locked)+`}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when the workspace gets unlocked", func(t *testing.T) {
+		reads, unlockAfter = 0, 2
+
+		err := client.Workspaces.WaitForUnlock(ctx, "ws-123", 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, 3, reads)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		reads, unlockAfter = 0, 0
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		err := client.Workspaces.WaitForUnlock(ctx, "ws-123", 10*time.Millisecond)
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		err := client.Workspaces.WaitForUnlock(ctx, badIdentifier, time.Second)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestWorkspacesAutoDestroy(t *testing.T) {
+	var attributes map[string]interface{}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			attributes = body.Data.Attributes
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces"}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when setting auto destroy", func(t *testing.T) {
+		destroyAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		_, err := client.Workspaces.UpdateByID(ctx, "ws-123", WorkspaceUpdateOptions{
+			AutoDestroyAt:               &destroyAt,
+			AutoDestroyActivityDuration: String("14d"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"auto-destroy-at":                "2030-01-02T03:04:05Z",
+			"auto-destroy-activity-duration": "14d",
+		}, attributes)
+	})
+
+	t.Run("when removing auto destroy", func(t *testing.T) {
+		_, err := client.Workspaces.RemoveAutoDestroy(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"auto-destroy-at":                nil,
+			"auto-destroy-activity-duration": nil,
+		}, attributes)
+	})
+
+	t.Run("with an invalid activity duration", func(t *testing.T) {
+		for _, duration := range []string{"", "14", "0d", "2w", "1.5h"} {
+			_, err := client.Workspaces.UpdateByID(ctx, "ws-123", WorkspaceUpdateOptions{
+				AutoDestroyActivityDuration: String(duration),
+			})
+			assert.Equal(t, ErrInvalidAutoDestroyActivityDuration, err, duration)
+		}
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		_, err := client.Workspaces.RemoveAutoDestroy(ctx, badIdentifier)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestWorkspacesSettingOverwrites(t *testing.T) {
+	var attributes map[string]interface{}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			attributes = body.Data.Attributes
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"setting-overwrites":{"execution-mode":false,"agent-pool":true}}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	w, err := client.Workspaces.UpdateByID(context.Background(), "ws-123", WorkspaceUpdateOptions{
+		SettingOverwrites: &WorkspaceSettingOverwritesOptions{
+			ExecutionMode: Bool(false),
+			AgentPool:     Bool(true),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"setting-overwrites": map[string]interface{}{
+			"execution-mode": false,
+			"agent-pool":     true,
+		},
+	}, attributes)
+	require.NotNil(t, w.SettingOverwrites)
+	assert.Equal(t, Bool(false), w.SettingOverwrites.ExecutionMode)
+	assert.Equal(t, Bool(true), w.SettingOverwrites.AgentPool)
+}
+
+func TestWorkspacesList_filters(t *testing.T) {
+	var query url.Values
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme/workspaces":
+			query = r.URL.Query()
+			checkedWrite(t, w, []byte(`{"data":[{"id":"ws-123","type":"workspaces","attributes":{"name":"app-prod"}}],"meta":{"pagination":{"current-page":1,"total-pages":1,"total-count":1}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	wl, err := client.Workspaces.List(context.Background(), "acme", WorkspaceListOptions{
+		ListOptions:  ListOptions{PageSize: 50},
+		Search:       String("app"),
+		Tags:         String("prod,team-a"),
+		ExcludeTags:  String("deprecated"),
+		WildcardName: String("*-prod"),
+		ProjectID:    String("prj-123"),
+	})
+	require.NoError(t, err)
+	require.Len(t, wl.Items, 1)
+	assert.Equal(t, 1, wl.TotalCount)
+
+	assert.Equal(t, url.Values{
+		"page[size]":            []string{"50"},
+		"search[name]":          []string{"app"},
+		"search[tags]":          []string{"prod,team-a"},
+		"search[exclude-tags]":  []string{"deprecated"},
+		"search[wildcard-name]": []string{"*-prod"},
+		"filter[project][id]":   []string{"prj-123"},
+	}, query)
+}
+
+func TestWorkspacesList_currentAssessmentResult(t *testing.T) {
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme/workspaces":
+			assert.Equal(t, "current_assessment_result", r.URL.Query().Get("include"))
+			checkedWrite(t, w, []byte(`{"data":[
+				{"id":"ws-1","type":"workspaces","attributes":{"name":"drifted"},"relationships":{"current-assessment-result":{"data":{"id":"asmtres-1","type":"assessment-results"}}}},
+				{"id":"ws-2","type":"workspaces","attributes":{"name":"in-sync"},"relationships":{"current-assessment-result":{"data":{"id":"asmtres-2","type":"assessment-results"}}}},
+				{"id":"ws-3","type":"workspaces","attributes":{"name":"failed"},"relationships":{"current-assessment-result":{"data":{"id":"asmtres-3","type":"assessment-results"}}}},
+				{"id":"ws-4","type":"workspaces","attributes":{"name":"never-assessed"},"relationships":{"current-assessment-result":{"data":null}}}
+			],"included":[
+				{"id":"asmtres-1","type":"assessment-results","attributes":{"drifted":true,"succeeded":true,"created-at":"2022-08-01T10:00:00Z"}},
+				{"id":"asmtres-2","type":"assessment-results","attributes":{"drifted":false,"succeeded":true,"created-at":"2022-08-01T10:00:00Z"}},
+				{"id":"asmtres-3","type":"assessment-results","attributes":{"drifted":false,"succeeded":false,"error-msg":"plan failed","created-at":"2022-08-01T10:00:00Z"}}
+			]}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	wl, err := client.Workspaces.List(context.Background(), "acme", WorkspaceListOptions{
+		Include: String("current_assessment_result"),
+	})
+	require.NoError(t, err)
+	require.Len(t, wl.Items, 4)
+
+	assert.Equal(t, Bool(true), wl.Items[0].Drifted())
+	assert.Equal(t, Bool(false), wl.Items[1].Drifted())
+	assert.Nil(t, wl.Items[2].Drifted())
+	assert.Equal(t, "plan failed", wl.Items[2].CurrentAssessmentResult.ErrorMsg)
+	assert.Nil(t, wl.Items[3].Drifted())
+	assert.Nil(t, wl.Items[3].CurrentAssessmentResult)
+}
+
+func TestWorkspacesToggleAutoApply(t *testing.T) {
+	var attributes map[string]interface{}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			assert.Equal(t, "PATCH", r.Method)
+			var body struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			attributes = body.Data.Attributes
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","attributes":{"auto-apply":`+strconv.FormatBool(attributes["auto-apply"] == true)+`}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("when enabling auto apply", func(t *testing.T) {
+		w, err := client.Workspaces.EnableAutoApply(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.True(t, w.AutoApply)
+		assert.Equal(t, map[string]interface{}{"auto-apply": true}, attributes)
+	})
+
+	t.Run("when disabling auto apply", func(t *testing.T) {
+		w, err := client.Workspaces.DisableAutoApply(ctx, "ws-123")
+		require.NoError(t, err)
+		assert.False(t, w.AutoApply)
+		assert.Equal(t, map[string]interface{}{"auto-apply": false}, attributes)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		_, err := client.Workspaces.EnableAutoApply(ctx, badIdentifier)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestWorkspacesCreateMany(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme/workspaces":
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			var body struct {
+				Data struct {
+					Attributes struct {
+						Name string `json:"name"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+			name := body.Data.Attributes.Name
+			if name == "taken" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				checkedWrite(t, w, []byte(`{"errors":[{"status":"422","title":"invalid attribute","detail":"Name has already been taken"}]}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-`+name+`","type":"workspaces","attributes":{"name":"`+name+`"}}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	names := []string{"ws1", "ws2", "taken", "ws4", "ws5", "ws6", "ws7"}
+	inputs := make([]WorkspaceCreateOptions, len(names))
+	for i, name := range names {
+		inputs[i] = WorkspaceCreateOptions{Name: String(name)}
+	}
+	inputs = append(inputs, WorkspaceCreateOptions{Name: String(badIdentifier)})
+
+	t.Run("with bounded concurrency", func(t *testing.T) {
+		maxInFlight = 0
+
+		ws, errs := client.Workspaces.CreateMany(context.Background(), "acme", inputs, 3)
+		require.Len(t, ws, len(inputs))
+		require.Len(t, errs, len(inputs))
+
+		for i, name := range names {
+			if name == "taken" {
+				assert.Nil(t, ws[i])
+				assert.EqualError(t, errs[i], "invalid attribute\n\nName has already been taken")
+				continue
+			}
+			require.NoError(t, errs[i])
+			assert.Equal(t, "ws-"+name, ws[i].ID)
+		}
+		assert.Nil(t, ws[len(names)])
+		assert.Equal(t, ErrInvalidName, errs[len(names)])
+
+		assert.True(t, maxInFlight > 1)
+		assert.True(t, maxInFlight <= 3)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ws, errs := client.Workspaces.CreateMany(ctx, "acme", inputs[:2], 1)
+		assert.Equal(t, []*Workspace{nil, nil}, ws)
+		for _, err := range errs {
+			assert.Equal(t, context.Canceled, err)
+		}
+	})
+}
+
+func TestWorkspacesListPolicySets(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest1, wTest1Cleanup := createWorkspace(t, client, orgTest)
+	defer wTest1Cleanup()
+	wTest2, wTest2Cleanup := createWorkspace(t, client, orgTest)
+	defer wTest2Cleanup()
+
+	psTest1, psTest1Cleanup := createPolicySet(t, client, orgTest, nil, []*Workspace{wTest1})
+	defer psTest1Cleanup()
+	_, psTest2Cleanup := createPolicySet(t, client, orgTest, nil, []*Workspace{wTest2})
+	defer psTest2Cleanup()
+
+	t.Run("with a workspace with a policy set", func(t *testing.T) {
+		psl, err := client.Workspaces.ListPolicySets(ctx, wTest1.ID, ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, psl.Items, 1)
+		assert.Equal(t, psTest1.ID, psl.Items[0].ID)
+		assert.Equal(t, 1, psl.TotalCount)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		psl, err := client.Workspaces.ListPolicySets(ctx, badIdentifier, ListOptions{})
+		assert.Nil(t, psl)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestWorkspacesListPolicySets_filtering(t *testing.T) {
+	// Two pages of policy sets: the workspace is attached to ps-1 and ps-4,
+	// and ps-3 is global.
+	pages := []string{
+		`{"data":[` +
+			`{"id":"ps-1","type":"policy-sets","attributes":{"global":false},"relationships":{"workspaces":{"data":[{"id":"ws-123","type":"workspaces"}]}}},` +
+			`{"id":"ps-2","type":"policy-sets","attributes":{"global":false},"relationships":{"workspaces":{"data":[{"id":"ws-456","type":"workspaces"}]}}}` +
+			`],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":4}}}`,
+		`{"data":[` +
+			`{"id":"ps-3","type":"policy-sets","attributes":{"global":true},"relationships":{"workspaces":{"data":[]}}},` +
+			`{"id":"ps-4","type":"policy-sets","attributes":{"global":false},"relationships":{"workspaces":{"data":[{"id":"ws-456","type":"workspaces"},{"id":"ws-123","type":"workspaces"}]}}}` +
+			`],"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":4}}}`,
+	}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123":
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces","relationships":{"organization":{"data":{"id":"acme","type":"organizations"}}}}}`))
+		case "/api/v2/organizations/acme/policy-sets":
+			switch r.URL.Query().Get("page[number]") {
+			case "1":
+				checkedWrite(t, w, []byte(pages[0]))
+			case "2":
+				checkedWrite(t, w, []byte(pages[1]))
+			default:
+				assert.Fail(t, "invalid page", r.RequestURI)
+			}
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("without list options", func(t *testing.T) {
+		psl, err := client.Workspaces.ListPolicySets(ctx, "ws-123", ListOptions{})
+		require.NoError(t, err)
+
+		var ids []string
+		for _, ps := range psl.Items {
+			ids = append(ids, ps.ID)
+		}
+		assert.Equal(t, []string{"ps-1", "ps-3", "ps-4"}, ids)
+		assert.Equal(t, 1, psl.CurrentPage)
+		assert.Equal(t, 0, psl.NextPage)
+		assert.Equal(t, 1, psl.TotalPages)
+		assert.Equal(t, 3, psl.TotalCount)
+	})
+
+	t.Run("with list options", func(t *testing.T) {
+		psl, err := client.Workspaces.ListPolicySets(ctx, "ws-123", ListOptions{
+			PageNumber: 2,
+			PageSize:   2,
+		})
+		require.NoError(t, err)
+		require.Len(t, psl.Items, 1)
+		assert.Equal(t, "ps-4", psl.Items[0].ID)
+		assert.Equal(t, 2, psl.CurrentPage)
+		assert.Equal(t, 1, psl.PreviousPage)
+		assert.Equal(t, 0, psl.NextPage)
+		assert.Equal(t, 2, psl.TotalPages)
+		assert.Equal(t, 3, psl.TotalCount)
+	})
+
+	t.Run("with a page past the end", func(t *testing.T) {
+		psl, err := client.Workspaces.ListPolicySets(ctx, "ws-123", ListOptions{PageNumber: 3})
+		require.NoError(t, err)
+		assert.Empty(t, psl.Items)
+		assert.Equal(t, 3, psl.TotalCount)
+	})
+}
+
+func TestWorkspacesEffectiveTags(t *testing.T) {
+	pages := []string{
+		`{"data":[` +
+			`{"id":"etb-1","type":"effective-tag-bindings","attributes":{"key":"env","value":"prod"}},` +
+			`{"id":"etb-2","type":"effective-tag-bindings","attributes":{"key":"team","value":"platform"},"links":{"inherited-from":"/api/v2/projects/prj-123"}}` +
+			`],"meta":{"pagination":{"current-page":1,"next-page":2,"total-pages":2,"total-count":3}}}`,
+		`{"data":[` +
+			`{"id":"etb-3","type":"effective-tag-bindings","attributes":{"key":"cost-center","value":"42"},"links":{"inherited-from":"/api/v2/projects/prj-123"}}` +
+			`],"meta":{"pagination":{"current-page":2,"prev-page":1,"total-pages":2,"total-count":3}}}`,
+	}
+
+	client := testServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/workspaces/ws-123/effective-tag-bindings":
+			switch r.URL.Query().Get("page[number]") {
+			case "1":
+				checkedWrite(t, w, []byte(pages[0]))
+			case "2":
+				checkedWrite(t, w, []byte(pages[1]))
+			default:
+				assert.Fail(t, "invalid page", r.RequestURI)
+			}
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	})
+
+	ctx := context.Background()
+
+	t.Run("with direct and inherited tags", func(t *testing.T) {
+		tags, err := client.Workspaces.EffectiveTags(ctx, "ws-123")
+		require.NoError(t, err)
+		require.Len(t, tags, 3)
+
+		assert.Equal(t, "env", tags[0].Key)
+		assert.Equal(t, "prod", tags[0].Value)
+		assert.False(t, tags[0].Inherited())
+
+		assert.Equal(t, "team", tags[1].Key)
+		assert.True(t, tags[1].Inherited())
+		assert.Equal(t, "prj-123", tags[1].InheritedFrom)
+
+		assert.Equal(t, "cost-center", tags[2].Key)
+		assert.Equal(t, "prj-123", tags[2].InheritedFrom)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		tags, err := client.Workspaces.EffectiveTags(ctx, badIdentifier)
+		assert.Nil(t, tags)
+		assert.EqualError(t, err, ErrInvalidWorkspaceID.Error())
+	})
+}
+
+func TestWorkspacesCreate_source(t *testing.T) {
+	var attributes map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/ping":
+		case "/api/v2/organizations/acme/workspaces":
+			var doc struct {
+				Data struct {
+					Attributes map[string]interface{} `json:"attributes"`
+				} `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&doc))
+			attributes = doc.Data.Attributes
+
+			checkedWrite(t, w, []byte(`{"data":{"id":"ws-123","type":"workspaces"}}`))
+		default:
+			assert.Fail(t, "invalid request", "%s %s", r.Method, r.RequestURI)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+
+	create := func(t *testing.T, client *Client, options WorkspaceCreateOptions) {
+		options.Name = String("app")
+		_, err := client.Workspaces.Create(ctx, "acme", options)
+		require.NoError(t, err)
+	}
+
+	t.Run("with the default user agent", func(t *testing.T) {
+		client, err := NewClient(&Config{Address: ts.URL, Token: "123", HTTPClient: ts.Client()})
+		require.NoError(t, err)
+
+		create(t, client, WorkspaceCreateOptions{})
+		assert.NotContains(t, attributes, "source-name")
+		assert.NotContains(t, attributes, "source-url")
+	})
+
+	t.Run("with a custom user agent", func(t *testing.T) {
+		client, err := NewClientWithOptions(
+			WithAddress(ts.URL),
+			WithToken("123"),
+			WithHTTPClient(ts.Client()),
+			WithUserAgent("acme-pipeline/2.1"),
+		)
+		require.NoError(t, err)
+
+		create(t, client, WorkspaceCreateOptions{})
+		assert.Equal(t, "acme-pipeline/2.1", attributes["source-name"])
+		assert.NotContains(t, attributes, "source-url")
+	})
+
+	t.Run("with a configured source", func(t *testing.T) {
+		client, err := NewClientWithOptions(
+			WithAddress(ts.URL),
+			WithToken("123"),
+			WithHTTPClient(ts.Client()),
+			WithUserAgent("acme-pipeline/2.1"),
+			WithSource("Acme Pipeline", "https://ci.acme.com/pipelines/42"),
+		)
+		require.NoError(t, err)
+
+		create(t, client, WorkspaceCreateOptions{})
+		assert.Equal(t, "Acme Pipeline", attributes["source-name"])
+		assert.Equal(t, "https://ci.acme.com/pipelines/42", attributes["source-url"])
+
+		t.Run("overridden by the create options", func(t *testing.T) {
+			create(t, client, WorkspaceCreateOptions{
+				SourceName: String("Acme Bootstrap"),
+				SourceURL:  String("https://ci.acme.com/bootstrap"),
+			})
+			assert.Equal(t, "Acme Bootstrap", attributes["source-name"])
+			assert.Equal(t, "https://ci.acme.com/bootstrap", attributes["source-url"])
+		})
+	})
+
+	t.Run("with an invalid source", func(t *testing.T) {
+		_, err := NewClientWithOptions(WithSource("", ""))
+		assert.Equal(t, ErrRequiredName, err)
+
+		_, err = NewClientWithOptions(WithSource("Acme Pipeline", "not a url"))
+		assert.Error(t, err)
+	})
+}